@@ -2,11 +2,39 @@ package main
 
 import (
 	"fmt"
+	"os"
 
 	"geeksonator/internal/app/geeksonator"
 )
 
+const (
+	// modeSyncCommands only publishes the commands menu, e.g. `geeksonator sync-commands`.
+	modeSyncCommands = "sync-commands"
+	// modeBlocklist imports the shared blocklist, e.g. `geeksonator blocklist import spam.csv`.
+	modeBlocklist = "blocklist"
+)
+
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == modeSyncCommands {
+		if err := geeksonator.SyncCommands(); err != nil {
+			panic(fmt.Errorf("geeksonator.SyncCommands: %v", err))
+		}
+
+		return
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == modeBlocklist {
+		if len(os.Args) != 4 || os.Args[2] != "import" {
+			panic("usage: geeksonator blocklist import <file.csv|file.json>")
+		}
+
+		if err := geeksonator.ImportBlocklist(os.Args[3]); err != nil {
+			panic(fmt.Errorf("geeksonator.ImportBlocklist: %v", err))
+		}
+
+		return
+	}
+
 	if err := geeksonator.Start(); err != nil {
 		panic(fmt.Errorf("geeksonator.Start: %v", err))
 	}
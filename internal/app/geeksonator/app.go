@@ -2,9 +2,11 @@ package geeksonator
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"os/signal"
+	"path/filepath"
 	"sync"
 	"syscall"
 	"time"
@@ -12,16 +14,43 @@ import (
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 	"go.uber.org/zap"
 
+	"geeksonator/internal/audit"
+	"geeksonator/internal/blocklist"
+	"geeksonator/internal/captcha"
+	"geeksonator/internal/catalog"
+	"geeksonator/internal/federation"
+	"geeksonator/internal/filters"
+	"geeksonator/internal/flood"
+	"geeksonator/internal/members"
+	"geeksonator/internal/menu"
 	"geeksonator/internal/observer"
+	"geeksonator/internal/posts"
 	"geeksonator/internal/provider/telegram"
+	"geeksonator/internal/reports"
+	"geeksonator/internal/scheduler"
+	"geeksonator/internal/warnings"
 	cacher "geeksonator/pkg/cache"
 )
 
 const (
 	cacheMaxSize = 100
 	cacheTTL     = 24 * time.Hour
+
+	dataDirPerm  = 0o700
+	warningsFile = "warnings.json"
+	cleanupFile  = "cleanup.json"
+	filtersFile  = "filters.json"
+	membersFile  = "members.json"
+	captchaFile  = "captcha.json"
+	reportsFile  = "reports.json"
+	auditFile    = "audit.jsonl"
+	fedFile      = "federation.json"
+	blockFile    = "blocklist.json"
+	postsFile    = "posts.json"
 )
 
+var errNoDataDir = errors.New("GEEKSONATOR_DATA_DIR isn't set, the blocklist can't be saved")
+
 // Start starts the application.
 func Start() error {
 	ctx, stop := signal.NotifyContext(
@@ -45,22 +74,31 @@ func Start() error {
 	}
 	defer logger.Sync() //nolint:errcheck // it's ok
 
-	var tgBotToken string
-	if cfg.DebugMode {
-		tgBotToken = cfg.DebugTgBotToken
-	} else {
-		tgBotToken = cfg.TgBotToken
-	}
-	botAPI, err := tgbotapi.NewBotAPI(tgBotToken)
+	commands, err := catalog.Load(cfg.CatalogPath)
 	if err != nil {
-		return fmt.Errorf("tgbotapi.NewBotAPI: %v", err)
+		return fmt.Errorf("catalog.Load: %v", err)
 	}
-	logger.Info("Authorized on account",
-		zap.String("account", botAPI.Self.UserName),
+	logger.Info("Commands catalog loaded",
+		zap.String("path", cfg.CatalogPath),
+		zap.Int("commands", len(commands.Commands)),
 	)
 
+	commandsStore := catalog.NewStore(commands)
+
+	botAPI, err := newBotAPI(cfg, logger)
+	if err != nil {
+		return fmt.Errorf("newBotAPI: %v", err)
+	}
+
 	telegramService := telegram.NewService(botAPI)
 
+	menuSyncer := menu.NewSyncer(telegramService, logger)
+	if err := menuSyncer.Sync(commands); err != nil {
+		logger.Error("Commands menu sync failed",
+			zap.Error(err),
+		)
+	}
+
 	updateConfig := tgbotapi.NewUpdate(0)
 	updateConfig.Timeout = cfg.TgTimeoutSeconds // long polling
 
@@ -75,13 +113,132 @@ func Start() error {
 		return fmt.Errorf("cacher.NewCacher: %v", err)
 	}
 
+	if cfg.DataDir == "" {
+		logger.Warn("Data directory isn't set, warnings and pending deletions are kept in memory")
+	}
+
+	warningsPath, err := dataFile(cfg, warningsFile)
+	if err != nil {
+		return fmt.Errorf("dataFile: %v", err)
+	}
+
+	warningsStore, err := warnings.NewStore(warningsPath)
+	if err != nil {
+		return fmt.Errorf("warnings.NewStore: %v", err)
+	}
+
+	cleanupPath, err := dataFile(cfg, cleanupFile)
+	if err != nil {
+		return fmt.Errorf("dataFile: %v", err)
+	}
+
+	cleanupScheduler, err := scheduler.NewScheduler(cleanupPath, telegramService, logger)
+	if err != nil {
+		return fmt.Errorf("scheduler.NewScheduler: %v", err)
+	}
+
+	filtersPath, err := dataFile(cfg, filtersFile)
+	if err != nil {
+		return fmt.Errorf("dataFile: %v", err)
+	}
+
+	filtersStore, err := filters.NewStore(filtersPath)
+	if err != nil {
+		return fmt.Errorf("filters.NewStore: %v", err)
+	}
+
+	membersPath, err := dataFile(cfg, membersFile)
+	if err != nil {
+		return fmt.Errorf("dataFile: %v", err)
+	}
+
+	membersStore, err := members.NewStore(membersPath)
+	if err != nil {
+		return fmt.Errorf("members.NewStore: %v", err)
+	}
+
+	captchaPath, err := dataFile(cfg, captchaFile)
+	if err != nil {
+		return fmt.Errorf("dataFile: %v", err)
+	}
+
+	challengesStore, err := captcha.NewStore(captchaPath)
+	if err != nil {
+		return fmt.Errorf("captcha.NewStore: %v", err)
+	}
+
+	reportsPath, err := dataFile(cfg, reportsFile)
+	if err != nil {
+		return fmt.Errorf("dataFile: %v", err)
+	}
+
+	reportsStore, err := reports.NewStore(reportsPath)
+	if err != nil {
+		return fmt.Errorf("reports.NewStore: %v", err)
+	}
+
+	auditPath, err := dataFile(cfg, auditFile)
+	if err != nil {
+		return fmt.Errorf("dataFile: %v", err)
+	}
+
+	auditLog := audit.NewLog(auditPath)
+
+	fedPath, err := dataFile(cfg, fedFile)
+	if err != nil {
+		return fmt.Errorf("dataFile: %v", err)
+	}
+
+	fedStore, err := federation.NewStore(fedPath)
+	if err != nil {
+		return fmt.Errorf("federation.NewStore: %v", err)
+	}
+
+	blockPath, err := dataFile(cfg, blockFile)
+	if err != nil {
+		return fmt.Errorf("dataFile: %v", err)
+	}
+
+	blockList, err := blocklist.NewList(blockPath)
+	if err != nil {
+		return fmt.Errorf("blocklist.NewList: %v", err)
+	}
+	logger.Info("Blocklist loaded",
+		zap.Int("users", blockList.Len()),
+	)
+
+	postsPath, err := dataFile(cfg, postsFile)
+	if err != nil {
+		return fmt.Errorf("dataFile: %v", err)
+	}
+
+	postsStore, err := posts.NewStore(postsPath)
+	if err != nil {
+		return fmt.Errorf("posts.NewStore: %v", err)
+	}
+
+	floodLimiter := flood.NewLimiter()
+
 	var observerManager *observer.Manager
 	if cfg.DebugMode {
 		observerManager = observer.NewManager(
 			telegramService,
 			updatesChan,
 			cache,
+			commandsStore,
 			observer.WithDebug(logger),
+			observer.WithBotUsername(botAPI.Self.UserName),
+			observer.WithWarnings(warningsStore),
+			observer.WithScheduler(cleanupScheduler),
+			observer.WithFloodLimiter(floodLimiter),
+			observer.WithFilters(filtersStore),
+			observer.WithMembers(membersStore),
+			observer.WithChallenges(challengesStore),
+			observer.WithReports(reportsStore),
+			observer.WithAuditor(auditLog),
+			observer.WithFederation(fedStore),
+			observer.WithBlocklist(blockList),
+			observer.WithPosts(postsStore),
 			observer.WithSkipAdminCheck(),
 		)
 	} else {
@@ -89,16 +246,80 @@ func Start() error {
 			telegramService,
 			updatesChan,
 			cache,
+			commandsStore,
 			observer.WithDebug(logger),
+			observer.WithBotUsername(botAPI.Self.UserName),
+			observer.WithWarnings(warningsStore),
+			observer.WithScheduler(cleanupScheduler),
+			observer.WithFloodLimiter(floodLimiter),
+			observer.WithFilters(filtersStore),
+			observer.WithMembers(membersStore),
+			observer.WithChallenges(challengesStore),
+			observer.WithReports(reportsStore),
+			observer.WithAuditor(auditLog),
+			observer.WithFederation(fedStore),
+			observer.WithBlocklist(blockList),
+			observer.WithPosts(postsStore),
 		)
 	}
 
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
 	var wg sync.WaitGroup
 
+	// the handler is installed without the catalog file too, so SIGHUP doesn't stop the bot
+	reloadChan := make(chan os.Signal, 1)
+	signal.Notify(reloadChan, syscall.SIGHUP)
+	defer signal.Stop(reloadChan)
+
+	watcher := catalog.NewWatcher(
+		cfg.CatalogPath,
+		commandsStore,
+		cfg.CatalogReloadInterval,
+		logger,
+		catalog.WithOnReload(func(c *catalog.Catalog) {
+			if err := menuSyncer.Sync(c); err != nil {
+				logger.Error("Commands menu sync failed",
+					zap.Error(err),
+				)
+			}
+		}),
+	)
+
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
 
+		watcher.Run(ctx, reloadChan)
+		logger.Info("Catalog watcher stopped")
+	}()
+
+	blockReloadChan := make(chan os.Signal, 1)
+	signal.Notify(blockReloadChan, syscall.SIGHUP)
+	defer signal.Stop(blockReloadChan)
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		reloadBlocklist(ctx, blockList, blockReloadChan, logger)
+		logger.Info("Blocklist reloader stopped")
+	}()
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		cleanupScheduler.Run(ctx)
+		logger.Info("Cleanup scheduler stopped")
+	}()
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer cancel() // stops the other goroutines if the observer fails
+
 		err = observerManager.Run(ctx)
 		if err != nil {
 			logger.Error("Observer manager Run error",
@@ -117,6 +338,151 @@ func Start() error {
 	return nil
 }
 
+// SyncCommands publishes the commands catalog to the Telegram menu and exits.
+func SyncCommands() error {
+	cfg, err := LoadConfig()
+	if err != nil {
+		return fmt.Errorf("LoadConfig: %v", err)
+	}
+
+	logger, err := newLogger(cfg.DebugMode)
+	if err != nil {
+		return fmt.Errorf("newLogger: %v", err)
+	}
+	defer logger.Sync() //nolint:errcheck // it's ok
+
+	commands, err := catalog.Load(cfg.CatalogPath)
+	if err != nil {
+		return fmt.Errorf("catalog.Load: %v", err)
+	}
+
+	botAPI, err := newBotAPI(cfg, logger)
+	if err != nil {
+		return fmt.Errorf("newBotAPI: %v", err)
+	}
+
+	err = menu.NewSyncer(telegram.NewService(botAPI), logger).Sync(commands)
+	if err != nil {
+		return fmt.Errorf("menu.Sync: %v", err)
+	}
+
+	return nil
+}
+
+// ImportBlocklist merges the shared blocklist file in CSV or JSON into the blocklist of the data directory.
+// The running bot picks the changes up on SIGHUP or on restart.
+func ImportBlocklist(path string) error {
+	cfg, err := LoadConfig()
+	if err != nil {
+		return fmt.Errorf("LoadConfig: %v", err)
+	}
+
+	logger, err := newLogger(cfg.DebugMode)
+	if err != nil {
+		return fmt.Errorf("newLogger: %v", err)
+	}
+	defer logger.Sync() //nolint:errcheck // it's ok
+
+	if cfg.DataDir == "" {
+		return errNoDataDir
+	}
+
+	format, err := blocklist.FormatFor(path)
+	if err != nil {
+		return fmt.Errorf("blocklist.FormatFor: %v", err)
+	}
+
+	file, err := os.Open(path) //nolint:gosec // the path is given by the operator
+	if err != nil {
+		return fmt.Errorf("os.Open: %v", err)
+	}
+	defer file.Close() //nolint:errcheck // the file is only read
+
+	entries, err := blocklist.Parse(file, format)
+	if err != nil {
+		return fmt.Errorf("blocklist.Parse: %v", err)
+	}
+
+	blockPath, err := dataFile(cfg, blockFile)
+	if err != nil {
+		return fmt.Errorf("dataFile: %v", err)
+	}
+
+	blockList, err := blocklist.NewList(blockPath)
+	if err != nil {
+		return fmt.Errorf("blocklist.NewList: %v", err)
+	}
+
+	added, err := blockList.Import(entries, filepath.Base(path), time.Now())
+	if err != nil {
+		return fmt.Errorf("blockList.Import: %v", err)
+	}
+
+	logger.Info("Blocklist imported",
+		zap.String("path", path),
+		zap.Int("entries", len(entries)),
+		zap.Int("added", added),
+		zap.Int("users", blockList.Len()),
+	)
+
+	return nil
+}
+
+// reloadBlocklist reloads the blocklist imported by the import command on each value of the reload channel
+// until the context is done. The previous list is kept if the file fails to load.
+func reloadBlocklist(ctx context.Context, blockList *blocklist.List, reload <-chan os.Signal, logger *zap.Logger) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-reload:
+			if err := blockList.Reload(); err != nil {
+				logger.Error("Blocklist reload failed",
+					zap.Error(err),
+				)
+
+				continue
+			}
+
+			logger.Info("Blocklist reloaded",
+				zap.Int("users", blockList.Len()),
+			)
+		}
+	}
+}
+
+// newBotAPI creates new telegram bot API client, the debug bot is used in debug mode.
+func newBotAPI(cfg *Config, logger *zap.Logger) (*tgbotapi.BotAPI, error) {
+	tgBotToken := cfg.TgBotToken
+	if cfg.DebugMode {
+		tgBotToken = cfg.DebugTgBotToken
+	}
+
+	botAPI, err := tgbotapi.NewBotAPI(tgBotToken)
+	if err != nil {
+		return nil, fmt.Errorf("tgbotapi.NewBotAPI: %v", err)
+	}
+	logger.Info("Authorized on account",
+		zap.String("account", botAPI.Self.UserName),
+	)
+
+	return botAPI, nil
+}
+
+// dataFile returns the path of the file in the data directory and creates the directory.
+// The path is empty if the data directory isn't set, the data is kept in memory then.
+func dataFile(cfg *Config, name string) (string, error) {
+	if cfg.DataDir == "" {
+		return "", nil
+	}
+
+	if err := os.MkdirAll(cfg.DataDir, dataDirPerm); err != nil {
+		return "", fmt.Errorf("os.MkdirAll: %v", err)
+	}
+
+	return filepath.Join(cfg.DataDir, name), nil
+}
+
 // newLogger creates new logger.
 func newLogger(debugMode bool) (*zap.Logger, error) { //nolint:revive // false positive
 	if debugMode {
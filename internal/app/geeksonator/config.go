@@ -2,16 +2,20 @@ package geeksonator
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/caarlos0/env/v10"
 )
 
 // Config represents application configuration.
 type Config struct {
-	TgBotToken       string `env:"GEEKSONATOR_TELEGRAM_BOT_TOKEN"`
-	TgTimeoutSeconds int    `env:"GEEKSONATOR_TELEGRAM_TIMEOUT_SECONDS" envDefault:"15"`
-	DebugMode        bool   `env:"GEEKSONATOR_DEBUG_MODE"`
-	DebugTgBotToken  string `env:"GEEKSONATOR_DEBUG_TELEGRAM_BOT_TOKEN"`
+	TgBotToken            string        `env:"GEEKSONATOR_TELEGRAM_BOT_TOKEN"`
+	TgTimeoutSeconds      int           `env:"GEEKSONATOR_TELEGRAM_TIMEOUT_SECONDS" envDefault:"15"`
+	DebugMode             bool          `env:"GEEKSONATOR_DEBUG_MODE"`
+	DebugTgBotToken       string        `env:"GEEKSONATOR_DEBUG_TELEGRAM_BOT_TOKEN"`
+	CatalogPath           string        `env:"GEEKSONATOR_CATALOG_PATH"`
+	CatalogReloadInterval time.Duration `env:"GEEKSONATOR_CATALOG_RELOAD_INTERVAL" envDefault:"10s"`
+	DataDir               string        `env:"GEEKSONATOR_DATA_DIR"`
 }
 
 // LoadConfig loads application configuration.
@@ -0,0 +1,106 @@
+package audit
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// filePerm is the permission of the log file.
+const filePerm = 0o600
+
+// Action is the moderation action.
+type Action string
+
+// The actions of the events, the automatic actions of the bot are filter, flood, newcomer, captcha, blocklist, jobpost and repost.
+const (
+	ActionBan       Action = "ban"
+	ActionUnban     Action = "unban"
+	ActionMute      Action = "mute"
+	ActionUnmute    Action = "unmute"
+	ActionWarn      Action = "warn"
+	ActionUnwarn    Action = "unwarn"
+	ActionDelete    Action = "delete"
+	ActionFilter    Action = "filter"
+	ActionFlood     Action = "flood"
+	ActionNewcomer  Action = "newcomer"
+	ActionCaptcha   Action = "captcha"
+	ActionFban      Action = "fban"
+	ActionFunban    Action = "funban"
+	ActionBlocklist Action = "blocklist"
+	ActionJobPost   Action = "jobpost"
+	ActionRepost    Action = "repost"
+)
+
+// Event is the moderation action taken through the bot.
+type Event struct {
+	// Date is the date of the action.
+	Date time.Time `json:"date"`
+	// Action is the action.
+	Action Action `json:"action"`
+	// ChatID and Chat are the ID and the title of the chat.
+	ChatID int64  `json:"chatId"`
+	Chat   string `json:"chat,omitempty"`
+	// ActorID and Actor are the ID and the name of the admin, zero and empty for the actions of the bot.
+	ActorID int64  `json:"actorId,omitempty"`
+	Actor   string `json:"actor,omitempty"`
+	// TargetID and Target are the ID and the name of the member the action is applied to.
+	TargetID int64  `json:"targetId,omitempty"`
+	Target   string `json:"target,omitempty"`
+	// Duration is the ban or mute duration in seconds, zero is permanent.
+	Duration int64 `json:"duration,omitempty"`
+	// Reason is the reason of the action.
+	Reason string `json:"reason,omitempty"`
+	// Link is the link to the message of the action.
+	Link string `json:"link,omitempty"`
+	// Content is the text of the deleted message.
+	Content string `json:"content,omitempty"`
+}
+
+// Log appends the events to the JSONL file, one event per line.
+type Log struct {
+	path string
+
+	mu sync.Mutex
+}
+
+// NewLog creates the log of the file, the events aren't written if the path is empty.
+func NewLog(path string) *Log {
+	return &Log{
+		path: path,
+	}
+}
+
+// Write appends the event to the file.
+func (l *Log) Write(e Event) error {
+	if l.path == "" {
+		return nil
+	}
+
+	data, err := json.Marshal(e)
+	if err != nil {
+		return fmt.Errorf("json.Marshal: %v", err)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	f, err := os.OpenFile(l.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, filePerm)
+	if err != nil {
+		return fmt.Errorf("os.OpenFile: %v", err)
+	}
+
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		f.Close() //nolint:errcheck,gosec // the write error is returned
+
+		return fmt.Errorf("f.Write: %v", err)
+	}
+
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("f.Close: %v", err)
+	}
+
+	return nil
+}
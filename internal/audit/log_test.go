@@ -0,0 +1,45 @@
+package audit
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLog_Write(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	date := time.Date(2024, 3, 8, 12, 0, 0, 0, time.UTC)
+
+	l := NewLog(path)
+
+	assert.NoError(t, l.Write(Event{
+		Date:     date,
+		Action:   ActionMute,
+		ChatID:   -100500,
+		Chat:     "PHP",
+		ActorID:  42,
+		Actor:    "@admin",
+		TargetID: 43,
+		Target:   "@spammer",
+		Duration: 3600,
+		Reason:   "spam",
+	}))
+	assert.NoError(t, l.Write(Event{Date: date, Action: ActionDelete, ChatID: -100500, Content: "Лучшее казино"}))
+
+	data, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"date":"2024-03-08T12:00:00Z","action":"mute","chatId":-100500,"chat":"PHP","actorId":42,"actor":"@admin",`+
+		`"targetId":43,"target":"@spammer","duration":3600,"reason":"spam"}`+"\n"+
+		`{"date":"2024-03-08T12:00:00Z","action":"delete","chatId":-100500,"content":"Лучшее казино"}`+"\n", string(data))
+}
+
+func TestLog_Write_Disabled(t *testing.T) {
+	t.Parallel()
+
+	assert.NoError(t, NewLog("").Write(Event{Action: ActionBan}))
+}
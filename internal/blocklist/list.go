@@ -0,0 +1,116 @@
+package blocklist
+
+import (
+	"fmt"
+	"maps"
+	"sync"
+	"time"
+
+	"geeksonator/pkg/jsonfile"
+)
+
+// Entry is the blocked user.
+type Entry struct {
+	// UserID is the ID of the user, it's the key of the list.
+	UserID int64 `json:"-"`
+	// Reason is the reason from the shared list, may be empty.
+	Reason string `json:"reason"`
+	// Source is the name of the imported file.
+	Source string `json:"source"`
+	// Added is the date of the import.
+	Added time.Time `json:"added"`
+}
+
+// List is the blocklist indexed by the user ID and kept in the JSON file.
+type List struct {
+	path string
+
+	mu      sync.RWMutex
+	entries map[int64]Entry
+}
+
+// NewList loads the list from the file, the list is kept in memory only if the path is empty.
+func NewList(path string) (*List, error) {
+	l := &List{
+		path:    path,
+		entries: map[int64]Entry{},
+	}
+
+	if err := l.Reload(); err != nil {
+		return nil, fmt.Errorf("l.Reload: %v", err)
+	}
+
+	return l, nil
+}
+
+// Reload replaces the list with the file contents, e.g. after the import by another process.
+// The list is unchanged if the file can't be loaded.
+func (l *List) Reload() error {
+	if l.path == "" {
+		return nil
+	}
+
+	entries := map[int64]Entry{}
+	if err := jsonfile.Load(l.path, &entries); err != nil {
+		return fmt.Errorf("jsonfile.Load: %v", err)
+	}
+
+	for id, entry := range entries {
+		entry.UserID = id
+		entries[id] = entry
+	}
+
+	l.mu.Lock()
+	l.entries = entries
+	l.mu.Unlock()
+
+	return nil
+}
+
+// Import adds the entries from the source file dated at the date and returns the number of the new users.
+// The entries of the users already in the list are replaced.
+func (l *List) Import(entries []Entry, source string, at time.Time) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	merged := maps.Clone(l.entries)
+
+	var added int
+	for _, entry := range entries {
+		if _, ok := merged[entry.UserID]; !ok {
+			added++
+		}
+
+		entry.Source = source
+		entry.Added = at
+		merged[entry.UserID] = entry
+	}
+
+	if l.path != "" {
+		if err := jsonfile.Save(l.path, merged); err != nil {
+			return 0, fmt.Errorf("jsonfile.Save: %v", err)
+		}
+	}
+
+	l.entries = merged
+
+	return added, nil
+}
+
+// Get returns the entry of the user, false if the user isn't blocked.
+func (l *List) Get(userID int64) (Entry, bool) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	entry, ok := l.entries[userID]
+
+	return entry, ok
+}
+
+// Len returns the number of the blocked users.
+func (l *List) Len() int {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	return len(l.entries)
+}
@@ -0,0 +1,67 @@
+package blocklist
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestList(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "blocklist.json")
+	at := time.Date(2024, 3, 8, 12, 0, 0, 0, time.UTC)
+
+	l, err := NewList(path)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, l.Len())
+
+	added, err := l.Import([]Entry{{UserID: 100500, Reason: "spam"}, {UserID: 100501}}, "spam.csv", at)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, added)
+
+	added, err = l.Import([]Entry{{UserID: 100501, Reason: "scam"}, {UserID: 100502}}, "scam.json", at.Add(time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, added, "known user isn't counted")
+	assert.Equal(t, 3, l.Len())
+
+	got, ok := l.Get(100501)
+	assert.True(t, ok)
+	assert.Equal(t, Entry{UserID: 100501, Reason: "scam", Source: "scam.json", Added: at.Add(time.Hour)}, got)
+
+	_, ok = l.Get(100503)
+	assert.False(t, ok)
+
+	// the bot reloads the list imported by another process
+	running, err := NewList(path)
+	assert.NoError(t, err)
+
+	_, err = l.Import([]Entry{{UserID: 100503}}, "more.csv", at)
+	assert.NoError(t, err)
+
+	_, ok = running.Get(100503)
+	assert.False(t, ok)
+
+	assert.NoError(t, running.Reload())
+
+	got, ok = running.Get(100503)
+	assert.True(t, ok, "reloaded")
+	assert.Equal(t, Entry{UserID: 100503, Source: "more.csv", Added: at}, got)
+	assert.Equal(t, 4, running.Len())
+}
+
+func TestList_InMemory(t *testing.T) {
+	t.Parallel()
+
+	l, err := NewList("")
+	assert.NoError(t, err)
+
+	_, err = l.Import([]Entry{{UserID: 100500}}, "spam.csv", time.Now())
+	assert.NoError(t, err)
+	assert.NoError(t, l.Reload())
+
+	_, ok := l.Get(100500)
+	assert.True(t, ok)
+}
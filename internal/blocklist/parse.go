@@ -0,0 +1,143 @@
+package blocklist
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrUnknownFormat = errors.New("unknown blocklist format")
+	ErrInvalidEntry  = errors.New("invalid blocklist entry")
+)
+
+// Format is the format of the shared blocklist file.
+type Format string
+
+const (
+	// FormatCSV is the list of the rows with the user ID and the optional reason, the header row is optional.
+	FormatCSV Format = "csv"
+	// FormatJSON is the array of the user IDs or of the objects with the id and the optional reason.
+	FormatJSON Format = "json"
+)
+
+// FormatFor returns the format of the file by its extension.
+func FormatFor(path string) (Format, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		return FormatCSV, nil
+	case ".json":
+		return FormatJSON, nil
+	default:
+		return "", fmt.Errorf("%w: %s", ErrUnknownFormat, path)
+	}
+}
+
+// Parse parses the shared blocklist, e.g.
+//
+//	id,reason
+//	100500,spam
+//
+// or
+//
+//	[100500, {"id": 100501, "reason": "spam"}]
+func Parse(r io.Reader, format Format) ([]Entry, error) {
+	switch format {
+	case FormatCSV:
+		return parseCSV(r)
+	case FormatJSON:
+		return parseJSON(r)
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownFormat, format)
+	}
+}
+
+// parseCSV parses the rows with the user ID and the optional reason, the first row is skipped if it's a header.
+func parseCSV(r io.Reader) ([]Entry, error) {
+	reader := csv.NewReader(r)
+	reader.Comment = '#'
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	var entries []Entry
+
+	for row := 1; ; row++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return entries, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reader.Read: %v", err)
+		}
+
+		id, err := parseID(record[0])
+		if err != nil {
+			if row == 1 {
+				continue // header
+			}
+
+			return nil, fmt.Errorf("row %d: %w", row, err)
+		}
+
+		entry := Entry{UserID: id}
+		if len(record) > 1 {
+			entry.Reason = strings.TrimSpace(record[1])
+		}
+
+		entries = append(entries, entry)
+	}
+}
+
+// parseJSON parses the array of the user IDs or of the objects with the id and the optional reason.
+func parseJSON(r io.Reader) ([]Entry, error) {
+	var items []json.RawMessage
+	if err := json.NewDecoder(r).Decode(&items); err != nil {
+		return nil, fmt.Errorf("json.Decode: %v", err)
+	}
+
+	entries := make([]Entry, 0, len(items))
+
+	for i, item := range items {
+		var entry Entry
+
+		if bytes.HasPrefix(bytes.TrimSpace(item), []byte("{")) {
+			var obj struct {
+				ID     json.Number `json:"id"`
+				Reason string      `json:"reason"`
+			}
+
+			if err := json.Unmarshal(item, &obj); err != nil {
+				return nil, fmt.Errorf("item %d: %w: %v", i, ErrInvalidEntry, err)
+			}
+
+			item = []byte(obj.ID)
+			entry.Reason = strings.TrimSpace(obj.Reason)
+		}
+
+		id, err := parseID(strings.Trim(string(item), `"`))
+		if err != nil {
+			return nil, fmt.Errorf("item %d: %w", i, err)
+		}
+
+		entry.UserID = id
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+// parseID parses the positive user ID.
+func parseID(s string) (int64, error) {
+	id, err := strconv.ParseInt(strings.TrimSpace(s), 10, 64)
+	if err != nil || id <= 0 {
+		return 0, fmt.Errorf("%w: user ID %q", ErrInvalidEntry, s)
+	}
+
+	return id, nil
+}
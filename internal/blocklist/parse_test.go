@@ -0,0 +1,97 @@
+package blocklist
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		data    string
+		format  Format
+		want    []Entry
+		wantErr error
+	}{
+		{
+			name:   "CSV with header",
+			data:   "id,reason\n100500,spam\n# comment\n100501\n",
+			format: FormatCSV,
+			want: []Entry{
+				{UserID: 100500, Reason: "spam"},
+				{UserID: 100501},
+			},
+		},
+		{
+			name:   "CSV without header",
+			data:   "100500, crypto scam",
+			format: FormatCSV,
+			want: []Entry{
+				{UserID: 100500, Reason: "crypto scam"},
+			},
+		},
+		{
+			name:    "CSV invalid ID",
+			data:    "100500\nspammer\n",
+			format:  FormatCSV,
+			wantErr: ErrInvalidEntry,
+		},
+		{
+			name:   "JSON IDs and objects",
+			data:   `[100500, "100501", {"id": 100502, "reason": "spam"}, {"id": "100503"}]`,
+			format: FormatJSON,
+			want: []Entry{
+				{UserID: 100500},
+				{UserID: 100501},
+				{UserID: 100502, Reason: "spam"},
+				{UserID: 100503},
+			},
+		},
+		{
+			name:    "JSON negative ID",
+			data:    `[-100500]`,
+			format:  FormatJSON,
+			wantErr: ErrInvalidEntry,
+		},
+		{
+			name:    "JSON object without ID",
+			data:    `[{"reason": "spam"}]`,
+			format:  FormatJSON,
+			wantErr: ErrInvalidEntry,
+		},
+		{
+			name:    "Unknown format",
+			data:    "100500",
+			format:  "xml",
+			wantErr: ErrUnknownFormat,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := Parse(strings.NewReader(tt.data), tt.format)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestFormatFor(t *testing.T) {
+	t.Parallel()
+
+	format, err := FormatFor("/tmp/spam.CSV")
+	assert.NoError(t, err)
+	assert.Equal(t, FormatCSV, format)
+
+	format, err = FormatFor("spam.json")
+	assert.NoError(t, err)
+	assert.Equal(t, FormatJSON, format)
+
+	_, err = FormatFor("spam.txt")
+	assert.ErrorIs(t, err, ErrUnknownFormat)
+}
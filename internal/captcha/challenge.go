@@ -0,0 +1,79 @@
+package captcha
+
+import "strconv"
+
+// optionsCount is the number of the inline buttons of the challenge.
+const optionsCount = 4
+
+// animals are the options of the elephant challenge, the first one is the answer.
+var animals = []string{"🐘", "🐍", "🐹", "🦀", "🐳", "🐪"} //nolint:gochecknoglobals // it's a constant list
+
+// Challenge is the question with the inline button options.
+type Challenge struct {
+	// Question is the text of the challenge.
+	Question string
+	// Options are the button labels.
+	Options []string
+	// Answer is the index of the correct option.
+	Answer int
+}
+
+// New returns a random challenge, intn returns a random number in [0, n), e.g. rand.IntN.
+func New(intn func(n int) int) Challenge {
+	if intn(2) == 0 {
+		return elephant(intn)
+	}
+
+	return arithmetic(intn)
+}
+
+// elephant asks to pick the PHP elephant among other animals.
+func elephant(intn func(n int) int) Challenge {
+	others := append([]string(nil), animals[1:]...)
+	shuffle(others, intn)
+
+	return withAnswer("Выберите слона PHP.", animals[0], others[:optionsCount-1], intn)
+}
+
+// arithmetic asks the sum of two numbers from 1 to 9.
+func arithmetic(intn func(n int) int) Challenge {
+	a, b := 1+intn(9), 1+intn(9)
+	sum := a + b
+
+	// the wrong options are the closest numbers, so the answer can't be guessed by the range
+	wrong := make([]string, 0, optionsCount-1)
+	for d := 1; len(wrong) < optionsCount-1; d++ {
+		wrong = append(wrong, strconv.Itoa(sum+d))
+		if len(wrong) < optionsCount-1 && sum-d > 0 {
+			wrong = append(wrong, strconv.Itoa(sum-d))
+		}
+	}
+
+	question := "Сколько будет " + strconv.Itoa(a) + " + " + strconv.Itoa(b) + "?"
+
+	return withAnswer(question, strconv.Itoa(sum), wrong, intn)
+}
+
+// withAnswer returns the challenge with the answer put among the wrong options at a random position.
+func withAnswer(question, answer string, wrong []string, intn func(n int) int) Challenge {
+	pos := intn(len(wrong) + 1)
+
+	options := make([]string, 0, len(wrong)+1)
+	options = append(options, wrong[:pos]...)
+	options = append(options, answer)
+	options = append(options, wrong[pos:]...)
+
+	return Challenge{
+		Question: question,
+		Options:  options,
+		Answer:   pos,
+	}
+}
+
+// shuffle shuffles the list in place.
+func shuffle(list []string, intn func(n int) int) {
+	for i := len(list) - 1; i > 0; i-- {
+		j := intn(i + 1)
+		list[i], list[j] = list[j], list[i]
+	}
+}
@@ -0,0 +1,69 @@
+package captcha
+
+import (
+	"math/rand/v2"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// zero is the random function always returning 0.
+func zero(int) int {
+	return 0
+}
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		intn func(n int) int
+		want Challenge
+	}{
+		{
+			name: "Elephant",
+			intn: zero,
+			want: Challenge{
+				Question: "Выберите слона PHP.",
+				Options:  []string{"🐘", "🐹", "🦀", "🐳"},
+				Answer:   0,
+			},
+		},
+		{
+			name: "Arithmetic",
+			intn: func(n int) int {
+				return n - 1
+			},
+			want: Challenge{
+				Question: "Сколько будет 9 + 9?",
+				Options:  []string{"19", "17", "20", "18"},
+				Answer:   3,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, New(tt.intn))
+		})
+	}
+}
+
+func TestNew_Random(t *testing.T) {
+	t.Parallel()
+
+	for range 100 {
+		got := New(rand.IntN)
+
+		assert.Len(t, got.Options, optionsCount)
+		assert.Contains(t, []string{"🐘", "2", "3", "4", "5", "6", "7", "8", "9", "10", "11", "12", "13", "14", "15", "16", "17", "18"},
+			got.Options[got.Answer])
+
+		unique := make(map[string]struct{}, len(got.Options))
+		for _, option := range got.Options {
+			unique[option] = struct{}{}
+		}
+		assert.Len(t, unique, optionsCount)
+	}
+}
@@ -0,0 +1,136 @@
+package captcha
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"geeksonator/pkg/jsonfile"
+)
+
+// Pending is the challenge waiting for the answer of the new member.
+type Pending struct {
+	ChatID int64 `json:"chatId"`
+	// ChatTitle is logged to the audit log if the member is kicked.
+	ChatTitle string `json:"chatTitle,omitempty"`
+	UserID    int64  `json:"userId"`
+	// UserName, FirstName and LastName are logged to the audit log if the member is kicked.
+	UserName  string `json:"userName,omitempty"`
+	FirstName string `json:"firstName,omitempty"`
+	LastName  string `json:"lastName,omitempty"`
+	// MessageID is the challenge message.
+	MessageID int `json:"messageId"`
+	// Answer is the index of the correct option.
+	Answer int `json:"answer"`
+	// Deadline is the time the member is kicked at if the challenge isn't solved.
+	Deadline time.Time `json:"deadline"`
+	// Scheduled is set if the challenge message deletion is scheduled at the deadline.
+	Scheduled bool `json:"scheduled,omitempty"`
+}
+
+// Store keeps the pending challenges in the JSON file, so they expire after restarts.
+type Store struct {
+	path string
+
+	mu      sync.Mutex
+	pending map[string]Pending
+}
+
+// NewStore loads the pending challenges from the file, they are kept in memory only if the path is empty.
+func NewStore(path string) (*Store, error) {
+	s := &Store{
+		path:    path,
+		pending: map[string]Pending{},
+	}
+
+	if path == "" {
+		return s, nil
+	}
+
+	if err := jsonfile.Load(path, &s.pending); err != nil {
+		return nil, fmt.Errorf("jsonfile.Load: %v", err)
+	}
+
+	return s, nil
+}
+
+// Add saves the pending challenge, the previous challenge of the member in the chat is replaced.
+func (s *Store) Add(p Pending) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.save(key(p.ChatID, p.UserID), &p); err != nil {
+		return fmt.Errorf("s.save: %v", err)
+	}
+
+	return nil
+}
+
+// Remove removes the pending challenge of the member in the chat.
+func (s *Store) Remove(chatID, userID int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	k := key(chatID, userID)
+	if _, ok := s.pending[k]; !ok {
+		return nil
+	}
+
+	if err := s.save(k, nil); err != nil {
+		return fmt.Errorf("s.save: %v", err)
+	}
+
+	return nil
+}
+
+// List returns the pending challenges in the order of the deadlines.
+func (s *Store) List() []Pending {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	list := make([]Pending, 0, len(s.pending))
+	for _, p := range s.pending {
+		list = append(list, p)
+	}
+
+	slices.SortFunc(list, func(a, b Pending) int {
+		return a.Deadline.Compare(b.Deadline)
+	})
+
+	return list
+}
+
+// save replaces the challenge, nil removes it, and writes all the challenges into the file.
+// The previous challenge is restored if the file can't be written.
+func (s *Store) save(k string, p *Pending) error {
+	prev, ok := s.pending[k]
+
+	if p == nil {
+		delete(s.pending, k)
+	} else {
+		s.pending[k] = *p
+	}
+
+	if s.path == "" {
+		return nil
+	}
+
+	if err := jsonfile.Save(s.path, s.pending); err != nil {
+		if ok {
+			s.pending[k] = prev
+		} else {
+			delete(s.pending, k)
+		}
+
+		return fmt.Errorf("jsonfile.Save: %v", err)
+	}
+
+	return nil
+}
+
+// key returns the key of the challenge, e.g. -100500:42.
+func key(chatID, userID int64) string {
+	return strconv.FormatInt(chatID, 10) + ":" + strconv.FormatInt(userID, 10)
+}
@@ -0,0 +1,41 @@
+package captcha
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStore(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "captcha.json")
+	deadline := time.Date(2024, 3, 8, 12, 2, 0, 0, time.UTC)
+
+	first := Pending{ChatID: -100500, ChatTitle: "Geeks", UserID: 42, UserName: "newbie", MessageID: 50, Deadline: deadline}
+	second := Pending{ChatID: -100500, UserID: 43, FirstName: "John", MessageID: 51, Answer: 2, Deadline: deadline.Add(-time.Minute)}
+
+	s, err := NewStore(path)
+	assert.NoError(t, err)
+	assert.Empty(t, s.List())
+
+	assert.NoError(t, s.Add(first))
+	assert.NoError(t, s.Add(second))
+
+	loaded, err := NewStore(path)
+	assert.NoError(t, err)
+	assert.Equal(t, []Pending{second, first}, loaded.List(), "challenges persist in the order of the deadlines")
+
+	rejoined := first
+	rejoined.MessageID, rejoined.Scheduled = 52, true
+
+	assert.NoError(t, loaded.Add(rejoined))
+	assert.NoError(t, loaded.Remove(-100500, 43))
+	assert.NoError(t, loaded.Remove(-100500, 43))
+
+	loaded, err = NewStore(path)
+	assert.NoError(t, err)
+	assert.Equal(t, []Pending{rejoined}, loaded.List(), "rejoined member's challenge is replaced")
+}
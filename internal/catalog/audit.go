@@ -0,0 +1,16 @@
+package catalog
+
+// Audit is the configuration of the moderation audit log.
+type Audit struct {
+	// LogChat is the ID of the chat the moderation actions are posted to, the bot must be its member.
+	LogChat int64 `json:"logChat" yaml:"logChat"`
+}
+
+// AuditConfig returns the configuration of the audit log, the log without the log chat by default.
+func (c *Catalog) AuditConfig() *Audit {
+	if c.Audit == nil {
+		return &Audit{}
+	}
+
+	return c.Audit
+}
@@ -0,0 +1,28 @@
+package catalog
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCatalog_AuditConfig(t *testing.T) {
+	t.Parallel()
+
+	c, err := Parse([]byte(`version: 1
+audit:
+  logChat: -100100
+commands:
+  - name: php
+    response: "@phpGeeks"
+chats:
+  - id: -100500
+    audit:
+      logChat: -100200
+`), FormatYAML)
+	assert.NoError(t, err)
+
+	assert.Equal(t, &Audit{LogChat: -100100}, c.ForChat(ChatRef{}).AuditConfig())
+	assert.Equal(t, &Audit{LogChat: -100200}, c.ForChat(ChatRef{ID: -100500}).AuditConfig())
+	assert.Equal(t, &Audit{}, (&Catalog{}).AuditConfig(), "default")
+}
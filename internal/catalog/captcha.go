@@ -0,0 +1,51 @@
+package catalog
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// DefaultCaptchaTimeout is the time to solve the join challenge if the timeout isn't set.
+const DefaultCaptchaTimeout = 2 * time.Minute
+
+var ErrInvalidCaptcha = errors.New("invalid captcha")
+
+// Captcha is the configuration of the join challenge of the new chat members.
+type Captcha struct {
+	// Disable disables the global captcha in the chat.
+	Disable bool `json:"disable" yaml:"disable"`
+	// Timeout is the time to solve the challenge, the user is kicked after it, 2m by default.
+	Timeout Duration `json:"timeout" yaml:"timeout"`
+}
+
+// TimeoutValue returns the time to solve the challenge.
+func (c *Captcha) TimeoutValue() time.Duration {
+	if c.Timeout == 0 {
+		return DefaultCaptchaTimeout
+	}
+
+	return time.Duration(c.Timeout)
+}
+
+// CaptchaConfig returns the configuration of the captcha, false if the captcha is disabled.
+func (c *Catalog) CaptchaConfig() (*Captcha, bool) {
+	if c.Captcha == nil || c.Captcha.Disable {
+		return nil, false
+	}
+
+	return c.Captcha, true
+}
+
+// validate checks the timeout, the member is restricted until it.
+func (c *Captcha) validate() error {
+	if c == nil {
+		return nil
+	}
+
+	if err := c.Timeout.validateRestriction(); err != nil {
+		return fmt.Errorf("%w: timeout: %v", ErrInvalidCaptcha, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,77 @@
+package catalog
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCatalog_CaptchaConfig(t *testing.T) {
+	t.Parallel()
+
+	c, err := Parse([]byte(`version: 1
+captcha:
+  timeout: 5m
+commands:
+  - name: php
+    response: "@phpGeeks"
+chats:
+  - id: -100500
+    captcha:
+      disable: true
+  - id: -100501
+    captcha: {}
+`), FormatYAML)
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		chat        ChatRef
+		wantTimeout time.Duration
+		wantOk      bool
+	}{
+		{
+			name:        "Global",
+			chat:        ChatRef{},
+			wantTimeout: 5 * time.Minute,
+			wantOk:      true,
+		},
+		{
+			name:   "Disabled in the chat",
+			chat:   ChatRef{ID: -100500},
+			wantOk: false,
+		},
+		{
+			name:        "Default timeout in the chat",
+			chat:        ChatRef{ID: -100501},
+			wantTimeout: DefaultCaptchaTimeout,
+			wantOk:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, ok := c.ForChat(tt.chat).CaptchaConfig()
+			assert.Equal(t, tt.wantOk, ok)
+
+			if ok {
+				assert.Equal(t, tt.wantTimeout, got.TimeoutValue())
+			}
+		})
+	}
+}
+
+func TestCaptcha_validate(t *testing.T) {
+	t.Parallel()
+
+	_, err := Parse([]byte(`version: 1
+captcha:
+  timeout: 10s
+commands:
+  - name: php
+    response: "@phpGeeks"
+`), FormatYAML)
+	assert.ErrorIs(t, err, ErrInvalidCaptcha)
+}
@@ -0,0 +1,331 @@
+package catalog
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"text/template"
+)
+
+// Version is the supported catalog schema version.
+const Version = 1
+
+var (
+	ErrUnsupportedVersion = errors.New("unsupported catalog version")
+	ErrNoCommands         = errors.New("catalog has no commands")
+	ErrInvalidName        = errors.New("invalid command name")
+	ErrInvalidAlias       = errors.New("invalid command alias")
+	ErrDuplicateAlias     = errors.New("duplicate command name or alias")
+	ErrEmptyResponse      = errors.New("command response is empty")
+	ErrUnknownHandler     = errors.New("unknown command handler")
+	ErrUnknownRole        = errors.New("unknown command role")
+	ErrUnknownSection     = errors.New("unknown command section")
+	ErrInvalidSection     = errors.New("invalid section")
+	ErrInvalidLanguage    = errors.New("invalid description language code")
+	ErrModerationRole     = errors.New("moderation command must be admin only")
+)
+
+// Role is a role of the command caller.
+type Role string
+
+const (
+	// RoleAdmin is a chat administrator, the default role of commands.
+	RoleAdmin Role = "admin"
+	// RoleMember is any chat member.
+	RoleMember Role = "member"
+)
+
+// Handler is a name of the built-in command handler.
+type Handler string
+
+const (
+	// HandlerHelp generates the list of available commands.
+	HandlerHelp Handler = "help"
+	// HandlerBan bans the author of the replied message.
+	HandlerBan Handler = "ban"
+	// HandlerUnban unbans the author of the replied message.
+	HandlerUnban Handler = "unban"
+	// HandlerMute forbids the author of the replied message to send messages.
+	HandlerMute Handler = "mute"
+	// HandlerUnmute lifts the restrictions from the author of the replied message.
+	HandlerUnmute Handler = "unmute"
+	// HandlerWarn warns the author of the replied message and applies the warnings ladder.
+	HandlerWarn Handler = "warn"
+	// HandlerUnwarn removes the last warning of the author of the replied message.
+	HandlerUnwarn Handler = "unwarn"
+	// HandlerWarns lists the warnings of the author of the replied message.
+	HandlerWarns Handler = "warns"
+	// HandlerDel deletes the replied message and the command message.
+	HandlerDel Handler = "del"
+	// HandlerFilters lists, adds and removes the content filter rules of the chat.
+	HandlerFilters Handler = "filters"
+	// HandlerReport reports the replied message to the admins.
+	HandlerReport Handler = "report"
+	// HandlerReports subscribes the admin to the reports of the chat.
+	HandlerReports Handler = "reports"
+	// HandlerFban bans the author of the replied message in all the federation chats.
+	HandlerFban Handler = "fban"
+	// HandlerFunban lifts the federation ban of the user.
+	HandlerFunban Handler = "funban"
+)
+
+// handlers is the set of the known built-in handlers, the value is true for the moderation handlers.
+var handlers = map[Handler]bool{ //nolint:gochecknoglobals // it's a constant set
+	HandlerHelp:    false,
+	HandlerBan:     true,
+	HandlerUnban:   true,
+	HandlerMute:    true,
+	HandlerUnmute:  true,
+	HandlerWarn:    true,
+	HandlerUnwarn:  true,
+	HandlerWarns:   true,
+	HandlerDel:     true,
+	HandlerFilters: true,
+	HandlerReport:  false,
+	HandlerReports: true,
+	HandlerFban:    true,
+	HandlerFunban:  true,
+}
+
+// Moderation returns true if the handler moderates the chat members, such handlers are admin only.
+func (h Handler) Moderation() bool {
+	return handlers[h]
+}
+
+var (
+	// nameRe matches names accepted by Telegram as bot commands.
+	nameRe = regexp.MustCompile(`^[a-z0-9_]{1,32}$`)
+	// aliasRe matches any lower-case word, including cyrillic ones.
+	aliasRe = regexp.MustCompile(`^[\p{Ll}\p{N}_]{1,32}$`)
+	// languageRe matches two-letter ISO 639-1 language codes.
+	languageRe = regexp.MustCompile(`^[a-z]{2}$`)
+)
+
+// Command is a chat command with its canned response.
+type Command struct {
+	// Name is the main command name without the leading slash.
+	Name string `json:"name" yaml:"name"`
+	// Aliases are alternative names without the leading slash.
+	Aliases []string `json:"aliases" yaml:"aliases"`
+	// Description is the one-line HTML description for the help.
+	Description string `json:"description" yaml:"description"`
+	// Descriptions are the localized descriptions for the Telegram menu by the language code, e.g. en.
+	Descriptions map[string]string `json:"descriptions" yaml:"descriptions"`
+	// Section is the help section ID.
+	Section string `json:"section" yaml:"section"`
+	// Role is the minimal caller role, admin by default.
+	Role Role `json:"role" yaml:"role"`
+	// Handler is the built-in handler of the command.
+	Handler Handler `json:"handler" yaml:"handler"`
+	// Link is the chat link used by the {{chat "name"}} template function, e.g. @golangGeeks.
+	Link string `json:"link" yaml:"link"`
+	// Response is the HTML response template of the command, for the handlers it's an intro.
+	Response string `json:"response" yaml:"response"`
+	// Refs are the commands whose responses are joined into the response of the command.
+	Refs []string `json:"refs" yaml:"refs"`
+	// AliasOf makes the entry a pure alias of another command, the entry can't have other fields.
+	AliasOf string `json:"aliasOf" yaml:"aliasOf"`
+	// Cleanup replaces the cleanup of the chat for the command.
+	Cleanup *Cleanup `json:"cleanup" yaml:"cleanup"`
+
+	// body is the response template with the resolved refs.
+	body string
+	tmpl *template.Template
+}
+
+// AllowedFor returns true if the command is available for the role.
+func (c *Command) AllowedFor(role Role) bool {
+	return role == RoleAdmin || c.Role == role
+}
+
+// Section is a group of commands in the help.
+type Section struct {
+	ID    string `json:"id"    yaml:"id"`
+	Title string `json:"title" yaml:"title"`
+}
+
+// Catalog is a validated set of commands.
+type Catalog struct {
+	Version    int         `json:"version"    yaml:"version"`
+	Sections   []Section   `json:"sections"   yaml:"sections"`
+	Commands   []Command   `json:"commands"   yaml:"commands"`
+	Chats      []Chat      `json:"chats"      yaml:"chats"`
+	Warnings   *Warnings   `json:"warnings"   yaml:"warnings"`
+	Captcha    *Captcha    `json:"captcha"    yaml:"captcha"`
+	Flood      *Flood      `json:"flood"      yaml:"flood"`
+	Newcomers  *Newcomers  `json:"newcomers"  yaml:"newcomers"`
+	Reports    *Reports    `json:"reports"    yaml:"reports"`
+	Audit      *Audit      `json:"audit"      yaml:"audit"`
+	Federation *Federation `json:"federation" yaml:"federation"`
+
+	index map[string]*Command
+
+	// parent is the global catalog of the chat catalog.
+	parent *Catalog
+	// chat is the configuration of the chat catalog.
+	chat *Chat
+	// byID and byUsername are the chat catalogs.
+	byID       map[int64]*Catalog
+	byUsername map[string]*Catalog
+}
+
+// Lookup returns the command by its name or alias without the leading slash.
+func (c *Catalog) Lookup(name string) (*Command, bool) {
+	cmd, ok := c.index[name]
+
+	return cmd, ok
+}
+
+// validate checks the catalog schema and builds the lookup index.
+func (c *Catalog) validate() error {
+	if c.Version != Version {
+		return fmt.Errorf("%w: %d", ErrUnsupportedVersion, c.Version)
+	}
+
+	if len(c.Commands) == 0 {
+		return ErrNoCommands
+	}
+
+	sections, err := c.validateSections()
+	if err != nil {
+		return err
+	}
+
+	if c.Warnings != nil {
+		if err := c.Warnings.validate(); err != nil {
+			return err
+		}
+	}
+
+	if err := c.Captcha.validate(); err != nil {
+		return err
+	}
+
+	if err := c.Flood.validate(); err != nil {
+		return err
+	}
+
+	if err := c.Newcomers.validate(); err != nil {
+		return err
+	}
+
+	if err := c.Federation.validate(); err != nil {
+		return err
+	}
+
+	if err := c.build(sections); err != nil {
+		return err
+	}
+
+	return c.buildChats(sections)
+}
+
+// validateSections checks the sections and returns the set of their IDs.
+func (c *Catalog) validateSections() (map[string]struct{}, error) {
+	sections := make(map[string]struct{}, len(c.Sections))
+	for i, section := range c.Sections {
+		if !nameRe.MatchString(section.ID) || section.Title == "" {
+			return nil, fmt.Errorf("sections[%d]: %w: %q", i, ErrInvalidSection, section.ID)
+		}
+
+		if _, ok := sections[section.ID]; ok {
+			return nil, fmt.Errorf("sections[%d]: %w: duplicate %q", i, ErrInvalidSection, section.ID)
+		}
+
+		sections[section.ID] = struct{}{}
+	}
+
+	return sections, nil
+}
+
+// build validates the commands and builds the lookup index.
+func (c *Catalog) build(sections map[string]struct{}) error {
+	index := make(map[string]*Command, len(c.Commands))
+	for i := range c.Commands {
+		cmd := &c.Commands[i]
+
+		if !nameRe.MatchString(cmd.Name) {
+			return fmt.Errorf("commands[%d]: %w: %q", i, ErrInvalidName, cmd.Name)
+		}
+
+		if err := cmd.validate(sections); err != nil {
+			return fmt.Errorf("commands[%d] %s: %w", i, cmd.Name, err)
+		}
+
+		for _, alias := range cmd.Aliases {
+			if !aliasRe.MatchString(alias) {
+				return fmt.Errorf("commands[%d] %s: %w: %q", i, cmd.Name, ErrInvalidAlias, alias)
+			}
+		}
+
+		for _, key := range append([]string{cmd.Name}, cmd.Aliases...) {
+			if _, ok := index[key]; ok {
+				return fmt.Errorf("commands[%d] %s: %w: %q", i, cmd.Name, ErrDuplicateAlias, key)
+			}
+
+			index[key] = cmd
+		}
+	}
+
+	c.index = index
+
+	if err := c.resolveRefs(); err != nil {
+		return err
+	}
+
+	return c.parseTemplates()
+}
+
+// validate checks the command fields except the name and aliases uniqueness.
+func (c *Command) validate(sections map[string]struct{}) error {
+	if err := c.validateRefs(); err != nil {
+		return err
+	}
+
+	if c.isAlias() {
+		return nil
+	}
+
+	if c.Handler != "" {
+		if _, ok := handlers[c.Handler]; !ok {
+			return fmt.Errorf("%w: %q", ErrUnknownHandler, c.Handler)
+		}
+	} else if c.Response == "" && len(c.Refs) == 0 {
+		return ErrEmptyResponse
+	}
+
+	switch c.Role {
+	case "":
+		c.Role = RoleAdmin
+	case RoleAdmin, RoleMember:
+	default:
+		return fmt.Errorf("%w: %q", ErrUnknownRole, c.Role)
+	}
+
+	if c.Handler.Moderation() && c.Role != RoleAdmin {
+		return fmt.Errorf("%w: %q", ErrModerationRole, c.Handler)
+	}
+
+	if c.Section != "" {
+		if _, ok := sections[c.Section]; !ok {
+			return fmt.Errorf("%w: %q", ErrUnknownSection, c.Section)
+		}
+	}
+
+	for language := range c.Descriptions {
+		if !languageRe.MatchString(language) {
+			return fmt.Errorf("%w: %q", ErrInvalidLanguage, language)
+		}
+	}
+
+	return c.Cleanup.validate()
+}
+
+// WarningsConfig returns the configuration of the warnings, warnings without expiration and ladder by default.
+func (c *Catalog) WarningsConfig() *Warnings {
+	if c.Warnings == nil {
+		return &Warnings{}
+	}
+
+	return c.Warnings
+}
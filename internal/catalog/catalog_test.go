@@ -0,0 +1,212 @@
+package catalog
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCatalog_Lookup(t *testing.T) {
+	t.Parallel()
+
+	c := &Catalog{
+		Version: Version,
+		Commands: []Command{
+			{
+				Name:     "php",
+				Aliases:  []string{"пхп"},
+				Response: "@phpGeeks - Best PHP chat",
+			},
+		},
+	}
+	assert.NoError(t, c.validate())
+
+	tests := []struct {
+		name   string
+		lookup string
+		want   *Command
+		wantOk bool
+	}{
+		{
+			name:   "By name",
+			lookup: "php",
+			want:   &c.Commands[0],
+			wantOk: true,
+		},
+		{
+			name:   "By alias",
+			lookup: "пхп",
+			want:   &c.Commands[0],
+			wantOk: true,
+		},
+		{
+			name:   "Unknown",
+			lookup: "go",
+			want:   nil,
+			wantOk: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, ok := c.Lookup(tt.lookup)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantOk, ok)
+		})
+	}
+}
+
+func TestCatalog_validate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		catalog *Catalog
+		wantErr error
+	}{
+		{
+			name: "Success",
+			catalog: &Catalog{
+				Version: Version,
+				Commands: []Command{
+					{Name: "php", Aliases: []string{"пхп"}, Response: "php"},
+					{Name: "go", Aliases: []string{"го"}, Response: "go"},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "Unsupported version",
+			catalog: &Catalog{
+				Version: 2,
+			},
+			wantErr: ErrUnsupportedVersion,
+		},
+		{
+			name: "No commands",
+			catalog: &Catalog{
+				Version: Version,
+			},
+			wantErr: ErrNoCommands,
+		},
+		{
+			name: "Invalid name",
+			catalog: &Catalog{
+				Version: Version,
+				Commands: []Command{
+					{Name: "/php", Response: "php"},
+				},
+			},
+			wantErr: ErrInvalidName,
+		},
+		{
+			name: "Invalid alias",
+			catalog: &Catalog{
+				Version: Version,
+				Commands: []Command{
+					{Name: "php", Aliases: []string{"П Х П"}, Response: "php"},
+				},
+			},
+			wantErr: ErrInvalidAlias,
+		},
+		{
+			name: "Empty response",
+			catalog: &Catalog{
+				Version: Version,
+				Commands: []Command{
+					{Name: "php"},
+				},
+			},
+			wantErr: ErrEmptyResponse,
+		},
+		{
+			name: "Duplicate alias",
+			catalog: &Catalog{
+				Version: Version,
+				Commands: []Command{
+					{Name: "db", Aliases: []string{"бд"}, Response: "db"},
+					{Name: "dbgeeks", Aliases: []string{"бд"}, Response: "db"},
+				},
+			},
+			wantErr: ErrDuplicateAlias,
+		},
+		{
+			name: "Handler without response",
+			catalog: &Catalog{
+				Version: Version,
+				Commands: []Command{
+					{Name: "help", Handler: HandlerHelp},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "Unknown handler",
+			catalog: &Catalog{
+				Version: Version,
+				Commands: []Command{
+					{Name: "kick", Handler: "kick"},
+				},
+			},
+			wantErr: ErrUnknownHandler,
+		},
+		{
+			name: "Member moderation command",
+			catalog: &Catalog{
+				Version: Version,
+				Commands: []Command{
+					{Name: "ban", Handler: HandlerBan, Role: RoleMember},
+				},
+			},
+			wantErr: ErrModerationRole,
+		},
+		{
+			name: "Unknown role",
+			catalog: &Catalog{
+				Version: Version,
+				Commands: []Command{
+					{Name: "php", Role: "owner", Response: "php"},
+				},
+			},
+			wantErr: ErrUnknownRole,
+		},
+		{
+			name: "Unknown section",
+			catalog: &Catalog{
+				Version: Version,
+				Commands: []Command{
+					{Name: "php", Section: "chats", Response: "php"},
+				},
+			},
+			wantErr: ErrUnknownSection,
+		},
+		{
+			name: "Duplicate section",
+			catalog: &Catalog{
+				Version: Version,
+				Sections: []Section{
+					{ID: "chats", Title: "Чаты"},
+					{ID: "chats", Title: "Чаты"},
+				},
+				Commands: []Command{
+					{Name: "php", Section: "chats", Response: "php"},
+				},
+			},
+			wantErr: ErrInvalidSection,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tt.catalog.validate()
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+
+				return
+			}
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
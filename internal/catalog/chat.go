@@ -0,0 +1,258 @@
+package catalog
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	ErrInvalidChat   = errors.New("invalid chat")
+	ErrDuplicateChat = errors.New("duplicate chat")
+	ErrUnknownTarget = errors.New("unknown command")
+)
+
+// ChatRef identifies the chat of the incoming update.
+type ChatRef struct {
+	ID       int64
+	Username string
+}
+
+// Chat is the configuration of the commands in a chat, the chat is matched by ID or username.
+type Chat struct {
+	// ID is the chat ID, e.g. -1001234567890.
+	ID int64 `json:"id" yaml:"id"`
+	// Username is the public chat username without @, e.g. golangGeeks.
+	Username string `json:"username" yaml:"username"`
+	// Inherit enables the global commands in the chat, true by default.
+	Inherit *bool `json:"inherit" yaml:"inherit"`
+	// Disable is the list of the disabled global commands.
+	Disable []string `json:"disable" yaml:"disable"`
+	// Overrides are the responses of the global commands replaced in the chat.
+	Overrides map[string]string `json:"overrides" yaml:"overrides"`
+	// Commands are the commands available only in the chat.
+	Commands []Command `json:"commands" yaml:"commands"`
+	// LayoutFallback enables recognition of the commands typed in the wrong keyboard layout, e.g. /зрз.
+	LayoutFallback bool `json:"layoutFallback" yaml:"layoutFallback"`
+	// Warnings replace the global warnings configuration in the chat.
+	Warnings *Warnings `json:"warnings" yaml:"warnings"`
+	// Cleanup is the deletion of the command messages and the bot replies in the chat.
+	Cleanup *Cleanup `json:"cleanup" yaml:"cleanup"`
+	// Captcha replaces the global captcha configuration in the chat.
+	Captcha *Captcha `json:"captcha" yaml:"captcha"`
+	// Flood replaces the global flood protection in the chat.
+	Flood *Flood `json:"flood" yaml:"flood"`
+	// Newcomers replace the global newcomers restrictions in the chat.
+	Newcomers *Newcomers `json:"newcomers" yaml:"newcomers"`
+	// Reports replace the global reports configuration in the chat.
+	Reports *Reports `json:"reports" yaml:"reports"`
+	// Audit replaces the global audit log configuration in the chat.
+	Audit *Audit `json:"audit" yaml:"audit"`
+	// Federation opts the chat out of the global federation.
+	Federation *Federation `json:"federation" yaml:"federation"`
+	// Jobs make the chat a job board, the posts are validated against the job post template.
+	Jobs *Jobs `json:"jobs" yaml:"jobs"`
+	// Posts throttle the reposts in the chat and delete the old posts.
+	Posts *Posts `json:"posts" yaml:"posts"`
+}
+
+// inherit returns true if the global commands are enabled in the chat.
+func (ch *Chat) inherit() bool {
+	return ch.Inherit == nil || *ch.Inherit
+}
+
+// ForChat returns the catalog of the chat, the global catalog if the chat has no configuration.
+func (c *Catalog) ForChat(ref ChatRef) *Catalog {
+	if chat, ok := c.byID[ref.ID]; ok && ref.ID != 0 {
+		return chat
+	}
+
+	if chat, ok := c.byUsername[strings.ToLower(ref.Username)]; ok && ref.Username != "" {
+		return chat
+	}
+
+	return c
+}
+
+// Chat returns the configuration of the chat catalog, nil for the global catalog.
+func (c *Catalog) Chat() *Chat {
+	return c.chat
+}
+
+// buildChats validates the chat configurations and builds their catalogs.
+func (c *Catalog) buildChats(sections map[string]struct{}) error {
+	c.byID = make(map[int64]*Catalog, len(c.Chats))
+	c.byUsername = make(map[string]*Catalog, len(c.Chats))
+
+	for i := range c.Chats {
+		chat := &c.Chats[i]
+
+		if chat.ID == 0 && chat.Username == "" {
+			return fmt.Errorf("chats[%d]: %w: id or username is required", i, ErrInvalidChat)
+		}
+
+		view, err := c.buildChat(chat, sections)
+		if err != nil {
+			return fmt.Errorf("chats[%d]: %w", i, err)
+		}
+
+		if chat.ID != 0 {
+			if _, ok := c.byID[chat.ID]; ok {
+				return fmt.Errorf("chats[%d]: %w: %d", i, ErrDuplicateChat, chat.ID)
+			}
+
+			c.byID[chat.ID] = view
+		}
+
+		if chat.Username != "" {
+			username := strings.ToLower(strings.TrimPrefix(chat.Username, "@"))
+			if _, ok := c.byUsername[username]; ok {
+				return fmt.Errorf("chats[%d]: %w: %s", i, ErrDuplicateChat, chat.Username)
+			}
+
+			c.byUsername[username] = view
+		}
+	}
+
+	return nil
+}
+
+// buildChat builds the catalog of the chat from the global commands and the chat commands.
+func (c *Catalog) buildChat(chat *Chat, sections map[string]struct{}) (*Catalog, error) {
+	for _, name := range chat.Disable {
+		if _, ok := c.index[name]; !ok {
+			return nil, fmt.Errorf("disable: %w: %q", ErrUnknownTarget, name)
+		}
+	}
+
+	if err := chat.Cleanup.validate(); err != nil {
+		return nil, err
+	}
+
+	if err := chat.Federation.validateChat(); err != nil {
+		return nil, err
+	}
+
+	if err := chat.Jobs.validate(); err != nil {
+		return nil, err
+	}
+
+	if err := chat.Posts.validate(); err != nil {
+		return nil, err
+	}
+
+	audit := c.Audit
+	if chat.Audit != nil {
+		audit = chat.Audit
+	}
+
+	reports := c.Reports
+	if chat.Reports != nil {
+		reports = chat.Reports
+	}
+
+	newcomers := c.Newcomers
+	if chat.Newcomers != nil {
+		if err := chat.Newcomers.validate(); err != nil {
+			return nil, err
+		}
+
+		newcomers = chat.Newcomers
+	}
+
+	flood := c.Flood
+	if chat.Flood != nil {
+		if err := chat.Flood.validate(); err != nil {
+			return nil, err
+		}
+
+		flood = chat.Flood
+	}
+
+	captcha := c.Captcha
+	if chat.Captcha != nil {
+		if err := chat.Captcha.validate(); err != nil {
+			return nil, err
+		}
+
+		captcha = chat.Captcha
+	}
+
+	warnings := c.Warnings
+	if chat.Warnings != nil {
+		if err := chat.Warnings.validate(); err != nil {
+			return nil, err
+		}
+
+		warnings = chat.Warnings
+	}
+
+	overrides := make(map[string]string, len(chat.Overrides))
+	for name, response := range chat.Overrides {
+		cmd, ok := c.index[name]
+		if !ok {
+			return nil, fmt.Errorf("overrides: %w: %q", ErrUnknownTarget, name)
+		}
+
+		overrides[cmd.Name] = response
+	}
+
+	var commands []Command
+	if chat.inherit() {
+		for _, cmd := range c.Commands {
+			if containsCommand(chat.Disable, cmd) {
+				continue
+			}
+
+			// the alias entries are disabled with their commands
+			if cmd.isAlias() && containsCommand(chat.Disable, *c.index[cmd.Name]) {
+				continue
+			}
+
+			if response, ok := overrides[cmd.Name]; ok {
+				cmd.Response = response
+				cmd.Refs = nil
+			}
+
+			commands = append(commands, cmd)
+		}
+	}
+	commands = append(commands, chat.Commands...)
+
+	view := &Catalog{
+		Version:   c.Version,
+		Sections:  c.Sections,
+		Commands:  commands,
+		Warnings:  warnings,
+		Captcha:   captcha,
+		Flood:     flood,
+		Newcomers: newcomers,
+		Reports:   reports,
+		Audit:     audit,
+		parent:    c,
+		chat:      chat,
+	}
+
+	if err := view.build(sections); err != nil {
+		return nil, err
+	}
+
+	return view, nil
+}
+
+// containsCommand returns true if the names contain the command name or one of its aliases.
+func containsCommand(names []string, cmd Command) bool {
+	for _, name := range names {
+		if name == cmd.Name {
+			return true
+		}
+
+		for _, alias := range cmd.Aliases {
+			if name == alias {
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,204 @@
+package catalog
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCatalog_ForChat(t *testing.T) {
+	t.Parallel()
+
+	c, err := Parse([]byte(`version: 1
+commands:
+  - name: go
+    aliases: [го]
+    link: "@golangGeeks"
+    response: "@golangGeeks"
+  - name: php
+    response: "@phpGeeks"
+  - name: wtf
+    response: "wtf"
+chats:
+  - username: golangGeeks
+    disable: [wtf]
+    overrides:
+      го: 'Вы уже в {{chat "go"}}'
+  - id: -100500
+    inherit: false
+    commands:
+      - name: rules
+        response: "rules"
+`), FormatYAML)
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name         string
+		chat         ChatRef
+		command      string
+		wantOk       bool
+		wantResponse string
+	}{
+		{
+			name:         "Global",
+			chat:         ChatRef{},
+			command:      "wtf",
+			wantOk:       true,
+			wantResponse: "wtf",
+		},
+		{
+			name:         "Unknown chat",
+			chat:         ChatRef{ID: 1, Username: "phpGeeks"},
+			command:      "go",
+			wantOk:       true,
+			wantResponse: "@golangGeeks",
+		},
+		{
+			name:         "Disabled by username",
+			chat:         ChatRef{Username: "GolangGeeks"},
+			command:      "wtf",
+			wantOk:       false,
+			wantResponse: "",
+		},
+		{
+			name:         "Override by alias",
+			chat:         ChatRef{Username: "golangGeeks"},
+			command:      "go",
+			wantOk:       true,
+			wantResponse: "Вы уже в @golangGeeks",
+		},
+		{
+			name:         "Inherited",
+			chat:         ChatRef{Username: "golangGeeks"},
+			command:      "php",
+			wantOk:       true,
+			wantResponse: "@phpGeeks",
+		},
+		{
+			name:         "Chat command by id",
+			chat:         ChatRef{ID: -100500},
+			command:      "rules",
+			wantOk:       true,
+			wantResponse: "rules",
+		},
+		{
+			name:         "Not inherited",
+			chat:         ChatRef{ID: -100500},
+			command:      "php",
+			wantOk:       false,
+			wantResponse: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cmd, ok := c.ForChat(tt.chat).Lookup(tt.command)
+			assert.Equal(t, tt.wantOk, ok)
+
+			if !ok {
+				return
+			}
+
+			got, _, err := cmd.Render(Data{})
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantResponse, got)
+		})
+	}
+}
+
+func TestCatalog_ForChat_Help(t *testing.T) {
+	t.Parallel()
+
+	c, err := Parse([]byte(`version: 1
+commands:
+  - name: go
+    description: Go
+    response: "@golangGeeks"
+  - name: wtf
+    description: WTF
+    response: "wtf"
+chats:
+  - id: -100500
+    disable: [wtf]
+`), FormatYAML)
+	assert.NoError(t, err)
+
+	assert.Equal(t, []string{"[<code>/go</code>] Go\n[<code>/wtf</code>] WTF"}, c.Help(RoleAdmin))
+	assert.Equal(t, []string{"[<code>/go</code>] Go"}, c.ForChat(ChatRef{ID: -100500}).Help(RoleAdmin))
+}
+
+func TestCatalog_buildChats(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		chats   string
+		wantErr error
+	}{
+		{
+			name: "Valid",
+			chats: `
+  - id: -100500
+    disable: [go]`,
+			wantErr: nil,
+		},
+		{
+			name: "No id and username",
+			chats: `
+  - disable: [go]`,
+			wantErr: ErrInvalidChat,
+		},
+		{
+			name: "Duplicate id",
+			chats: `
+  - id: -100500
+  - id: -100500`,
+			wantErr: ErrDuplicateChat,
+		},
+		{
+			name: "Duplicate username",
+			chats: `
+  - username: golangGeeks
+  - username: "@GolangGeeks"`,
+			wantErr: ErrDuplicateChat,
+		},
+		{
+			name: "Unknown disabled command",
+			chats: `
+  - id: -100500
+    disable: [rust]`,
+			wantErr: ErrUnknownTarget,
+		},
+		{
+			name: "Unknown overridden command",
+			chats: `
+  - id: -100500
+    overrides:
+      rust: "@rustGeeks"`,
+			wantErr: ErrUnknownTarget,
+		},
+		{
+			name: "Chat command duplicates global alias",
+			chats: `
+  - id: -100500
+    commands:
+      - name: golang
+        aliases: [go]
+        response: "@golangGeeks"`,
+			wantErr: ErrDuplicateAlias,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := Parse([]byte(`version: 1
+commands:
+  - name: go
+    response: "@golangGeeks"
+chats:`+tt.chats+"\n"), FormatYAML)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package catalog
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// MaxCleanupDelay is the longest delay of the reply deletion, bots can't delete messages older than 48 hours.
+const MaxCleanupDelay = 48 * time.Hour
+
+var ErrInvalidCleanup = errors.New("invalid cleanup")
+
+// Cleanup is the configuration of the deletion of the command messages and the bot replies.
+type Cleanup struct {
+	// Command deletes the command message immediately.
+	Command bool `json:"command" yaml:"command"`
+	// Reply is the delay after which the bot reply is deleted, e.g. 10m, the reply is kept if it's empty.
+	Reply Duration `json:"reply" yaml:"reply"`
+}
+
+// CleanupIn returns the cleanup of the command in the chat, the command cleanup replaces the chat one.
+// The chat may be nil if it has no configuration.
+func (c *Command) CleanupIn(chat *Chat) Cleanup {
+	if c.Cleanup != nil {
+		return *c.Cleanup
+	}
+
+	if chat != nil && chat.Cleanup != nil {
+		return *chat.Cleanup
+	}
+
+	return Cleanup{}
+}
+
+// validate checks the reply deletion delay.
+func (c *Cleanup) validate() error {
+	if c == nil {
+		return nil
+	}
+
+	if c.Reply < 0 || time.Duration(c.Reply) > MaxCleanupDelay {
+		return fmt.Errorf("%w: reply delay must be up to %s", ErrInvalidCleanup, MaxCleanupDelay)
+	}
+
+	return nil
+}
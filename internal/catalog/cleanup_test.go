@@ -0,0 +1,95 @@
+package catalog
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCommand_CleanupIn(t *testing.T) {
+	t.Parallel()
+
+	c, err := Parse([]byte(`version: 1
+commands:
+  - name: php
+    response: "@phpGeeks"
+  - name: rules
+    response: "rules"
+    cleanup:
+      command: true
+chats:
+  - id: -100500
+    cleanup:
+      command: true
+      reply: 10m
+`), FormatYAML)
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		chat    ChatRef
+		command string
+		want    Cleanup
+	}{
+		{
+			name:    "No cleanup",
+			chat:    ChatRef{ID: -100501},
+			command: "php",
+			want:    Cleanup{},
+		},
+		{
+			name:    "Chat cleanup",
+			chat:    ChatRef{ID: -100500},
+			command: "php",
+			want:    Cleanup{Command: true, Reply: Duration(10 * time.Minute)},
+		},
+		{
+			name:    "Command cleanup replaces chat one",
+			chat:    ChatRef{ID: -100500},
+			command: "rules",
+			want:    Cleanup{Command: true},
+		},
+		{
+			name:    "Command cleanup without chat",
+			chat:    ChatRef{},
+			command: "rules",
+			want:    Cleanup{Command: true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			view := c.ForChat(tt.chat)
+
+			cmd, ok := view.Lookup(tt.command)
+			assert.True(t, ok)
+			assert.Equal(t, tt.want, cmd.CleanupIn(view.Chat()))
+		})
+	}
+}
+
+func TestCleanup_validate(t *testing.T) {
+	t.Parallel()
+
+	_, err := Parse([]byte(`version: 1
+commands:
+  - name: php
+    response: "@phpGeeks"
+    cleanup:
+      reply: 3d
+`), FormatYAML)
+	assert.ErrorIs(t, err, ErrInvalidCleanup)
+
+	_, err = Parse([]byte(`version: 1
+commands:
+  - name: php
+    response: "@phpGeeks"
+chats:
+  - id: -100500
+    cleanup:
+      reply: 49h
+`), FormatYAML)
+	assert.ErrorIs(t, err, ErrInvalidCleanup)
+}
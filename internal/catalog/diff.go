@@ -0,0 +1,143 @@
+package catalog
+
+import (
+	"reflect"
+	"slices"
+	"strconv"
+)
+
+// Diff is a difference between two catalogs.
+type Diff struct {
+	Added   []string
+	Removed []string
+	Changed []string
+	// Chats are the chats added, removed or changed, by the ID or @username.
+	Chats []string
+	// Settings are the changed top-level settings sections, e.g. "flood".
+	Settings []string
+}
+
+// Empty returns true if there are no differences.
+func (d Diff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0 &&
+		len(d.Chats) == 0 && len(d.Settings) == 0
+}
+
+// Compare returns names of the commands added, removed or changed in the next catalog,
+// the chats and the settings sections changed in it.
+func Compare(prev, next *Catalog) Diff {
+	prevCommands := make(map[string]*Command, len(prev.Commands))
+	for i := range prev.Commands {
+		prevCommands[prev.Commands[i].Name] = &prev.Commands[i]
+	}
+
+	var d Diff
+	for i := range next.Commands {
+		cmd := &next.Commands[i]
+
+		prevCmd, ok := prevCommands[cmd.Name]
+		switch {
+		case !ok:
+			d.Added = append(d.Added, cmd.Name)
+		case !prevCmd.equal(cmd):
+			d.Changed = append(d.Changed, cmd.Name)
+		}
+
+		delete(prevCommands, cmd.Name)
+	}
+
+	for i := range prev.Commands {
+		if _, ok := prevCommands[prev.Commands[i].Name]; ok {
+			d.Removed = append(d.Removed, prev.Commands[i].Name)
+		}
+	}
+
+	d.Chats = compareChats(prev.Chats, next.Chats)
+	d.Settings = compareSettings(prev, next)
+
+	return d
+}
+
+// compareChats returns the keys of the chats added, removed or changed in the next chats.
+func compareChats(prev, next []Chat) []string {
+	prevChats := make(map[string]*Chat, len(prev))
+	for i := range prev {
+		prevChats[prev[i].key()] = &prev[i]
+	}
+
+	var keys []string
+	for i := range next {
+		chat := &next[i]
+
+		prevChat, ok := prevChats[chat.key()]
+		if !ok || !prevChat.equal(chat) {
+			keys = append(keys, chat.key())
+		}
+
+		delete(prevChats, chat.key())
+	}
+
+	for i := range prev {
+		if _, ok := prevChats[prev[i].key()]; ok {
+			keys = append(keys, prev[i].key())
+		}
+	}
+
+	return keys
+}
+
+// compareSettings returns the names of the top-level settings sections changed in the next catalog.
+func compareSettings(prev, next *Catalog) []string {
+	settings := []struct {
+		name       string
+		prev, next any
+	}{
+		{"sections", prev.Sections, next.Sections},
+		{"warnings", prev.Warnings, next.Warnings},
+		{"captcha", prev.Captcha, next.Captcha},
+		{"flood", prev.Flood, next.Flood},
+		{"newcomers", prev.Newcomers, next.Newcomers},
+		{"reports", prev.Reports, next.Reports},
+		{"audit", prev.Audit, next.Audit},
+		{"federation", prev.Federation, next.Federation},
+	}
+
+	var names []string
+	for _, s := range settings {
+		if !reflect.DeepEqual(s.prev, s.next) {
+			names = append(names, s.name)
+		}
+	}
+
+	return names
+}
+
+// equal returns true if the commands have the same declaration.
+func (c *Command) equal(other *Command) bool {
+	a, b := *c, *other
+	a.body, b.body = "", ""
+	a.tmpl, b.tmpl = nil, nil
+
+	return reflect.DeepEqual(a, b)
+}
+
+// key returns the chat ID or @username if the ID isn't set.
+func (ch *Chat) key() string {
+	if ch.ID != 0 {
+		return strconv.FormatInt(ch.ID, 10)
+	}
+
+	return "@" + ch.Username
+}
+
+// equal returns true if the chats have the same declaration.
+func (ch *Chat) equal(other *Chat) bool {
+	if !slices.EqualFunc(ch.Commands, other.Commands, func(a, b Command) bool { return a.equal(&b) }) {
+		return false
+	}
+
+	a, b := *ch, *other
+	a.Commands, b.Commands = nil, nil
+
+	return reflect.DeepEqual(a, b)
+}
@@ -0,0 +1,92 @@
+package catalog
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompare(t *testing.T) {
+	t.Parallel()
+
+	type args struct {
+		prev *Catalog
+		next *Catalog
+	}
+	tests := []struct {
+		name      string
+		args      args
+		want      Diff
+		wantEmpty bool
+	}{
+		{
+			name: "Same",
+			args: args{
+				prev: &Catalog{Commands: []Command{{Name: "php", Response: "php"}}},
+				next: &Catalog{Commands: []Command{{Name: "php", Response: "php"}}},
+			},
+			want:      Diff{},
+			wantEmpty: true,
+		},
+		{
+			name: "Added, removed and changed",
+			args: args{
+				prev: &Catalog{Commands: []Command{
+					{Name: "php", Response: "php"},
+					{Name: "wtf", Response: "wtf"},
+					{Name: "go", Aliases: []string{"го"}, Response: "go"},
+				}},
+				next: &Catalog{Commands: []Command{
+					{Name: "php", Response: "php"},
+					{Name: "go", Aliases: []string{"го", "гоу"}, Response: "go"},
+					{Name: "rust", Response: "rust"},
+				}},
+			},
+			want: Diff{
+				Added:   []string{"rust"},
+				Removed: []string{"wtf"},
+				Changed: []string{"go"},
+			},
+			wantEmpty: false,
+		},
+		{
+			name: "Chats and settings",
+			args: args{
+				prev: &Catalog{
+					Commands: []Command{{Name: "php", Response: "php"}},
+					Chats: []Chat{
+						{ID: 300600, Overrides: map[string]string{"php": "PHP"}},
+						{Username: "goGeeks", Commands: []Command{{Name: "go", Response: "go"}}},
+						{Username: "jobGeeks"},
+					},
+					Flood:   &Flood{Messages: 5},
+					Captcha: &Captcha{},
+				},
+				next: &Catalog{
+					Commands: []Command{{Name: "php", Response: "php"}},
+					Chats: []Chat{
+						{ID: 300600, Overrides: map[string]string{"php": "PHP 8"}},
+						{Username: "goGeeks", Commands: []Command{{Name: "go", Response: "go", body: "go"}}},
+						{ID: 300700},
+					},
+					Flood:   &Flood{Messages: 10},
+					Captcha: &Captcha{},
+				},
+			},
+			want: Diff{
+				Chats:    []string{"300600", "300700", "@jobGeeks"},
+				Settings: []string{"flood"},
+			},
+			wantEmpty: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := Compare(tt.args.prev, tt.args.next)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantEmpty, got.Empty())
+		})
+	}
+}
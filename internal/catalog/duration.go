@@ -0,0 +1,39 @@
+package catalog
+
+import (
+	"fmt"
+	"time"
+
+	"geeksonator/pkg/duration"
+)
+
+const (
+	// minRestriction and maxRestriction are the bounds of a restriction, Telegram treats the restrictions
+	// out of them as permanent.
+	minRestriction = 30 * time.Second
+	maxRestriction = 366 * 24 * time.Hour
+)
+
+// Duration is the duration in the catalog, e.g. 30m, 1d or 2ч.
+type Duration time.Duration
+
+// UnmarshalText parses the duration.
+func (d *Duration) UnmarshalText(text []byte) error {
+	parsed, err := duration.Parse(string(text))
+	if err != nil {
+		return fmt.Errorf("duration.Parse: %w", err)
+	}
+
+	*d = Duration(parsed)
+
+	return nil
+}
+
+// validateRestriction checks the restriction duration, zero is the permanent restriction.
+func (d Duration) validateRestriction() error {
+	if d != 0 && (time.Duration(d) < minRestriction || time.Duration(d) > maxRestriction) {
+		return fmt.Errorf("duration must be from %s to %s", minRestriction, maxRestriction)
+	}
+
+	return nil
+}
@@ -0,0 +1,71 @@
+package catalog
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+var ErrInvalidFederation = errors.New("invalid federation")
+
+// Federation is the federation of the community chats sharing the bans issued with /fban.
+type Federation struct {
+	// Disable opts the chat out of the federation, it's the only option of the chat entry.
+	Disable bool `json:"disable" yaml:"disable"`
+	// Chats are the IDs of the federation chats.
+	Chats []int64 `json:"chats" yaml:"chats"`
+}
+
+// FederationFor returns the federation of the chat with the chats which haven't opted out,
+// false if the chat isn't a federation member or opted out.
+func (c *Catalog) FederationFor(chatID int64) (*Federation, bool) {
+	if c.Federation == nil || c.Federation.Disable {
+		return nil, false
+	}
+
+	chats := make([]int64, 0, len(c.Federation.Chats))
+	for _, id := range c.Federation.Chats {
+		if view, ok := c.byID[id]; ok && view.chat.Federation != nil && view.chat.Federation.Disable {
+			continue
+		}
+
+		chats = append(chats, id)
+	}
+
+	if !slices.Contains(chats, chatID) {
+		return nil, false
+	}
+
+	return &Federation{Chats: chats}, true
+}
+
+// validate checks the chats of the global federation.
+func (f *Federation) validate() error {
+	if f == nil {
+		return nil
+	}
+
+	seen := make(map[int64]struct{}, len(f.Chats))
+	for _, id := range f.Chats {
+		if id == 0 {
+			return fmt.Errorf("%w: chat ID is empty", ErrInvalidFederation)
+		}
+
+		if _, ok := seen[id]; ok {
+			return fmt.Errorf("%w: duplicate chat %d", ErrInvalidFederation, id)
+		}
+
+		seen[id] = struct{}{}
+	}
+
+	return nil
+}
+
+// validateChat checks the federation option of the chat entry.
+func (f *Federation) validateChat() error {
+	if f != nil && len(f.Chats) > 0 {
+		return fmt.Errorf("%w: chats are set in the global federation only", ErrInvalidFederation)
+	}
+
+	return nil
+}
@@ -0,0 +1,99 @@
+package catalog
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCatalog_FederationFor(t *testing.T) {
+	t.Parallel()
+
+	c, err := Parse([]byte(`version: 1
+federation:
+  chats: [-100500, -100501, -100502]
+commands:
+  - name: php
+    response: "@phpGeeks"
+chats:
+  - id: -100502
+    federation:
+      disable: true
+`), FormatYAML)
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		chatID int64
+		want   *Federation
+		wantOk bool
+	}{
+		{
+			name:   "Member",
+			chatID: -100500,
+			want:   &Federation{Chats: []int64{-100500, -100501}},
+			wantOk: true,
+		},
+		{
+			name:   "Opted out",
+			chatID: -100502,
+			want:   nil,
+			wantOk: false,
+		},
+		{
+			name:   "Not a member",
+			chatID: -100503,
+			want:   nil,
+			wantOk: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, ok := c.FederationFor(tt.chatID)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantOk, ok)
+		})
+	}
+}
+
+func TestFederation_validate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		data string
+	}{
+		{
+			name: "Duplicate chat",
+			data: `version: 1
+federation:
+  chats: [-100500, -100500]
+commands:
+  - name: php
+    response: php
+`,
+		},
+		{
+			name: "Chats of the chat entry",
+			data: `version: 1
+commands:
+  - name: php
+    response: php
+chats:
+  - id: -100500
+    federation:
+      chats: [-100501]
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := Parse([]byte(tt.data), FormatYAML)
+			assert.ErrorIs(t, err, ErrInvalidFederation)
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package catalog
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// DefaultFloodMute is the mute duration of the flooder if the duration isn't set.
+const DefaultFloodMute = 10 * time.Minute
+
+var ErrInvalidFlood = errors.New("invalid flood protection")
+
+// Flood is the configuration of the flood protection, e.g. 15 messages in 10 seconds.
+type Flood struct {
+	// Disable disables the global flood protection in the chat.
+	Disable bool `json:"disable" yaml:"disable"`
+	// Messages is the number of the messages within the window the user is muted at.
+	Messages int `json:"messages" yaml:"messages"`
+	// Window is the sliding window of the messages, e.g. 10s.
+	Window Duration `json:"window" yaml:"window"`
+	// Mute is the mute duration of the user who exceeded the limit, 10m by default.
+	Mute Duration `json:"mute" yaml:"mute"`
+}
+
+// MuteValue returns the mute duration of the flooder.
+func (f *Flood) MuteValue() time.Duration {
+	if f.Mute == 0 {
+		return DefaultFloodMute
+	}
+
+	return time.Duration(f.Mute)
+}
+
+// FloodConfig returns the configuration of the flood protection, false if it's disabled.
+func (c *Catalog) FloodConfig() (*Flood, bool) {
+	if c.Flood == nil || c.Flood.Disable {
+		return nil, false
+	}
+
+	return c.Flood, true
+}
+
+// validate checks the limit of the enabled flood protection.
+func (f *Flood) validate() error {
+	if f == nil || f.Disable {
+		return nil
+	}
+
+	if f.Messages < 2 || f.Window <= 0 {
+		return fmt.Errorf("%w: messages must be at least 2 and window must be positive", ErrInvalidFlood)
+	}
+
+	if err := f.Mute.validateRestriction(); err != nil {
+		return fmt.Errorf("%w: mute: %v", ErrInvalidFlood, err)
+	}
+
+	return nil
+}
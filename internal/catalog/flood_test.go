@@ -0,0 +1,140 @@
+package catalog
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCatalog_FloodConfig(t *testing.T) {
+	t.Parallel()
+
+	c, err := Parse([]byte(`version: 1
+flood:
+  messages: 15
+  window: 10s
+commands:
+  - name: php
+    response: "@phpGeeks"
+chats:
+  - id: -100500
+    flood:
+      disable: true
+  - id: -100501
+    flood:
+      messages: 5
+      window: 1m
+      mute: 1h
+`), FormatYAML)
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		chat   ChatRef
+		want   *Flood
+		wantOk bool
+	}{
+		{
+			name:   "Global",
+			chat:   ChatRef{},
+			want:   &Flood{Messages: 15, Window: Duration(10 * time.Second)},
+			wantOk: true,
+		},
+		{
+			name:   "Disabled in the chat",
+			chat:   ChatRef{ID: -100500},
+			want:   nil,
+			wantOk: false,
+		},
+		{
+			name:   "Chat limit",
+			chat:   ChatRef{ID: -100501},
+			want:   &Flood{Messages: 5, Window: Duration(time.Minute), Mute: Duration(time.Hour)},
+			wantOk: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, ok := c.ForChat(tt.chat).FloodConfig()
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantOk, ok)
+		})
+	}
+}
+
+func TestFlood_MuteValue(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, DefaultFloodMute, (&Flood{}).MuteValue())
+	assert.Equal(t, time.Hour, (&Flood{Mute: Duration(time.Hour)}).MuteValue())
+}
+
+func TestFlood_validate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		data string
+	}{
+		{
+			name: "No window",
+			data: `version: 1
+flood:
+  messages: 15
+commands:
+  - name: php
+    response: php
+`,
+		},
+		{
+			name: "Every message is flood",
+			data: `version: 1
+flood:
+  messages: 1
+  window: 10s
+commands:
+  - name: php
+    response: php
+`,
+		},
+		{
+			name: "Too short mute",
+			data: `version: 1
+commands:
+  - name: php
+    response: php
+chats:
+  - id: -100500
+    flood:
+      messages: 15
+      window: 10s
+      mute: 5s
+`,
+		},
+		{
+			name: "Too long mute",
+			data: `version: 1
+commands:
+  - name: php
+    response: php
+chats:
+  - id: -100500
+    flood:
+      messages: 15
+      window: 10s
+      mute: 367d
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := Parse([]byte(tt.data), FormatYAML)
+			assert.ErrorIs(t, err, ErrInvalidFlood)
+		})
+	}
+}
@@ -0,0 +1,163 @@
+package catalog
+
+import (
+	"strings"
+	"unicode/utf16"
+)
+
+// MaxMessageLength is the maximum length of the telegram message text.
+const MaxMessageLength = 4096
+
+// Help returns the list of the commands available for the role, grouped by sections.
+// The list is split into several messages if it exceeds MaxMessageLength.
+func (c *Catalog) Help(role Role) []string {
+	var b strings.Builder
+
+	if cmd, ok := c.helpCommand(); ok && cmd.Response != "" {
+		b.WriteString(cmd.Response)
+	}
+
+	c.writeSection(&b, Section{}, role)
+	for _, section := range c.Sections {
+		c.writeSection(&b, section, role)
+	}
+
+	return SplitMessage(strings.TrimSpace(b.String()), MaxMessageLength)
+}
+
+// helpCommand returns the first command with the help handler.
+func (c *Catalog) helpCommand() (*Command, bool) {
+	for i := range c.Commands {
+		if c.Commands[i].Handler == HandlerHelp {
+			return &c.Commands[i], true
+		}
+	}
+
+	return nil, false
+}
+
+// writeSection writes the section title and its commands available for the role.
+// Commands without a section are written for the empty section without a title.
+func (c *Catalog) writeSection(b *strings.Builder, section Section, role Role) {
+	var lines []string
+	for i := range c.Commands {
+		cmd := &c.Commands[i]
+		if cmd.isAlias() || cmd.Section != section.ID || !cmd.AllowedFor(role) {
+			continue
+		}
+
+		lines = append(lines, c.helpLine(cmd))
+	}
+
+	if len(lines) == 0 {
+		return
+	}
+
+	b.WriteString("\n")
+	if section.Title != "" {
+		b.WriteString("<b>" + section.Title + "</b>\n")
+	}
+	b.WriteString(strings.Join(lines, "\n"))
+	b.WriteString("\n")
+}
+
+// helpLine returns the help line of the command, e.g. "[<code>/php</code>, <code>/пхп</code>] description".
+// The names of the alias entries are listed with the aliases of the command.
+func (c *Catalog) helpLine(cmd *Command) string {
+	names := make([]string, 0, len(cmd.Aliases)+1)
+	for _, name := range append([]string{cmd.Name}, cmd.Aliases...) {
+		names = append(names, "<code>/"+name+"</code>")
+	}
+
+	for i := range c.Commands {
+		if entry := &c.Commands[i]; entry.isAlias() && c.index[entry.Name] == cmd {
+			names = append(names, "<code>/"+entry.Name+"</code>")
+		}
+	}
+
+	line := "[" + strings.Join(names, ", ") + "]"
+	if cmd.Description != "" {
+		line += " " + cmd.Description
+	}
+
+	return line
+}
+
+// SplitMessage splits the text by lines into parts no longer than limit UTF-16 code units,
+// the unit Telegram uses to measure messages. Lines longer than limit are split as is.
+func SplitMessage(text string, limit int) []string {
+	if text == "" {
+		return nil
+	}
+
+	var parts []string
+	var part strings.Builder
+	partLen := 0
+
+	flush := func() {
+		if part.Len() > 0 {
+			parts = append(parts, part.String())
+			part.Reset()
+			partLen = 0
+		}
+	}
+
+	for _, line := range strings.Split(text, "\n") {
+		for _, chunk := range splitLine(line, limit) {
+			chunkLen := utf16Len(chunk)
+
+			sep := 0
+			if part.Len() > 0 {
+				sep = 1
+			}
+
+			if partLen+sep+chunkLen > limit {
+				flush()
+				sep = 0
+			}
+
+			if sep > 0 {
+				part.WriteString("\n")
+			}
+			part.WriteString(chunk)
+			partLen += sep + chunkLen
+		}
+	}
+	flush()
+
+	return parts
+}
+
+// splitLine splits the line into chunks no longer than limit UTF-16 code units.
+func splitLine(line string, limit int) []string {
+	if utf16Len(line) <= limit {
+		return []string{line}
+	}
+
+	var chunks []string
+	var chunk []rune
+	chunkLen := 0
+	for _, r := range line {
+		runeLen := utf16.RuneLen(r)
+		if chunkLen+runeLen > limit {
+			chunks = append(chunks, string(chunk))
+			chunk = chunk[:0]
+			chunkLen = 0
+		}
+
+		chunk = append(chunk, r)
+		chunkLen += runeLen
+	}
+
+	return append(chunks, string(chunk))
+}
+
+// utf16Len returns the length of the string in UTF-16 code units.
+func utf16Len(s string) int {
+	n := 0
+	for _, r := range s {
+		n += utf16.RuneLen(r)
+	}
+
+	return n
+}
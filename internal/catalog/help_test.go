@@ -0,0 +1,162 @@
+package catalog
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCatalog_Help(t *testing.T) {
+	t.Parallel()
+
+	c := &Catalog{
+		Version: Version,
+		Sections: []Section{
+			{ID: "chats", Title: "Чаты"},
+			{ID: "etiquette", Title: "Этикет"},
+		},
+		Commands: []Command{
+			{
+				Name:        "help",
+				Aliases:     []string{"хелп"},
+				Description: "Список команд",
+				Role:        RoleMember,
+				Handler:     HandlerHelp,
+				Response:    "Команды бота:",
+			},
+			{
+				Name:        "php",
+				Aliases:     []string{"пхп"},
+				Description: "@phpGeeks",
+				Section:     "chats",
+				Response:    "@phpGeeks - Best PHP chat",
+			},
+			{
+				Name:     "nometa",
+				Section:  "etiquette",
+				Role:     RoleMember,
+				Response: "nometa.xyz",
+			},
+		},
+	}
+	assert.NoError(t, c.validate())
+
+	tests := []struct {
+		name string
+		role Role
+		want []string
+	}{
+		{
+			name: "Admin",
+			role: RoleAdmin,
+			want: []string{`Команды бота:
+[<code>/help</code>, <code>/хелп</code>] Список команд
+
+<b>Чаты</b>
+[<code>/php</code>, <code>/пхп</code>] @phpGeeks
+
+<b>Этикет</b>
+[<code>/nometa</code>]`},
+		},
+		{
+			name: "Member",
+			role: RoleMember,
+			want: []string{`Команды бота:
+[<code>/help</code>, <code>/хелп</code>] Список команд
+
+<b>Этикет</b>
+[<code>/nometa</code>]`},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := c.Help(tt.role)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestDefault_Help(t *testing.T) {
+	t.Parallel()
+
+	c, err := Default()
+	assert.NoError(t, err)
+
+	for _, cmd := range c.Commands {
+		for _, alias := range cmd.Aliases {
+			got, ok := c.Lookup(alias)
+			assert.True(t, ok)
+			assert.Equal(t, cmd.Name, got.Name)
+		}
+	}
+
+	help := c.Help(RoleAdmin)
+	assert.Len(t, help, 1)
+	assert.Contains(t, help[0], "[<code>/db</code>, <code>/бд</code>, <code>/дб</code>]")
+}
+
+func TestSplitMessage(t *testing.T) {
+	t.Parallel()
+
+	type args struct {
+		text  string
+		limit int
+	}
+	tests := []struct {
+		name string
+		args args
+		want []string
+	}{
+		{
+			name: "Empty",
+			args: args{
+				text:  "",
+				limit: 10,
+			},
+			want: nil,
+		},
+		{
+			name: "Fits",
+			args: args{
+				text:  "line 1\nline 2",
+				limit: 13,
+			},
+			want: []string{"line 1\nline 2"},
+		},
+		{
+			name: "By lines",
+			args: args{
+				text:  "line 1\nline 2\nline 3",
+				limit: 13,
+			},
+			want: []string{"line 1\nline 2", "line 3"},
+		},
+		{
+			name: "Long line",
+			args: args{
+				text:  "строка\n" + strings.Repeat("я", 12),
+				limit: 5,
+			},
+			want: []string{"строк", "а", "яяяяя", "яяяяя", "яя"},
+		},
+		{
+			name: "UTF-16 surrogate pairs",
+			args: args{
+				text:  "😀😀😀",
+				limit: 4,
+			},
+			want: []string{"😀😀", "😀"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := SplitMessage(tt.args.text, tt.args.limit)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package catalog
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// DefaultJobsCurrency is the currency of the job board if the currency isn't set.
+const DefaultJobsCurrency = "RUB"
+
+var ErrInvalidJobs = errors.New("invalid job board")
+
+// currencyRe matches the ISO 4217 currency codes, e.g. USD.
+var currencyRe = regexp.MustCompile(`^[A-Z]{3}$`)
+
+// Jobs is the configuration of the job board chat, the posts must follow the job post template.
+type Jobs struct {
+	// MinSalary is the minimum monthly salary in the currency, zero disables the check.
+	MinSalary int64 `json:"minSalary" yaml:"minSalary"`
+	// Currency is the currency of the minimum salary and of the amounts without a currency, RUB by default.
+	Currency string `json:"currency" yaml:"currency"`
+	// Rates are the offline prices of the other currencies in the currency, e.g. USD: 90.
+	Rates map[string]float64 `json:"rates" yaml:"rates"`
+	// Hashtags are the hashtags required in the posts, e.g. #вакансия.
+	Hashtags []string `json:"hashtags" yaml:"hashtags"`
+}
+
+// CurrencyValue returns the currency of the job board.
+func (j *Jobs) CurrencyValue() string {
+	if j.Currency == "" {
+		return DefaultJobsCurrency
+	}
+
+	return j.Currency
+}
+
+// JobsConfig returns the job board configuration of the chat, false if the chat isn't a job board.
+func (c *Catalog) JobsConfig() (*Jobs, bool) {
+	if c.chat == nil || c.chat.Jobs == nil {
+		return nil, false
+	}
+
+	return c.chat.Jobs, true
+}
+
+// validate checks the minimum salary, the currencies and the hashtags of the job board.
+func (j *Jobs) validate() error {
+	if j == nil {
+		return nil
+	}
+
+	if j.MinSalary < 0 {
+		return fmt.Errorf("%w: minSalary must not be negative", ErrInvalidJobs)
+	}
+
+	if j.Currency != "" && !currencyRe.MatchString(j.Currency) {
+		return fmt.Errorf("%w: currency %q isn't an upper case ISO code", ErrInvalidJobs, j.Currency)
+	}
+
+	for code, rate := range j.Rates {
+		if !currencyRe.MatchString(code) {
+			return fmt.Errorf("%w: rate currency %q isn't an upper case ISO code", ErrInvalidJobs, code)
+		}
+
+		if rate <= 0 {
+			return fmt.Errorf("%w: rate of %s must be positive", ErrInvalidJobs, code)
+		}
+	}
+
+	for _, tag := range j.Hashtags {
+		if !strings.HasPrefix(tag, "#") || len(tag) < 2 || strings.ContainsAny(tag, " \t\n") {
+			return fmt.Errorf("%w: hashtag %q", ErrInvalidJobs, tag)
+		}
+	}
+
+	return nil
+}
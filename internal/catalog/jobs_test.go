@@ -0,0 +1,78 @@
+package catalog
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCatalog_JobsConfig(t *testing.T) {
+	t.Parallel()
+
+	c, err := Parse([]byte(`version: 1
+commands:
+  - name: php
+    response: "@phpGeeks"
+chats:
+  - id: -100500
+    jobs:
+      minSalary: 250000
+      rates:
+        USD: 90
+      hashtags: ["#вакансия"]
+  - id: -100501
+`), FormatYAML)
+	assert.NoError(t, err)
+
+	got, ok := c.ForChat(ChatRef{ID: -100500}).JobsConfig()
+	assert.True(t, ok)
+	assert.Equal(t, &Jobs{MinSalary: 250000, Rates: map[string]float64{"USD": 90}, Hashtags: []string{"#вакансия"}}, got)
+	assert.Equal(t, DefaultJobsCurrency, got.CurrencyValue())
+
+	_, ok = c.ForChat(ChatRef{ID: -100501}).JobsConfig()
+	assert.False(t, ok, "not a job board")
+
+	_, ok = c.ForChat(ChatRef{ID: -100502}).JobsConfig()
+	assert.False(t, ok, "global catalog")
+}
+
+func TestJobs_validate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		jobs string
+	}{
+		{
+			name: "Negative minimum",
+			jobs: "minSalary: -1",
+		},
+		{
+			name: "Lower case currency",
+			jobs: "currency: usd",
+		},
+		{
+			name: "Zero rate",
+			jobs: "rates: {USD: 0}",
+		},
+		{
+			name: "Hashtag without hash",
+			jobs: "hashtags: [вакансия]",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := Parse([]byte(`version: 1
+commands:
+  - name: php
+    response: php
+chats:
+  - id: -100500
+    jobs: {`+tt.jobs+`}
+`), FormatYAML)
+			assert.ErrorIs(t, err, ErrInvalidJobs)
+		})
+	}
+}
@@ -0,0 +1,104 @@
+package catalog
+
+import (
+	"bytes"
+	_ "embed" // for the default catalog
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Format is a catalog file format.
+type Format string
+
+const (
+	FormatYAML Format = "yaml"
+	FormatJSON Format = "json"
+)
+
+var ErrUnknownFormat = errors.New("unknown catalog format")
+
+//go:embed default.yaml
+var defaultCatalog []byte
+
+// Default returns the built-in phpGeeks catalog.
+func Default() (*Catalog, error) {
+	c, err := Parse(defaultCatalog, FormatYAML)
+	if err != nil {
+		return nil, fmt.Errorf("Parse: %v", err)
+	}
+
+	return c, nil
+}
+
+// Load reads and validates the catalog file, the format is detected by the file extension.
+// The built-in catalog is returned if the path is empty.
+func Load(path string) (*Catalog, error) {
+	if path == "" {
+		return Default()
+	}
+
+	format, err := formatByPath(path)
+	if err != nil {
+		return nil, fmt.Errorf("formatByPath: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("os.ReadFile: %v", err)
+	}
+
+	c, err := Parse(data, format)
+	if err != nil {
+		return nil, fmt.Errorf("Parse(%s): %v", path, err)
+	}
+
+	return c, nil
+}
+
+// Parse decodes and validates the catalog. Unknown fields are rejected.
+func Parse(data []byte, format Format) (*Catalog, error) {
+	var c Catalog
+
+	switch format {
+	case FormatYAML:
+		dec := yaml.NewDecoder(bytes.NewReader(data))
+		dec.KnownFields(true)
+
+		if err := dec.Decode(&c); err != nil {
+			return nil, fmt.Errorf("yaml.Decode: %w", err)
+		}
+	case FormatJSON:
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.DisallowUnknownFields()
+
+		if err := dec.Decode(&c); err != nil {
+			return nil, fmt.Errorf("json.Decode: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownFormat, format)
+	}
+
+	if err := c.validate(); err != nil {
+		return nil, fmt.Errorf("validate: %w", err)
+	}
+
+	return &c, nil
+}
+
+// formatByPath detects the catalog format by the file extension.
+func formatByPath(path string) (Format, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		return FormatYAML, nil
+	case ".json":
+		return FormatJSON, nil
+	}
+
+	return "", fmt.Errorf("%w: %s", ErrUnknownFormat, path)
+}
@@ -0,0 +1,160 @@
+package catalog
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDefault(t *testing.T) {
+	t.Parallel()
+
+	c, err := Default()
+	assert.NoError(t, err)
+
+	cmd, ok := c.Lookup("лара")
+	assert.True(t, ok)
+	assert.Equal(t, "@laravel_pro - Официальный чат для всех Laravel программистов.", cmd.Response)
+}
+
+func TestLoad(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	yamlPath := filepath.Join(dir, "commands.yaml")
+	assert.NoError(t, os.WriteFile(yamlPath, []byte(`version: 1
+commands:
+  - name: php
+    aliases: [пхп]
+    response: "@phpGeeks"
+`), 0o600))
+
+	jsonPath := filepath.Join(dir, "commands.json")
+	assert.NoError(t, os.WriteFile(jsonPath, []byte(`{
+  "version": 1,
+  "commands": [{"name": "go", "aliases": ["го"], "response": "@golangGeeks"}]
+}`), 0o600))
+
+	tests := []struct {
+		name     string
+		path     string
+		lookup   string
+		wantResp string
+		wantErr  bool
+	}{
+		{
+			name:     "Default",
+			path:     "",
+			lookup:   "php",
+			wantResp: "@phpGeeks - Best PHP chat",
+		},
+		{
+			name:     "YAML",
+			path:     yamlPath,
+			lookup:   "пхп",
+			wantResp: "@phpGeeks",
+		},
+		{
+			name:     "JSON",
+			path:     jsonPath,
+			lookup:   "го",
+			wantResp: "@golangGeeks",
+		},
+		{
+			name:    "Unknown format",
+			path:    filepath.Join(dir, "commands.toml"),
+			wantErr: true,
+		},
+		{
+			name:    "Not found",
+			path:    filepath.Join(dir, "not_found.yaml"),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := Load(tt.path)
+			if tt.wantErr {
+				assert.Error(t, err)
+
+				return
+			}
+			assert.NoError(t, err)
+
+			cmd, ok := got.Lookup(tt.lookup)
+			assert.True(t, ok)
+			assert.Equal(t, tt.wantResp, cmd.Response)
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	type args struct {
+		data   string
+		format Format
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr error
+	}{
+		{
+			name: "Unknown field",
+			args: args{
+				data: `version: 1
+commands:
+  - name: php
+    response: php
+    text: php
+`,
+				format: FormatYAML,
+			},
+		},
+		{
+			name: "Unknown JSON field",
+			args: args{
+				data:   `{"version": 1, "commands": [{"name": "php", "response": "php", "text": "php"}]}`,
+				format: FormatJSON,
+			},
+		},
+		{
+			name: "Validation error",
+			args: args{
+				data: `version: 2
+commands:
+  - name: php
+    response: php
+`,
+				format: FormatYAML,
+			},
+			wantErr: ErrUnsupportedVersion,
+		},
+		{
+			name: "Unknown format",
+			args: args{
+				data:   `version = 1`,
+				format: "toml",
+			},
+			wantErr: ErrUnknownFormat,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := Parse([]byte(tt.args.data), tt.args.format)
+			assert.Nil(t, got)
+			assert.Error(t, err)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			}
+		})
+	}
+}
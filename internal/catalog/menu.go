@@ -0,0 +1,164 @@
+package catalog
+
+import (
+	"html"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	// MaxMenuCommands is the maximum number of the commands in the Telegram menu.
+	MaxMenuCommands = 100
+	// MaxMenuDescriptionLength is the maximum length of the command description in the Telegram menu.
+	MaxMenuDescriptionLength = 256
+)
+
+// tagRe matches HTML tags of the descriptions.
+var tagRe = regexp.MustCompile(`<[^>]*>`)
+
+// ScopeType is the type of the Telegram menu scope, the values match the Bot API scope types.
+type ScopeType string
+
+const (
+	// ScopeDefault is the menu of all chats.
+	ScopeDefault ScopeType = "default"
+	// ScopeAllChatAdministrators is the menu of the administrators of all chats.
+	ScopeAllChatAdministrators ScopeType = "all_chat_administrators"
+	// ScopeChat is the menu of the chat.
+	ScopeChat ScopeType = "chat"
+	// ScopeChatAdministrators is the menu of the administrators of the chat.
+	ScopeChatAdministrators ScopeType = "chat_administrators"
+)
+
+// Scope is the scope of the Telegram menu.
+type Scope struct {
+	Type ScopeType
+	// ChatID is the chat of the chat scopes.
+	ChatID int64
+}
+
+// MenuKey identifies the menu by its scope and language.
+type MenuKey struct {
+	Scope    Scope
+	Language string
+}
+
+// MenuCommand is the command of the Telegram menu.
+type MenuCommand struct {
+	Name        string
+	Description string
+}
+
+// Menu is the list of the commands shown in the Telegram menu for the scope and the language.
+// An empty menu means the scope has no commands and falls back to the wider scope.
+type Menu struct {
+	MenuKey
+
+	Commands []MenuCommand
+}
+
+// Menus returns the Telegram menus of the catalog. Member commands go to the chat scopes and
+// admin commands go to the administrator scopes. Chats are scoped only when they are set by ID,
+// Telegram doesn't accept usernames of groups. Commands without descriptions are not listed.
+func (c *Catalog) Menus() []Menu {
+	languages := append([]string{""}, c.languages()...)
+
+	var menus []Menu
+	for _, language := range languages {
+		menus = append(menus,
+			c.menu(Scope{Type: ScopeDefault}, language, RoleMember),
+			c.menu(Scope{Type: ScopeAllChatAdministrators}, language, RoleAdmin),
+		)
+
+		for _, chat := range c.Chats {
+			if chat.ID == 0 {
+				continue
+			}
+
+			view := c.byID[chat.ID]
+			menus = append(menus,
+				view.menu(Scope{Type: ScopeChat, ChatID: chat.ID}, language, RoleMember),
+				view.menu(Scope{Type: ScopeChatAdministrators, ChatID: chat.ID}, language, RoleAdmin),
+			)
+		}
+	}
+
+	return menus
+}
+
+// menu returns the menu of the commands available for the role.
+func (c *Catalog) menu(scope Scope, language string, role Role) Menu {
+	menu := Menu{
+		MenuKey: MenuKey{
+			Scope:    scope,
+			Language: language,
+		},
+	}
+
+	for i := range c.Commands {
+		cmd := &c.Commands[i]
+		if !cmd.AllowedFor(role) {
+			continue
+		}
+
+		description := cmd.menuDescription(language)
+		if description == "" {
+			continue
+		}
+
+		menu.Commands = append(menu.Commands, MenuCommand{
+			Name:        cmd.Name,
+			Description: description,
+		})
+
+		if len(menu.Commands) == MaxMenuCommands {
+			break
+		}
+	}
+
+	return menu
+}
+
+// languages returns the sorted language codes of the localized descriptions.
+func (c *Catalog) languages() []string {
+	set := make(map[string]struct{})
+
+	add := func(commands []Command) {
+		for _, cmd := range commands {
+			for language := range cmd.Descriptions {
+				set[language] = struct{}{}
+			}
+		}
+	}
+
+	add(c.Commands)
+	for _, chat := range c.Chats {
+		add(chat.Commands)
+	}
+
+	languages := make([]string, 0, len(set))
+	for language := range set {
+		languages = append(languages, language)
+	}
+	sort.Strings(languages)
+
+	return languages
+}
+
+// menuDescription returns the plain text description in the language, the help description by default.
+func (c *Command) menuDescription(language string) string {
+	description, ok := c.Descriptions[language]
+	if !ok {
+		description = c.Description
+	}
+
+	description = strings.TrimSpace(html.UnescapeString(tagRe.ReplaceAllString(description, "")))
+
+	if utf8.RuneCountInString(description) > MaxMenuDescriptionLength {
+		description = string([]rune(description)[:MaxMenuDescriptionLength-1]) + "…"
+	}
+
+	return description
+}
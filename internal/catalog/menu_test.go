@@ -0,0 +1,112 @@
+package catalog
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCatalog_Menus(t *testing.T) {
+	t.Parallel()
+
+	c, err := Parse([]byte(`version: 1
+commands:
+  - name: help
+    aliases: [хелп]
+    description: Список команд
+    descriptions:
+      en: List of commands
+    role: member
+    handler: help
+  - name: go
+    description: "<b>@golangGeeks</b> &amp; гоферы"
+    response: "@golangGeeks"
+  - name: hidden
+    response: "hidden"
+chats:
+  - id: -100500
+    inherit: false
+    commands:
+      - name: rules
+        description: Правила
+        response: "rules"
+  - username: phpGeeks
+    disable: [go]
+`), FormatYAML)
+	assert.NoError(t, err)
+
+	want := []Menu{
+		{
+			MenuKey:  MenuKey{Scope: Scope{Type: ScopeDefault}, Language: ""},
+			Commands: []MenuCommand{{Name: "help", Description: "Список команд"}},
+		},
+		{
+			MenuKey: MenuKey{Scope: Scope{Type: ScopeAllChatAdministrators}, Language: ""},
+			Commands: []MenuCommand{
+				{Name: "help", Description: "Список команд"},
+				{Name: "go", Description: "@golangGeeks & гоферы"},
+			},
+		},
+		{
+			MenuKey:  MenuKey{Scope: Scope{Type: ScopeChat, ChatID: -100500}, Language: ""},
+			Commands: nil,
+		},
+		{
+			MenuKey:  MenuKey{Scope: Scope{Type: ScopeChatAdministrators, ChatID: -100500}, Language: ""},
+			Commands: []MenuCommand{{Name: "rules", Description: "Правила"}},
+		},
+		{
+			MenuKey:  MenuKey{Scope: Scope{Type: ScopeDefault}, Language: "en"},
+			Commands: []MenuCommand{{Name: "help", Description: "List of commands"}},
+		},
+		{
+			MenuKey: MenuKey{Scope: Scope{Type: ScopeAllChatAdministrators}, Language: "en"},
+			Commands: []MenuCommand{
+				{Name: "help", Description: "List of commands"},
+				{Name: "go", Description: "@golangGeeks & гоферы"},
+			},
+		},
+		{
+			MenuKey:  MenuKey{Scope: Scope{Type: ScopeChat, ChatID: -100500}, Language: "en"},
+			Commands: nil,
+		},
+		{
+			MenuKey:  MenuKey{Scope: Scope{Type: ScopeChatAdministrators, ChatID: -100500}, Language: "en"},
+			Commands: []MenuCommand{{Name: "rules", Description: "Правила"}},
+		},
+	}
+
+	assert.Equal(t, want, c.Menus())
+}
+
+func TestCommand_menuDescription(t *testing.T) {
+	t.Parallel()
+
+	long := strings.Repeat("б", MaxMenuDescriptionLength+1)
+
+	cmd := &Command{
+		Description:  long,
+		Descriptions: map[string]string{"en": " <i>Chats</i> "},
+	}
+
+	assert.Equal(t, "Chats", cmd.menuDescription("en"))
+
+	got := cmd.menuDescription("")
+	assert.Equal(t, MaxMenuDescriptionLength, utf8.RuneCountInString(got))
+	assert.True(t, strings.HasSuffix(got, "…"))
+}
+
+func TestCommand_validate_Descriptions(t *testing.T) {
+	t.Parallel()
+
+	_, err := Parse([]byte(`version: 1
+commands:
+  - name: go
+    descriptions:
+      english: Go chat
+    response: "@golangGeeks"
+`), FormatYAML)
+	assert.ErrorIs(t, err, ErrInvalidLanguage)
+}
@@ -0,0 +1,58 @@
+package catalog
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// MaxNewcomersPeriod is the longest restriction period, the new members are tracked for 30 days at most.
+const MaxNewcomersPeriod = 30 * 24 * time.Hour
+
+var ErrInvalidNewcomers = errors.New("invalid newcomers restrictions")
+
+// Newcomers is the configuration of the restrictions of the new chat members: they can't post links,
+// forwards and media while they're in the chat for less than the period or have sent fewer messages.
+type Newcomers struct {
+	// Disable disables the global newcomers restrictions in the chat.
+	Disable bool `json:"disable" yaml:"disable"`
+	// Period is the time after joining the restrictions apply, e.g. 24h.
+	Period Duration `json:"period" yaml:"period"`
+	// Messages is the number of the messages the restrictions apply to.
+	Messages int `json:"messages" yaml:"messages"`
+}
+
+// Restricts returns true if the member who joined at the date and sent the number of messages is a newcomer.
+func (n *Newcomers) Restricts(joined time.Time, messages int, now time.Time) bool {
+	return now.Before(joined.Add(time.Duration(n.Period))) || messages < n.Messages
+}
+
+// NewcomersConfig returns the configuration of the newcomers restrictions, false if they're disabled.
+func (c *Catalog) NewcomersConfig() (*Newcomers, bool) {
+	if c.Newcomers == nil || c.Newcomers.Disable {
+		return nil, false
+	}
+
+	return c.Newcomers, true
+}
+
+// validate checks the period and the messages of the enabled restrictions.
+func (n *Newcomers) validate() error {
+	if n == nil || n.Disable {
+		return nil
+	}
+
+	if n.Period < 0 || n.Messages < 0 {
+		return fmt.Errorf("%w: period and messages can't be negative", ErrInvalidNewcomers)
+	}
+
+	if time.Duration(n.Period) > MaxNewcomersPeriod {
+		return fmt.Errorf("%w: period must be up to %s", ErrInvalidNewcomers, MaxNewcomersPeriod)
+	}
+
+	if n.Period == 0 && n.Messages == 0 {
+		return fmt.Errorf("%w: period or messages must be set", ErrInvalidNewcomers)
+	}
+
+	return nil
+}
@@ -0,0 +1,163 @@
+package catalog
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCatalog_NewcomersConfig(t *testing.T) {
+	t.Parallel()
+
+	c, err := Parse([]byte(`version: 1
+newcomers:
+  period: 24h
+  messages: 5
+commands:
+  - name: php
+    response: "@phpGeeks"
+chats:
+  - id: -100500
+    newcomers:
+      disable: true
+  - id: -100501
+    newcomers:
+      messages: 10
+`), FormatYAML)
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		chat   ChatRef
+		want   *Newcomers
+		wantOk bool
+	}{
+		{
+			name:   "Global",
+			chat:   ChatRef{},
+			want:   &Newcomers{Period: Duration(24 * time.Hour), Messages: 5},
+			wantOk: true,
+		},
+		{
+			name:   "Disabled in the chat",
+			chat:   ChatRef{ID: -100500},
+			want:   nil,
+			wantOk: false,
+		},
+		{
+			name:   "Chat restrictions",
+			chat:   ChatRef{ID: -100501},
+			want:   &Newcomers{Messages: 10},
+			wantOk: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, ok := c.ForChat(tt.chat).NewcomersConfig()
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantOk, ok)
+		})
+	}
+}
+
+func TestNewcomers_Restricts(t *testing.T) {
+	t.Parallel()
+
+	joined := time.Date(2024, 3, 8, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		newcomers Newcomers
+		messages  int
+		now       time.Time
+		want      bool
+	}{
+		{
+			name:      "Within the period",
+			newcomers: Newcomers{Period: Duration(24 * time.Hour), Messages: 5},
+			messages:  10,
+			now:       joined.Add(time.Hour),
+			want:      true,
+		},
+		{
+			name:      "Too few messages",
+			newcomers: Newcomers{Period: Duration(24 * time.Hour), Messages: 5},
+			messages:  4,
+			now:       joined.Add(48 * time.Hour),
+			want:      true,
+		},
+		{
+			name:      "Both passed",
+			newcomers: Newcomers{Period: Duration(24 * time.Hour), Messages: 5},
+			messages:  5,
+			now:       joined.Add(24 * time.Hour),
+			want:      false,
+		},
+		{
+			name:      "Only messages",
+			newcomers: Newcomers{Messages: 5},
+			messages:  5,
+			now:       joined,
+			want:      false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, tt.newcomers.Restricts(joined, tt.messages, tt.now))
+		})
+	}
+}
+
+func TestNewcomers_validate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		data string
+	}{
+		{
+			name: "Nothing set",
+			data: `version: 1
+newcomers: {}
+commands:
+  - name: php
+    response: php
+`,
+		},
+		{
+			name: "Negative messages",
+			data: `version: 1
+commands:
+  - name: php
+    response: php
+chats:
+  - id: -100500
+    newcomers:
+      messages: -1
+`,
+		},
+		{
+			name: "Period longer than the tracking",
+			data: `version: 1
+newcomers:
+  period: 31d
+commands:
+  - name: php
+    response: php
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := Parse([]byte(tt.data), FormatYAML)
+			assert.ErrorIs(t, err, ErrInvalidNewcomers)
+		})
+	}
+}
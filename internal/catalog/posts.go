@@ -0,0 +1,82 @@
+package catalog
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// DefaultPostsSimilarity is the similarity of the near-identical posts if the similarity isn't set.
+const DefaultPostsSimilarity = 0.8
+
+var ErrInvalidPosts = errors.New("invalid posts rules")
+
+// Posts is the configuration of the posts of the announcement chat, e.g. the vacancies: the reposts within the interval
+// are deleted and the posts are deleted when they're older than the max age.
+type Posts struct {
+	// Interval is the minimum interval between the near-identical posts, e.g. 7d, zero disables the throttling.
+	Interval Duration `json:"interval" yaml:"interval"`
+	// PerAuthor limits the author to one post per interval whatever the text is.
+	PerAuthor bool `json:"perAuthor" yaml:"perAuthor"`
+	// Similarity is the share of the common phrases of the near-identical posts from 0 to 1, 0.8 by default.
+	Similarity float64 `json:"similarity" yaml:"similarity"`
+	// MaxAge is the age the posts are deleted at, e.g. 24h, up to MaxCleanupDelay, zero keeps the posts.
+	MaxAge Duration `json:"maxAge" yaml:"maxAge"`
+}
+
+// SimilarityValue returns the similarity of the near-identical posts.
+func (p *Posts) SimilarityValue() float64 {
+	if p.Similarity == 0 {
+		return DefaultPostsSimilarity
+	}
+
+	return p.Similarity
+}
+
+// IntervalValue returns the minimum interval between the near-identical posts.
+func (p *Posts) IntervalValue() time.Duration {
+	return time.Duration(p.Interval)
+}
+
+// MaxAgeValue returns the age the posts are deleted at, zero if they're kept.
+func (p *Posts) MaxAgeValue() time.Duration {
+	return time.Duration(p.MaxAge)
+}
+
+// PostsConfig returns the posts rules of the chat, false if the chat has none.
+func (c *Catalog) PostsConfig() (*Posts, bool) {
+	if c.chat == nil || c.chat.Posts == nil {
+		return nil, false
+	}
+
+	return c.chat.Posts, true
+}
+
+// validate checks the interval, the similarity and the max age of the posts rules.
+func (p *Posts) validate() error {
+	if p == nil {
+		return nil
+	}
+
+	if p.Interval < 0 || p.MaxAge < 0 {
+		return fmt.Errorf("%w: interval and maxAge can't be negative", ErrInvalidPosts)
+	}
+
+	if p.Interval == 0 && p.MaxAge == 0 {
+		return fmt.Errorf("%w: interval or maxAge must be set", ErrInvalidPosts)
+	}
+
+	if time.Duration(p.MaxAge) > MaxCleanupDelay {
+		return fmt.Errorf("%w: maxAge must be up to %s", ErrInvalidPosts, MaxCleanupDelay)
+	}
+
+	if p.Interval == 0 && p.PerAuthor {
+		return fmt.Errorf("%w: perAuthor requires the interval", ErrInvalidPosts)
+	}
+
+	if p.Similarity < 0 || p.Similarity > 1 {
+		return fmt.Errorf("%w: similarity must be from 0 to 1", ErrInvalidPosts)
+	}
+
+	return nil
+}
@@ -0,0 +1,86 @@
+package catalog
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCatalog_PostsConfig(t *testing.T) {
+	t.Parallel()
+
+	c, err := Parse([]byte(`version: 1
+commands:
+  - name: php
+    response: "@phpGeeks"
+chats:
+  - id: -100500
+    posts:
+      interval: 7d
+      perAuthor: true
+      maxAge: 48h
+  - id: -100501
+`), FormatYAML)
+	assert.NoError(t, err)
+
+	got, ok := c.ForChat(ChatRef{ID: -100500}).PostsConfig()
+	assert.True(t, ok)
+	assert.Equal(t, &Posts{
+		Interval:  Duration(7 * 24 * time.Hour),
+		PerAuthor: true,
+		MaxAge:    Duration(48 * time.Hour),
+	}, got)
+	assert.Equal(t, DefaultPostsSimilarity, got.SimilarityValue())
+
+	_, ok = c.ForChat(ChatRef{ID: -100501}).PostsConfig()
+	assert.False(t, ok, "no rules")
+
+	_, ok = c.ForChat(ChatRef{ID: -100502}).PostsConfig()
+	assert.False(t, ok, "global catalog")
+}
+
+func TestPosts_validate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		posts string
+	}{
+		{
+			name:  "Empty",
+			posts: "similarity: 0.5",
+		},
+		{
+			name:  "Negative similarity",
+			posts: "interval: 7d, similarity: -0.5",
+		},
+		{
+			name:  "Author without interval",
+			posts: "perAuthor: true, maxAge: 24h",
+		},
+		{
+			name:  "Max age above the deletion limit",
+			posts: "maxAge: 3d",
+		},
+		{
+			name:  "Similarity above one",
+			posts: "interval: 7d, similarity: 1.5",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := Parse([]byte(`version: 1
+commands:
+  - name: php
+    response: php
+chats:
+  - id: -100500
+    posts: {`+tt.posts+`}
+`), FormatYAML)
+			assert.ErrorIs(t, err, ErrInvalidPosts)
+		})
+	}
+}
@@ -0,0 +1,165 @@
+package catalog
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	ErrInvalidRef = errors.New("invalid command reference")
+	ErrUnknownRef = errors.New("unknown command reference")
+	ErrRefCycle   = errors.New("command reference cycle")
+)
+
+// isAlias returns true if the command is a pure alias of another command.
+func (c *Command) isAlias() bool {
+	return c.AliasOf != ""
+}
+
+// validateRefs checks that the alias entry has no own fields and the composite command has no own response.
+func (c *Command) validateRefs() error {
+	if c.isAlias() {
+		if c.aliasOnly() {
+			return nil
+		}
+
+		return fmt.Errorf("%w: alias of %q can't have other fields", ErrInvalidRef, c.AliasOf)
+	}
+
+	if len(c.Refs) > 0 && (c.Handler != "" || c.Response != "") {
+		return fmt.Errorf("%w: refs can't be combined with a handler or a response", ErrInvalidRef)
+	}
+
+	return nil
+}
+
+// aliasOnly returns true if only the name and the aliased command are set.
+func (c *Command) aliasOnly() bool {
+	return len(c.Aliases) == 0 && c.Description == "" && len(c.Descriptions) == 0 && c.Section == "" &&
+		c.Role == "" && c.Handler == "" && c.Link == "" && c.Response == "" && len(c.Refs) == 0 && c.Cleanup == nil
+}
+
+// lookupRef returns the referenced command, chat catalogs fall back to the global catalog.
+func (c *Catalog) lookupRef(name string) (*Command, bool) {
+	if cmd, ok := c.index[name]; ok {
+		return cmd, true
+	}
+
+	if c.parent != nil {
+		return c.parent.lookupRef(name)
+	}
+
+	return nil, false
+}
+
+// resolveRefs points the alias entries to their commands in the index and composes the responses
+// of the composite commands.
+func (c *Catalog) resolveRefs() error {
+	for i := range c.Commands {
+		cmd := &c.Commands[i]
+		if !cmd.isAlias() {
+			continue
+		}
+
+		target, err := c.resolveAlias(cmd)
+		if err != nil {
+			return fmt.Errorf("commands[%d] %s: %w", i, cmd.Name, err)
+		}
+
+		c.index[cmd.Name] = target
+	}
+
+	resolved := make(map[*Command]bool, len(c.Commands))
+	for i := range c.Commands {
+		cmd := &c.Commands[i]
+		if cmd.isAlias() || cmd.Handler != "" {
+			continue
+		}
+
+		if err := c.resolveBody(cmd, resolved); err != nil {
+			return fmt.Errorf("commands[%d] %s: %w", i, cmd.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// resolveAlias follows the chain of the aliases and returns the final command.
+func (c *Catalog) resolveAlias(cmd *Command) (*Command, error) {
+	chain := []string{cmd.Name}
+	seen := map[*Command]struct{}{cmd: {}}
+	for cmd.isAlias() {
+		target, ok := c.lookupRef(cmd.AliasOf)
+		if !ok {
+			return nil, fmt.Errorf("%w: %q", ErrUnknownRef, cmd.AliasOf)
+		}
+
+		chain = append(chain, target.Name)
+		if _, ok := seen[target]; ok {
+			return nil, fmt.Errorf("%w: %s", ErrRefCycle, strings.Join(chain, " -> "))
+		}
+		seen[target] = struct{}{}
+
+		cmd = target
+	}
+
+	return cmd, nil
+}
+
+// resolveBody sets the response template of the command, the responses of the references are joined by lines.
+// The resolved map holds true for the resolved commands and false for the commands being resolved.
+func (c *Catalog) resolveBody(cmd *Command, resolved map[*Command]bool) error {
+	if len(cmd.Refs) == 0 {
+		cmd.body = cmd.Response
+
+		return nil
+	}
+
+	if done, ok := resolved[cmd]; ok {
+		if !done {
+			return fmt.Errorf("%w: %s", ErrRefCycle, cmd.Name)
+		}
+
+		return nil
+	}
+
+	resolved[cmd] = false
+
+	bodies := make([]string, 0, len(cmd.Refs))
+	for _, name := range cmd.Refs {
+		ref, ok := c.lookupRef(name)
+		if !ok {
+			return fmt.Errorf("%w: %q", ErrUnknownRef, name)
+		}
+
+		if ref.Handler != "" {
+			return fmt.Errorf("%w: %q has a handler", ErrInvalidRef, name)
+		}
+
+		// the commands of the global catalog are already resolved
+		if c.owns(ref) {
+			if err := c.resolveBody(ref, resolved); err != nil {
+				return fmt.Errorf("%s: %w", name, err)
+			}
+		}
+
+		bodies = append(bodies, ref.body)
+	}
+
+	cmd.body = strings.Join(bodies, "\n")
+	resolved[cmd] = true
+
+	return nil
+}
+
+// owns returns true if the command belongs to the catalog.
+func (c *Catalog) owns(cmd *Command) bool {
+	for i := range c.Commands {
+		if &c.Commands[i] == cmd {
+			return true
+		}
+	}
+
+	return false
+}
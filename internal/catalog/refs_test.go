@@ -0,0 +1,208 @@
+package catalog
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCatalog_resolveRefs(t *testing.T) {
+	t.Parallel()
+
+	c, err := Parse([]byte(`version: 1
+commands:
+  - name: hr
+    aliases: [хр]
+    link: "@jobGeeks"
+    response: "@jobGeeks"
+  - name: fl
+    response: "{{.Target}} @freelanceGeeks"
+  - name: job
+    refs: [хр, fl]
+  - name: all
+    refs: [job, vacancy]
+  - name: vacancy
+    aliasOf: work
+  - name: work
+    aliasOf: job
+chats:
+  - id: -100500
+    overrides:
+      hr: "@jobGeeksLocal"
+  - id: -100501
+    disable: [job]
+`), FormatYAML)
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		chat    ChatRef
+		command string
+		wantOk  bool
+		want    string
+	}{
+		{
+			name:    "Composite",
+			command: "job",
+			wantOk:  true,
+			want:    "@jobGeeks\n@target @freelanceGeeks",
+		},
+		{
+			name:    "Composite of composite and alias",
+			command: "all",
+			wantOk:  true,
+			want:    "@jobGeeks\n@target @freelanceGeeks\n@jobGeeks\n@target @freelanceGeeks",
+		},
+		{
+			name:    "Alias chain",
+			command: "vacancy",
+			wantOk:  true,
+			want:    "@jobGeeks\n@target @freelanceGeeks",
+		},
+		{
+			name:    "Override propagates to composite",
+			chat:    ChatRef{ID: -100500},
+			command: "job",
+			wantOk:  true,
+			want:    "@jobGeeksLocal\n@target @freelanceGeeks",
+		},
+		{
+			name:    "Alias is disabled with its command",
+			chat:    ChatRef{ID: -100501},
+			command: "work",
+			wantOk:  false,
+		},
+		{
+			name:    "Disabled command is still referenced",
+			chat:    ChatRef{ID: -100501},
+			command: "all",
+			wantOk:  true,
+			want:    "@jobGeeks\n@target @freelanceGeeks\n@jobGeeks\n@target @freelanceGeeks",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cmd, ok := c.ForChat(tt.chat).Lookup(tt.command)
+			assert.Equal(t, tt.wantOk, ok)
+
+			if !ok {
+				return
+			}
+
+			got, _, err := cmd.Render(Data{Target: "@target"})
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestCatalog_resolveRefs_Alias(t *testing.T) {
+	t.Parallel()
+
+	c, err := Parse([]byte(`version: 1
+commands:
+  - name: go
+    description: Go
+    response: "@golangGeeks"
+  - name: golang
+    aliasOf: go
+`), FormatYAML)
+	assert.NoError(t, err)
+
+	cmd, ok := c.Lookup("golang")
+	assert.True(t, ok)
+	assert.Equal(t, "go", cmd.Name)
+
+	assert.Equal(t, []string{"[<code>/go</code>, <code>/golang</code>] Go"}, c.Help(RoleAdmin))
+}
+
+func TestCatalog_resolveRefs_Errors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		commands string
+		wantErr  error
+	}{
+		{
+			name: "Dangling ref",
+			commands: `
+  - name: job
+    refs: [hr]`,
+			wantErr: ErrUnknownRef,
+		},
+		{
+			name: "Dangling alias",
+			commands: `
+  - name: job
+    aliasOf: hr`,
+			wantErr: ErrUnknownRef,
+		},
+		{
+			name: "Refs cycle",
+			commands: `
+  - name: a
+    refs: [b]
+  - name: b
+    refs: [c]
+  - name: c
+    refs: [a]`,
+			wantErr: ErrRefCycle,
+		},
+		{
+			name: "Self ref",
+			commands: `
+  - name: a
+    refs: [a]`,
+			wantErr: ErrRefCycle,
+		},
+		{
+			name: "Alias cycle",
+			commands: `
+  - name: a
+    aliasOf: b
+  - name: b
+    aliasOf: a`,
+			wantErr: ErrRefCycle,
+		},
+		{
+			name: "Refs with response",
+			commands: `
+  - name: hr
+    response: "@jobGeeks"
+  - name: job
+    refs: [hr]
+    response: "@jobGeeks"`,
+			wantErr: ErrInvalidRef,
+		},
+		{
+			name: "Alias with own fields",
+			commands: `
+  - name: hr
+    response: "@jobGeeks"
+  - name: job
+    aliasOf: hr
+    description: Job`,
+			wantErr: ErrInvalidRef,
+		},
+		{
+			name: "Ref to handler",
+			commands: `
+  - name: help
+    handler: help
+  - name: job
+    refs: [help]`,
+			wantErr: ErrInvalidRef,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := Parse([]byte("version: 1\ncommands:"+tt.commands+"\n"), FormatYAML)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
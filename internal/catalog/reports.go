@@ -0,0 +1,17 @@
+package catalog
+
+// Reports is the configuration of the delivery of the member reports to the admins.
+type Reports struct {
+	// LogChat is the ID of the chat the reports are posted to, the bot must be its member.
+	// The reports are sent only to the subscribed admins privately if it's not set.
+	LogChat int64 `json:"logChat" yaml:"logChat"`
+}
+
+// ReportsConfig returns the configuration of the reports, the reports without the log chat by default.
+func (c *Catalog) ReportsConfig() *Reports {
+	if c.Reports == nil {
+		return &Reports{}
+	}
+
+	return c.Reports
+}
@@ -0,0 +1,31 @@
+package catalog
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCatalog_ReportsConfig(t *testing.T) {
+	t.Parallel()
+
+	c, err := Parse([]byte(`version: 1
+reports:
+  logChat: -100100
+commands:
+  - name: php
+    response: "@phpGeeks"
+chats:
+  - id: -100500
+    reports: {}
+  - id: -100501
+    reports:
+      logChat: -100200
+`), FormatYAML)
+	assert.NoError(t, err)
+
+	assert.Equal(t, &Reports{LogChat: -100100}, c.ForChat(ChatRef{}).ReportsConfig())
+	assert.Equal(t, &Reports{}, c.ForChat(ChatRef{ID: -100500}).ReportsConfig(), "private delivery only")
+	assert.Equal(t, &Reports{LogChat: -100200}, c.ForChat(ChatRef{ID: -100501}).ReportsConfig())
+	assert.Equal(t, &Reports{}, (&Catalog{}).ReportsConfig(), "default")
+}
@@ -0,0 +1,95 @@
+package catalog
+
+import (
+	"sync/atomic"
+)
+
+// Store is a thread-safe holder of the current catalog, the catalog can be swapped at runtime.
+type Store struct {
+	current atomic.Pointer[Catalog]
+}
+
+// NewStore creates new store with the catalog.
+func NewStore(c *Catalog) *Store {
+	s := &Store{}
+	s.current.Store(c)
+
+	return s
+}
+
+// Catalog returns the current catalog.
+func (s *Store) Catalog() *Catalog {
+	return s.current.Load()
+}
+
+// Swap atomically replaces the current catalog and returns the previous one.
+func (s *Store) Swap(c *Catalog) *Catalog {
+	return s.current.Swap(c)
+}
+
+// Lookup returns the command of the chat in the current catalog by its name or alias.
+func (s *Store) Lookup(chat ChatRef, name string) (*Command, bool) {
+	return s.Catalog().ForChat(chat).Lookup(name)
+}
+
+// Help returns the help of the chat in the current catalog for the role.
+func (s *Store) Help(chat ChatRef, role Role) []string {
+	return s.Catalog().ForChat(chat).Help(role)
+}
+
+// Chat returns the configuration of the chat in the current catalog.
+func (s *Store) Chat(chat ChatRef) (*Chat, bool) {
+	c := s.Catalog().ForChat(chat).Chat()
+
+	return c, c != nil
+}
+
+// Suggest returns the name of the command of the chat in the current catalog closest to the unknown name.
+func (s *Store) Suggest(chat ChatRef, name string, role Role) (string, bool) {
+	return s.Catalog().ForChat(chat).Suggest(name, role)
+}
+
+// Warnings returns the warnings configuration of the chat in the current catalog.
+func (s *Store) Warnings(chat ChatRef) *Warnings {
+	return s.Catalog().ForChat(chat).WarningsConfig()
+}
+
+// Captcha returns the captcha configuration of the chat in the current catalog, false if it's disabled.
+func (s *Store) Captcha(chat ChatRef) (*Captcha, bool) {
+	return s.Catalog().ForChat(chat).CaptchaConfig()
+}
+
+// Flood returns the flood protection configuration of the chat in the current catalog, false if it's disabled.
+func (s *Store) Flood(chat ChatRef) (*Flood, bool) {
+	return s.Catalog().ForChat(chat).FloodConfig()
+}
+
+// Newcomers returns the newcomers restrictions of the chat in the current catalog, false if they're disabled.
+func (s *Store) Newcomers(chat ChatRef) (*Newcomers, bool) {
+	return s.Catalog().ForChat(chat).NewcomersConfig()
+}
+
+// Reports returns the reports configuration of the chat in the current catalog.
+func (s *Store) Reports(chat ChatRef) *Reports {
+	return s.Catalog().ForChat(chat).ReportsConfig()
+}
+
+// Federation returns the federation of the chat in the current catalog, false if the chat isn't its member.
+func (s *Store) Federation(chat ChatRef) (*Federation, bool) {
+	return s.Catalog().FederationFor(chat.ID)
+}
+
+// Jobs returns the job board configuration of the chat in the current catalog, false if the chat isn't a job board.
+func (s *Store) Jobs(chat ChatRef) (*Jobs, bool) {
+	return s.Catalog().ForChat(chat).JobsConfig()
+}
+
+// Posts returns the posts rules of the chat in the current catalog, false if the chat has none.
+func (s *Store) Posts(chat ChatRef) (*Posts, bool) {
+	return s.Catalog().ForChat(chat).PostsConfig()
+}
+
+// Audit returns the audit log configuration of the chat in the current catalog.
+func (s *Store) Audit(chat ChatRef) *Audit {
+	return s.Catalog().ForChat(chat).AuditConfig()
+}
@@ -0,0 +1,56 @@
+package catalog
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStore(t *testing.T) {
+	t.Parallel()
+
+	first := &Catalog{
+		Version: Version,
+		Commands: []Command{
+			{Name: "php", Response: "@phpGeeks"},
+		},
+	}
+	assert.NoError(t, first.validate())
+
+	second := &Catalog{
+		Version: Version,
+		Commands: []Command{
+			{Name: "go", Response: "@golangGeeks"},
+		},
+		Chats: []Chat{
+			{ID: -100500, LayoutFallback: true},
+		},
+	}
+	assert.NoError(t, second.validate())
+
+	s := NewStore(first)
+	assert.Same(t, first, s.Catalog())
+
+	_, ok := s.Lookup(ChatRef{}, "php")
+	assert.True(t, ok)
+
+	prev := s.Swap(second)
+	assert.Same(t, first, prev)
+	assert.Same(t, second, s.Catalog())
+
+	_, ok = s.Lookup(ChatRef{}, "php")
+	assert.False(t, ok)
+
+	cmd, ok := s.Lookup(ChatRef{}, "go")
+	assert.True(t, ok)
+	assert.Equal(t, "@golangGeeks", cmd.Response)
+
+	assert.Equal(t, []string{"[<code>/go</code>]"}, s.Help(ChatRef{}, RoleAdmin))
+
+	chat, ok := s.Chat(ChatRef{ID: -100500})
+	assert.True(t, ok)
+	assert.True(t, chat.LayoutFallback)
+
+	_, ok = s.Chat(ChatRef{ID: -100501})
+	assert.False(t, ok)
+}
@@ -0,0 +1,68 @@
+package catalog
+
+import (
+	"unicode/utf8"
+)
+
+// minSuggestLength is the minimal length of the unknown name to suggest a command for.
+const minSuggestLength = 2
+
+// Suggest returns the command name or alias closest to the unknown name by the edit distance.
+// Only the names of the commands available for the role are suggested, short names allow one typo
+// and names of six or more letters allow two.
+func (c *Catalog) Suggest(name string, role Role) (string, bool) {
+	length := utf8.RuneCountInString(name)
+	if length < minSuggestLength {
+		return "", false
+	}
+
+	maxDistance := 1
+	if length >= 6 {
+		maxDistance = 2
+	}
+
+	var suggestion string
+	best := maxDistance + 1
+
+	for i := range c.Commands {
+		cmd := &c.Commands[i]
+		if !c.index[cmd.Name].AllowedFor(role) {
+			continue
+		}
+
+		for _, candidate := range append([]string{cmd.Name}, cmd.Aliases...) {
+			if d := distance(name, candidate); d > 0 && d < best {
+				suggestion, best = candidate, d
+			}
+		}
+	}
+
+	return suggestion, suggestion != ""
+}
+
+// distance returns the Levenshtein distance between the strings in runes.
+func distance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+
+		prev, curr = curr, prev
+	}
+
+	return prev[len(rb)]
+}
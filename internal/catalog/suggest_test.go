@@ -0,0 +1,116 @@
+package catalog
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCatalog_Suggest(t *testing.T) {
+	t.Parallel()
+
+	c, err := Parse([]byte(`version: 1
+commands:
+  - name: help
+    role: member
+    handler: help
+  - name: lara
+    aliases: [лара]
+    response: "@laravel_pro"
+  - name: nometa
+    response: "nometa.xyz"
+  - name: go
+    response: "@golangGeeks"
+`), FormatYAML)
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		input  string
+		role   Role
+		want   string
+		wantOk bool
+	}{
+		{
+			name:   "Missing letter",
+			input:  "lar",
+			role:   RoleAdmin,
+			want:   "lara",
+			wantOk: true,
+		},
+		{
+			name:   "Cyrillic alias",
+			input:  "лра",
+			role:   RoleAdmin,
+			want:   "лара",
+			wantOk: true,
+		},
+		{
+			name:   "Two typos in a long name",
+			input:  "nmetaa",
+			role:   RoleAdmin,
+			want:   "nometa",
+			wantOk: true,
+		},
+		{
+			name:   "Two typos in a short name",
+			input:  "lrr",
+			role:   RoleAdmin,
+			want:   "",
+			wantOk: false,
+		},
+		{
+			name:   "Not available for the role",
+			input:  "lar",
+			role:   RoleMember,
+			want:   "",
+			wantOk: false,
+		},
+		{
+			name:   "Too short",
+			input:  "g",
+			role:   RoleAdmin,
+			want:   "",
+			wantOk: false,
+		},
+		{
+			name:   "Exact match is not a suggestion",
+			input:  "go",
+			role:   RoleAdmin,
+			want:   "",
+			wantOk: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, ok := c.Suggest(tt.input, tt.role)
+			assert.Equal(t, tt.wantOk, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_distance(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{a: "", b: "go", want: 2},
+		{a: "lara", b: "lara", want: 0},
+		{a: "lar", b: "lara", want: 1},
+		{a: "nmeta", b: "nometa", want: 1},
+		{a: "kitten", b: "sitting", want: 3},
+		{a: "дб", b: "бд", want: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.a+"_"+tt.b, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, distance(tt.a, tt.b))
+		})
+	}
+}
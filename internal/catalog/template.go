@@ -0,0 +1,109 @@
+package catalog
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+var (
+	ErrInvalidTemplate = errors.New("invalid response template")
+	ErrUnknownChat     = errors.New("unknown chat command")
+)
+
+// Data is the data of the response template. All values must be HTML-escaped.
+type Data struct {
+	// Target is the mention of the reply target, empty if the command is not a reply.
+	Target string
+	// Caller is the name of the command author.
+	Caller string
+	// Chat is the title of the chat.
+	Chat string
+	// Args are the command arguments.
+	Args string
+	// Date is the current date.
+	Date string
+}
+
+// templateData wraps Data to track whether the template mentions the target.
+type templateData struct {
+	*Data
+
+	targetUsed bool
+}
+
+// Target returns the mention of the reply target and marks it as used.
+func (d *templateData) Target() string {
+	d.targetUsed = true
+
+	return d.Data.Target
+}
+
+// Render executes the response template of the command.
+// The returned flag is true if the response mentions the reply target itself.
+func (c *Command) Render(data Data) (string, bool, error) {
+	if c.tmpl == nil {
+		return c.Response, false, nil
+	}
+
+	d := &templateData{Data: &data}
+
+	var b strings.Builder
+	if err := c.tmpl.Execute(&b, d); err != nil {
+		return "", false, fmt.Errorf("c.tmpl.Execute: %v", err)
+	}
+
+	return b.String(), d.targetUsed, nil
+}
+
+// parseTemplates parses the response templates of the commands and checks them with sample data,
+// so misspelled variables and links to unknown chats fail the validation.
+func (c *Catalog) parseTemplates() error {
+	funcs := template.FuncMap{
+		"chat": c.chatLink,
+	}
+
+	sample := Data{
+		Target: "@target",
+		Caller: "caller",
+		Chat:   "chat",
+		Args:   "args",
+		Date:   "01.01.2024",
+	}
+
+	for i := range c.Commands {
+		cmd := &c.Commands[i]
+		if cmd.Handler != "" || cmd.isAlias() {
+			continue
+		}
+
+		tmpl, err := template.New(cmd.Name).Funcs(funcs).Parse(cmd.body)
+		if err != nil {
+			return fmt.Errorf("commands[%d] %s: %w: %v", i, cmd.Name, ErrInvalidTemplate, err)
+		}
+
+		cmd.tmpl = tmpl
+
+		if _, _, err := cmd.Render(sample); err != nil {
+			return fmt.Errorf("commands[%d] %s: %w: %v", i, cmd.Name, ErrInvalidTemplate, err)
+		}
+	}
+
+	return nil
+}
+
+// chatLink returns the link of the command with the chat, e.g. {{chat "go"}} returns @golangGeeks.
+// Chat catalogs fall back to the global catalog, so links to the disabled commands still work.
+func (c *Catalog) chatLink(name string) (string, error) {
+	cmd, ok := c.index[name]
+	if ok && cmd.Link != "" {
+		return cmd.Link, nil
+	}
+
+	if c.parent != nil {
+		return c.parent.chatLink(name)
+	}
+
+	return "", fmt.Errorf("%w: %q", ErrUnknownChat, name)
+}
@@ -0,0 +1,117 @@
+package catalog
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCommand_Render(t *testing.T) {
+	t.Parallel()
+
+	c, err := Parse([]byte(`version: 1
+commands:
+  - name: go
+    link: "@golangGeeks"
+    response: "@golangGeeks"
+  - name: move
+    response: '{{.Target}}, ваш вопрос в {{chat "go"}}'
+  - name: whoami
+    response: "{{.Caller}} в {{.Chat}} {{.Date}}: {{.Args}}"
+`), FormatYAML)
+	assert.NoError(t, err)
+
+	data := Data{
+		Target: "@username",
+		Caller: "Admin",
+		Chat:   "phpGeeks",
+		Args:   "args",
+		Date:   "08.03.2024",
+	}
+
+	tests := []struct {
+		name           string
+		command        string
+		want           string
+		wantTargetUsed bool
+	}{
+		{
+			name:           "Static",
+			command:        "go",
+			want:           "@golangGeeks",
+			wantTargetUsed: false,
+		},
+		{
+			name:           "Target and chat link",
+			command:        "move",
+			want:           "@username, ваш вопрос в @golangGeeks",
+			wantTargetUsed: true,
+		},
+		{
+			name:           "Variables",
+			command:        "whoami",
+			want:           "Admin в phpGeeks 08.03.2024: args",
+			wantTargetUsed: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cmd, ok := c.Lookup(tt.command)
+			assert.True(t, ok)
+
+			got, targetUsed, err := cmd.Render(data)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantTargetUsed, targetUsed)
+		})
+	}
+}
+
+func TestCatalog_parseTemplates(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		response string
+		wantErr  error
+	}{
+		{
+			name:     "Syntax error",
+			response: "{{.Target}",
+			wantErr:  ErrInvalidTemplate,
+		},
+		{
+			name:     "Unknown variable",
+			response: "{{.Taget}}",
+			wantErr:  ErrInvalidTemplate,
+		},
+		{
+			name:     "Unknown chat",
+			response: `{{chat "rust"}}`,
+			wantErr:  ErrInvalidTemplate,
+		},
+		{
+			name:     "Chat without link",
+			response: `{{chat "php"}}`,
+			wantErr:  ErrInvalidTemplate,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			c := &Catalog{
+				Version: Version,
+				Commands: []Command{
+					{Name: "php", Response: "@phpGeeks"},
+					{Name: "test", Response: tt.response},
+				},
+			}
+
+			err := c.validate()
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
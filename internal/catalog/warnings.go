@@ -0,0 +1,86 @@
+package catalog
+
+import (
+	"errors"
+	"fmt"
+)
+
+var ErrInvalidWarnings = errors.New("invalid warnings")
+
+// Action is the action of the warnings ladder step.
+type Action string
+
+const (
+	// ActionMute forbids the user to send messages.
+	ActionMute Action = "mute"
+	// ActionBan bans the user, the warnings of the banned user are reset.
+	ActionBan Action = "ban"
+)
+
+// Warnings is the configuration of the warnings.
+type Warnings struct {
+	// Expire is the lifetime of a warning, e.g. 30d, warnings never expire if it's empty.
+	Expire Duration `json:"expire" yaml:"expire"`
+	// Ladder are the actions applied automatically when the user gets the number of warnings.
+	Ladder []Step `json:"ladder" yaml:"ladder"`
+}
+
+// Step is the step of the warnings ladder, e.g. 3 warnings - 1 day mute.
+type Step struct {
+	// Count is the number of the warnings.
+	Count int `json:"count" yaml:"count"`
+	// Action is the action applied to the user.
+	Action Action `json:"action" yaml:"action"`
+	// Duration is the duration of the action, the action is permanent if it's empty.
+	Duration Duration `json:"duration" yaml:"duration"`
+}
+
+// Step returns the ladder step for the number of the warnings.
+// The last step is repeated for the numbers above it.
+func (w *Warnings) Step(count int) (Step, bool) {
+	for _, step := range w.Ladder {
+		if step.Count == count {
+			return step, true
+		}
+	}
+
+	if n := len(w.Ladder); n > 0 && count > w.Ladder[n-1].Count {
+		return w.Ladder[n-1], true
+	}
+
+	return Step{}, false
+}
+
+// Next returns the next ladder step after the number of the warnings.
+func (w *Warnings) Next(count int) (Step, bool) {
+	for _, step := range w.Ladder {
+		if step.Count > count {
+			return step, true
+		}
+	}
+
+	return Step{}, false
+}
+
+// validate checks the ladder steps.
+func (w *Warnings) validate() error {
+	prev := 0
+	for i, step := range w.Ladder {
+		if step.Count <= prev {
+			return fmt.Errorf("%w: ladder[%d]: counts must be positive and ascending", ErrInvalidWarnings, i)
+		}
+		prev = step.Count
+
+		switch step.Action {
+		case ActionMute, ActionBan:
+		default:
+			return fmt.Errorf("%w: ladder[%d]: unknown action %q", ErrInvalidWarnings, i, step.Action)
+		}
+
+		if err := step.Duration.validateRestriction(); err != nil {
+			return fmt.Errorf("%w: ladder[%d]: %v", ErrInvalidWarnings, i, err)
+		}
+	}
+
+	return nil
+}
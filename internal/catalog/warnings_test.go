@@ -0,0 +1,208 @@
+package catalog
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"geeksonator/pkg/duration"
+)
+
+func TestCatalog_WarningsConfig(t *testing.T) {
+	t.Parallel()
+
+	c, err := Parse([]byte(`version: 1
+warnings:
+  expire: 30д
+  ladder:
+    - count: 3
+      action: mute
+      duration: 1d
+    - count: 5
+      action: ban
+commands:
+  - name: php
+    response: "@phpGeeks"
+chats:
+  - id: -100500
+    warnings:
+      ladder:
+        - count: 2
+          action: ban
+`), FormatYAML)
+	assert.NoError(t, err)
+
+	global := c.ForChat(ChatRef{}).WarningsConfig()
+	assert.Equal(t, &Warnings{
+		Expire: Duration(30 * 24 * time.Hour),
+		Ladder: []Step{
+			{Count: 3, Action: ActionMute, Duration: Duration(24 * time.Hour)},
+			{Count: 5, Action: ActionBan},
+		},
+	}, global)
+
+	chat := c.ForChat(ChatRef{ID: -100500}).WarningsConfig()
+	assert.Equal(t, &Warnings{
+		Ladder: []Step{
+			{Count: 2, Action: ActionBan},
+		},
+	}, chat)
+
+	empty := &Catalog{}
+	assert.Equal(t, &Warnings{}, empty.WarningsConfig())
+}
+
+func TestWarnings_Step(t *testing.T) {
+	t.Parallel()
+
+	w := &Warnings{
+		Ladder: []Step{
+			{Count: 3, Action: ActionMute, Duration: Duration(24 * time.Hour)},
+			{Count: 5, Action: ActionBan},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		count    int
+		wantStep Step
+		wantOk   bool
+		wantNext Step
+		wantMore bool
+	}{
+		{
+			name:     "Below the ladder",
+			count:    2,
+			wantStep: Step{},
+			wantOk:   false,
+			wantNext: w.Ladder[0],
+			wantMore: true,
+		},
+		{
+			name:     "First step",
+			count:    3,
+			wantStep: w.Ladder[0],
+			wantOk:   true,
+			wantNext: w.Ladder[1],
+			wantMore: true,
+		},
+		{
+			name:     "Between the steps",
+			count:    4,
+			wantStep: Step{},
+			wantOk:   false,
+			wantNext: w.Ladder[1],
+			wantMore: true,
+		},
+		{
+			name:     "Above the ladder",
+			count:    7,
+			wantStep: w.Ladder[1],
+			wantOk:   true,
+			wantNext: Step{},
+			wantMore: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			step, ok := w.Step(tt.count)
+			assert.Equal(t, tt.wantStep, step)
+			assert.Equal(t, tt.wantOk, ok)
+
+			next, ok := w.Next(tt.count)
+			assert.Equal(t, tt.wantNext, next)
+			assert.Equal(t, tt.wantMore, ok)
+		})
+	}
+}
+
+func TestWarnings_validate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		data    string
+		wantErr error
+	}{
+		{
+			name: "Invalid duration",
+			data: `version: 1
+warnings:
+  expire: month
+commands:
+  - name: php
+    response: php
+`,
+			wantErr: duration.ErrInvalid,
+		},
+		{
+			name: "Descending counts",
+			data: `version: 1
+warnings:
+  ladder:
+    - count: 5
+      action: ban
+    - count: 3
+      action: mute
+commands:
+  - name: php
+    response: php
+`,
+			wantErr: ErrInvalidWarnings,
+		},
+		{
+			name: "Too short mute",
+			data: `version: 1
+warnings:
+  ladder:
+    - count: 3
+      action: mute
+      duration: 10s
+commands:
+  - name: php
+    response: php
+`,
+			wantErr: ErrInvalidWarnings,
+		},
+		{
+			name: "Too long ban",
+			data: `version: 1
+warnings:
+  ladder:
+    - count: 3
+      action: ban
+      duration: 53w
+commands:
+  - name: php
+    response: php
+`,
+			wantErr: ErrInvalidWarnings,
+		},
+		{
+			name: "Unknown action",
+			data: `version: 1
+commands:
+  - name: php
+    response: php
+chats:
+  - id: -100500
+    warnings:
+      ladder:
+        - count: 3
+          action: kick
+`,
+			wantErr: ErrInvalidWarnings,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := Parse([]byte(tt.data), FormatYAML)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
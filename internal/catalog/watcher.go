@@ -0,0 +1,148 @@
+package catalog
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Watcher reloads the catalog file into the store when the file changes or on demand.
+// The store keeps the previous catalog if the new one fails to load.
+type Watcher struct {
+	path     string
+	store    *Store
+	interval time.Duration
+	logger   *zap.Logger
+	onReload func(c *Catalog)
+
+	modTime time.Time
+	size    int64
+}
+
+// NewWatcher creates new watcher of the catalog file.
+// The file is polled for changes every interval, a zero interval disables polling.
+// An empty path means the embedded catalog, it's neither polled nor reloaded.
+func NewWatcher(path string, store *Store, interval time.Duration, logger *zap.Logger, opts ...WatcherOption) *Watcher {
+	w := &Watcher{
+		path:     path,
+		store:    store,
+		interval: interval,
+		logger:   logger.Named("catalog_watcher"),
+	}
+
+	for _, opt := range opts {
+		opt(w)
+	}
+
+	if info, err := os.Stat(path); err == nil {
+		w.modTime = info.ModTime()
+		w.size = info.Size()
+	}
+
+	return w
+}
+
+// WatcherOption is functional option.
+type WatcherOption func(w *Watcher)
+
+// WithOnReload sets the function called with the new catalog after each successful reload.
+func WithOnReload(fn func(c *Catalog)) WatcherOption {
+	return func(w *Watcher) {
+		w.onReload = fn
+	}
+}
+
+// Run watches the file until the context is done, each value of the reload channel forces reload.
+func (w *Watcher) Run(ctx context.Context, reload <-chan os.Signal) {
+	var tick <-chan time.Time
+	if w.interval > 0 && w.path != "" {
+		ticker := time.NewTicker(w.interval)
+		defer ticker.Stop()
+
+		tick = ticker.C
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case sig := <-reload:
+			w.logger.Info("Reload requested",
+				zap.Stringer("signal", sig),
+			)
+
+			w.reload()
+		case <-tick:
+			if w.changed() {
+				w.reload()
+			}
+		}
+	}
+}
+
+// Reload loads the catalog file and swaps it in the store if it's valid.
+func (w *Watcher) Reload() error {
+	c, err := Load(w.path)
+	if err != nil {
+		return fmt.Errorf("Load: %v", err)
+	}
+
+	prev := w.store.Swap(c)
+
+	diff := Compare(prev, c)
+	w.logger.Info("Catalog reloaded",
+		zap.String("path", w.path),
+		zap.Strings("added", diff.Added),
+		zap.Strings("removed", diff.Removed),
+		zap.Strings("changed", diff.Changed),
+		zap.Strings("chats", diff.Chats),
+		zap.Strings("settings", diff.Settings),
+	)
+
+	if w.onReload != nil {
+		w.onReload(c)
+	}
+
+	return nil
+}
+
+// reload reloads the catalog and logs the error, the embedded catalog is kept as is.
+func (w *Watcher) reload() {
+	if w.path == "" {
+		w.logger.Info("No catalog file, the embedded catalog is kept")
+
+		return
+	}
+
+	if err := w.Reload(); err != nil {
+		w.logger.Error("Catalog reload failed, the previous catalog is kept",
+			zap.String("path", w.path),
+			zap.Error(err),
+		)
+	}
+}
+
+// changed returns true if the file modification time or size has changed since the last check.
+func (w *Watcher) changed() bool {
+	info, err := os.Stat(w.path)
+	if err != nil {
+		w.logger.Error("Stat catalog file",
+			zap.String("path", w.path),
+			zap.Error(err),
+		)
+
+		return false
+	}
+
+	if info.ModTime().Equal(w.modTime) && info.Size() == w.size {
+		return false
+	}
+
+	w.modTime = info.ModTime()
+	w.size = info.Size()
+
+	return true
+}
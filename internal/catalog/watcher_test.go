@@ -0,0 +1,161 @@
+package catalog
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+const (
+	phpCatalog = `version: 1
+commands:
+  - name: php
+    response: "@phpGeeks"
+`
+	goCatalog = `version: 1
+commands:
+  - name: go
+    response: "@golangGeeks"
+`
+)
+
+// newTestWatcher creates the catalog file with the content and the watcher of it.
+func newTestWatcher(t *testing.T, content string, interval time.Duration) (*Watcher, *Store, string) {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "commands.yaml")
+	assert.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	c, err := Load(path)
+	assert.NoError(t, err)
+
+	store := NewStore(c)
+
+	return NewWatcher(path, store, interval, zap.NewNop()), store, path
+}
+
+func TestWatcher_Reload(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		content    string
+		wantErr    bool
+		wantLookup string
+	}{
+		{
+			name:       "Valid catalog is swapped",
+			content:    goCatalog,
+			wantErr:    false,
+			wantLookup: "go",
+		},
+		{
+			name:       "Invalid catalog keeps the previous one",
+			content:    "version: 2\n",
+			wantErr:    true,
+			wantLookup: "php",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			w, store, path := newTestWatcher(t, phpCatalog, 0)
+			assert.NoError(t, os.WriteFile(path, []byte(tt.content), 0o600))
+
+			var reloaded *Catalog
+			WithOnReload(func(c *Catalog) {
+				reloaded = c
+			})(w)
+
+			err := w.Reload()
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.wantErr, reloaded == nil)
+
+			_, ok := store.Lookup(ChatRef{}, tt.wantLookup)
+			assert.True(t, ok)
+		})
+	}
+}
+
+func TestWatcher_Run(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Reload by signal", func(t *testing.T) {
+		t.Parallel()
+
+		w, store, path := newTestWatcher(t, phpCatalog, 0)
+		assert.NoError(t, os.WriteFile(path, []byte(goCatalog), 0o600))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		reload := make(chan os.Signal)
+		go w.Run(ctx, reload)
+
+		reload <- syscall.SIGHUP
+
+		assert.Eventually(t, func() bool {
+			_, ok := store.Lookup(ChatRef{}, "go")
+
+			return ok
+		}, time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("Embedded catalog isn't reloaded", func(t *testing.T) {
+		t.Parallel()
+
+		c, err := Parse([]byte(phpCatalog), FormatYAML)
+		assert.NoError(t, err)
+
+		store := NewStore(c)
+
+		var reloaded bool
+		w := NewWatcher("", store, 10*time.Millisecond, zap.NewNop(), WithOnReload(func(*Catalog) {
+			reloaded = true
+		}))
+
+		ctx, cancel := context.WithCancel(context.Background())
+
+		done := make(chan struct{})
+		reload := make(chan os.Signal)
+
+		go func() {
+			defer close(done)
+
+			w.Run(ctx, reload)
+		}()
+
+		reload <- syscall.SIGHUP
+		cancel()
+		<-done
+
+		assert.False(t, reloaded)
+		assert.Same(t, c, store.Catalog())
+	})
+
+	t.Run("Reload on file change", func(t *testing.T) {
+		t.Parallel()
+
+		w, store, path := newTestWatcher(t, phpCatalog, 10*time.Millisecond)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		go w.Run(ctx, nil)
+
+		assert.NoError(t, os.WriteFile(path, []byte(goCatalog+"\n"), 0o600))
+
+		assert.Eventually(t, func() bool {
+			_, ok := store.Lookup(ChatRef{}, "go")
+
+			return ok
+		}, time.Second, 10*time.Millisecond)
+	})
+}
@@ -0,0 +1,115 @@
+package federation
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"geeksonator/pkg/jsonfile"
+)
+
+// Ban is the ban of the user in all the federation chats.
+type Ban struct {
+	// Name is the name of the user at the time of the ban.
+	Name string `json:"name"`
+	// Reason is the free text reason, may be empty.
+	Reason string `json:"reason"`
+	// By is the ID of the admin who banned the user.
+	By int64 `json:"by"`
+	// ChatID is the ID of the chat the ban is issued in.
+	ChatID int64 `json:"chatId"`
+	// Date is the date of the ban.
+	Date time.Time `json:"date"`
+}
+
+// Store keeps the federation bans in the JSON file.
+type Store struct {
+	path string
+
+	mu   sync.Mutex
+	bans map[int64]Ban
+}
+
+// NewStore loads the bans from the file, the bans are kept in memory only if the path is empty.
+func NewStore(path string) (*Store, error) {
+	s := &Store{
+		path: path,
+		bans: map[int64]Ban{},
+	}
+
+	if path == "" {
+		return s, nil
+	}
+
+	if err := jsonfile.Load(path, &s.bans); err != nil {
+		return nil, fmt.Errorf("jsonfile.Load: %v", err)
+	}
+
+	return s, nil
+}
+
+// Ban adds the ban of the user, the previous ban of the user is replaced.
+func (s *Store) Ban(userID int64, ban Ban) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	prev, ok := s.bans[userID]
+
+	s.bans[userID] = ban
+
+	if err := s.save(); err != nil {
+		if ok {
+			s.bans[userID] = prev
+		} else {
+			delete(s.bans, userID)
+		}
+
+		return fmt.Errorf("s.save: %v", err)
+	}
+
+	return nil
+}
+
+// Unban removes the ban of the user, false is returned if the user isn't banned.
+func (s *Store) Unban(userID int64) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	prev, ok := s.bans[userID]
+	if !ok {
+		return false, nil
+	}
+
+	delete(s.bans, userID)
+
+	if err := s.save(); err != nil {
+		s.bans[userID] = prev
+
+		return false, fmt.Errorf("s.save: %v", err)
+	}
+
+	return true, nil
+}
+
+// Get returns the ban of the user, false if the user isn't banned.
+func (s *Store) Get(userID int64) (Ban, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ban, ok := s.bans[userID]
+
+	return ban, ok
+}
+
+// save writes all the bans into the file.
+func (s *Store) save() error {
+	if s.path == "" {
+		return nil
+	}
+
+	if err := jsonfile.Save(s.path, s.bans); err != nil {
+		return fmt.Errorf("jsonfile.Save: %v", err)
+	}
+
+	return nil
+}
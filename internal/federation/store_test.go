@@ -0,0 +1,55 @@
+package federation
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStore(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "federation.json")
+	ban := Ban{
+		Name:   "@spammer",
+		Reason: "spam",
+		By:     100500,
+		ChatID: -100300600,
+		Date:   time.Date(2024, 3, 8, 12, 0, 0, 0, time.UTC),
+	}
+
+	s, err := NewStore(path)
+	assert.NoError(t, err)
+
+	assert.NoError(t, s.Ban(100501, ban))
+
+	got, ok := s.Get(100501)
+	assert.True(t, ok)
+	assert.Equal(t, ban, got)
+
+	_, ok = s.Get(100502)
+	assert.False(t, ok)
+
+	loaded, err := NewStore(path)
+	assert.NoError(t, err)
+
+	got, ok = loaded.Get(100501)
+	assert.True(t, ok, "bans persist")
+	assert.Equal(t, ban, got)
+
+	removed, err := loaded.Unban(100501)
+	assert.NoError(t, err)
+	assert.True(t, removed)
+
+	removed, err = loaded.Unban(100501)
+	assert.NoError(t, err)
+	assert.False(t, removed, "not banned")
+
+	loaded, err = NewStore(path)
+	assert.NoError(t, err)
+
+	_, ok = loaded.Get(100501)
+	assert.False(t, ok, "unban persists")
+}
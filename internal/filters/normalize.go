@@ -0,0 +1,145 @@
+package filters
+
+import (
+	"strings"
+	"unicode"
+)
+
+// homoglyphs are the Cyrillic and Greek letters looking like the Latin ones, the text is folded to Latin.
+var homoglyphs = map[rune]rune{ //nolint:gochecknoglobals // it's a constant map
+	'а': 'a', 'в': 'b', 'е': 'e', 'ё': 'e', 'к': 'k', 'м': 'm', 'н': 'h', 'о': 'o', 'р': 'p', 'с': 'c',
+	'т': 't', 'у': 'y', 'х': 'x', 'і': 'i', 'ї': 'i', 'ј': 'j', 'ѕ': 's', 'ԁ': 'd', 'һ': 'h', 'ԛ': 'q',
+	'ԝ': 'w', 'α': 'a', 'β': 'b', 'ε': 'e', 'ι': 'i', 'κ': 'k', 'ν': 'v', 'ο': 'o', 'ρ': 'p', 'τ': 't',
+	'υ': 'u', 'χ': 'x',
+}
+
+// clean lower-cases the text and removes the invisible characters, e.g. the zero-width space,
+// the soft hyphen and the combining marks.
+func clean(text string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.In(r, unicode.Cf, unicode.Mn) {
+			return -1
+		}
+
+		return unicode.ToLower(r)
+	}, text)
+}
+
+// Normalize cleans the text and folds the look-alike letters to Latin, so the word written
+// with the mixed alphabets, e.g. "кaзинo" with the Latin "a" and "o", is equal to the plain one.
+func Normalize(text string) string {
+	return fold(clean(text))
+}
+
+// fold replaces the look-alike letters of the lower-cased text with the Latin ones.
+func fold(text string) string {
+	return strings.Map(func(r rune) rune {
+		if folded, ok := homoglyphs[r]; ok {
+			return folded
+		}
+
+		return r
+	}, text)
+}
+
+// foldPattern folds the look-alike letters of the regular expression, so it matches the normalized text.
+// The letters are lower-cased before folding, the escapes like \D are ASCII and stay as is.
+// The character classes keep their letters and get the folded ones added, the ranges like [а-я] stay as is.
+func foldPattern(pattern string) string {
+	runes := []rune(pattern)
+
+	var b strings.Builder
+
+	for i := 0; i < len(runes); i++ {
+		switch r := runes[i]; {
+		case r == '\\' && i+1 < len(runes):
+			b.WriteRune(r)
+			i++
+			b.WriteRune(runes[i])
+		case r == '[':
+			i = foldClass(&b, runes, i)
+		default:
+			b.WriteRune(foldRune(r))
+		}
+	}
+
+	return b.String()
+}
+
+// foldClass writes the character class starting at the index with the folded letters added before
+// its closing bracket and returns the index of the bracket. The range bounds aren't folded,
+// since the folded bounds may be out of order.
+func foldClass(b *strings.Builder, runes []rune, start int) int {
+	i := start + 1
+	if i < len(runes) && runes[i] == '^' {
+		i++
+	}
+
+	// the closing bracket right after the opening one is a literal
+	if i < len(runes) && runes[i] == ']' {
+		i++
+	}
+
+	var added []rune
+
+	for ; i < len(runes) && runes[i] != ']'; i++ {
+		switch {
+		case runes[i] == '\\' && i+1 < len(runes):
+			i++
+		case runes[i] == '[' && i+1 < len(runes) && runes[i+1] == ':':
+			// the ASCII class like [:alpha:]
+			for i+1 < len(runes) && (runes[i] != ':' || runes[i+1] != ']') {
+				i++
+			}
+
+			i++
+		case i+2 < len(runes) && runes[i+1] == '-' && runes[i+2] != ']':
+			i += 2
+		default:
+			if folded := foldRune(runes[i]); folded != runes[i] {
+				added = append(added, folded)
+			}
+		}
+	}
+
+	end := min(i, len(runes))
+
+	// the trailing hyphen is a literal, the letters are added before it to not make a range
+	split := end
+	if len(added) > 0 && runes[end-1] == '-' && runes[end-2] != '\\' {
+		split--
+	}
+
+	b.WriteString(string(runes[start:split]))
+	b.WriteString(string(added))
+	b.WriteString(string(runes[split:end]))
+
+	if end < len(runes) {
+		b.WriteRune(runes[end])
+	}
+
+	return end
+}
+
+// foldRune returns the Latin letter looking like the letter of the pattern, other runes stay as is.
+func foldRune(r rune) rune {
+	if r < unicode.MaxASCII {
+		return r
+	}
+
+	if folded, ok := homoglyphs[unicode.ToLower(r)]; ok {
+		return folded
+	}
+
+	return r
+}
+
+// words returns the words of the text joined by single spaces and wrapped in spaces,
+// e.g. " free crypto ", so a phrase is matched by the words boundaries.
+func words(text string) string {
+	fields := strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	return " " + strings.Join(fields, " ") + " "
+}
@@ -0,0 +1,98 @@
+package filters
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalize(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{
+			name: "Case",
+			text: "FREE Crypto",
+			want: "free crypto",
+		},
+		{
+			name: "Zero-width characters",
+			text: "fr\u200bee cr\u200dyp\ufeffto\u00ad",
+			want: "free crypto",
+		},
+		{
+			name: "Cyrillic look-alikes",
+			text: "сrурtо",
+			want: "crypto",
+		},
+		{
+			name: "Mixed alphabets are folded equally",
+			text: "кaзинo",
+			want: Normalize("казино"),
+		},
+		{
+			name: "Combining marks",
+			text: "c̶r̶y̶p̶t̶o̶",
+			want: "crypto",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, Normalize(tt.text))
+		})
+	}
+}
+
+func Test_foldPattern(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		pattern string
+		want    string
+	}{
+		{
+			name:    "Letters",
+			pattern: "КАЗИНО|ставки",
+			want:    "kaЗИho|ctabkи",
+		},
+		{
+			name:    "Escapes",
+			pattern: `\pL+ \d+ \[а`,
+			want:    `\pL+ \d+ \[a`,
+		},
+		{
+			name:    "Class letters are added",
+			pattern: "ставк[аи]",
+			want:    "ctabk[аиa]",
+		},
+		{
+			name:    "Class ranges stay as is",
+			pattern: "[^о-с]+",
+			want:    "[^о-с]+",
+		},
+		{
+			name:    "Class with range, letter and trailing hyphen",
+			pattern: "[а-яё-]",
+			want:    "[а-яёe-]",
+		},
+		{
+			name:    "Class with ASCII class and bracket",
+			pattern: "[]о[:alpha:]]",
+			want:    "[]о[:alpha:]o]",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, foldPattern(tt.pattern))
+		})
+	}
+}
@@ -0,0 +1,144 @@
+package filters
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+var ErrInvalidRule = errors.New("invalid filter rule")
+
+// Action is the action applied to the message matched by the rule.
+type Action string
+
+const (
+	// ActionDelete deletes the message.
+	ActionDelete Action = "delete"
+	// ActionWarn deletes the message and warns the author.
+	ActionWarn Action = "warn"
+	// ActionMute deletes the message and mutes the author.
+	ActionMute Action = "mute"
+	// ActionBan deletes the message and bans the author.
+	ActionBan Action = "ban"
+	// ActionReport reports the message to the admins and keeps it.
+	ActionReport Action = "report"
+)
+
+// actions is the set of the known actions.
+var actions = map[Action]struct{}{ //nolint:gochecknoglobals // it's a constant set
+	ActionDelete: {},
+	ActionWarn:   {},
+	ActionMute:   {},
+	ActionBan:    {},
+	ActionReport: {},
+}
+
+// Rule is the filter rule of the chat, it matches either the regular expression or any of the words.
+type Rule struct {
+	// ID is the rule number in the chat.
+	ID int `json:"id"`
+	// Action is the action applied to the matched message.
+	Action Action `json:"action"`
+	// Duration is the duration of the mute or ban, zero is permanent.
+	Duration time.Duration `json:"duration,omitempty"`
+	// Pattern is the regular expression matched case-insensitively.
+	Pattern string `json:"pattern,omitempty"`
+	// Words are the words or phrases matched by the words boundaries.
+	Words []string `json:"words,omitempty"`
+
+	re *regexp.Regexp
+	// folded is the regular expression with the look-alike letters folded.
+	folded *regexp.Regexp
+	// phrases are the normalized words wrapped in spaces.
+	phrases []string
+}
+
+// NewRule creates the rule of the action. The pattern in slashes, e.g. /crypto\s+invest/, is a regular
+// expression, otherwise it's the list of the words or phrases separated by commas.
+func NewRule(action Action, d time.Duration, pattern string) (Rule, error) {
+	rule := Rule{
+		Action:   action,
+		Duration: d,
+	}
+
+	pattern = strings.TrimSpace(pattern)
+	if len(pattern) > 2 && strings.HasPrefix(pattern, "/") && strings.HasSuffix(pattern, "/") {
+		rule.Pattern = pattern[1 : len(pattern)-1]
+	} else {
+		for _, word := range strings.Split(pattern, ",") {
+			if word = strings.TrimSpace(word); word != "" {
+				rule.Words = append(rule.Words, word)
+			}
+		}
+	}
+
+	if err := rule.compile(); err != nil {
+		return Rule{}, err
+	}
+
+	return rule, nil
+}
+
+// compile validates the rule and prepares it for matching.
+func (r *Rule) compile() error {
+	if _, ok := actions[r.Action]; !ok {
+		return fmt.Errorf("%w: unknown action %q", ErrInvalidRule, r.Action)
+	}
+
+	if r.Duration < 0 || r.Duration > 0 && r.Action != ActionMute && r.Action != ActionBan {
+		return fmt.Errorf("%w: duration of %s", ErrInvalidRule, r.Action)
+	}
+
+	if (r.Pattern == "") == (len(r.Words) == 0) {
+		return fmt.Errorf("%w: either pattern or words must be set", ErrInvalidRule)
+	}
+
+	if r.Pattern != "" {
+		re, err := regexp.Compile("(?i)" + r.Pattern)
+		if err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidRule, err)
+		}
+
+		r.re = re
+
+		// the folded pattern is only an addition, the rule works without it
+		if folded, err := regexp.Compile("(?i)" + foldPattern(r.Pattern)); err == nil {
+			r.folded = folded
+		}
+
+		return nil
+	}
+
+	r.phrases = make([]string, 0, len(r.Words))
+	for _, word := range r.Words {
+		phrase := words(Normalize(word))
+		if phrase == "  " {
+			return fmt.Errorf("%w: word %q has no letters", ErrInvalidRule, word)
+		}
+
+		r.phrases = append(r.phrases, phrase)
+	}
+
+	return nil
+}
+
+// match returns true if the rule matches the text. The cleaned text is the lower-cased text
+// without the invisible characters, the normalized one also has the look-alike letters folded.
+// The regular expression is matched against the cleaned text and, with its letters folded as well,
+// against the normalized one; the words are matched against the normalized text.
+func (r *Rule) match(cleaned, normalized string) bool {
+	if r.re != nil {
+		return r.re.MatchString(cleaned) || r.folded != nil && r.folded.MatchString(normalized)
+	}
+
+	text := words(normalized)
+	for _, phrase := range r.phrases {
+		if strings.Contains(text, phrase) {
+			return true
+		}
+	}
+
+	return false
+}
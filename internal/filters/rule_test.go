@@ -0,0 +1,193 @@
+package filters
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewRule(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		action  Action
+		d       time.Duration
+		pattern string
+		want    Rule
+		wantErr error
+	}{
+		{
+			name:    "Words",
+			action:  ActionDelete,
+			pattern: " казино, free crypto ,, ",
+			want:    Rule{Action: ActionDelete, Words: []string{"казино", "free crypto"}},
+		},
+		{
+			name:    "Pattern",
+			action:  ActionMute,
+			d:       time.Hour,
+			pattern: `/crypto\s+invest/`,
+			want:    Rule{Action: ActionMute, Duration: time.Hour, Pattern: `crypto\s+invest`},
+		},
+		{
+			name:    "Unknown action",
+			action:  "kick",
+			pattern: "spam",
+			wantErr: ErrInvalidRule,
+		},
+		{
+			name:    "Duration of delete",
+			action:  ActionDelete,
+			d:       time.Hour,
+			pattern: "spam",
+			wantErr: ErrInvalidRule,
+		},
+		{
+			name:    "Empty",
+			action:  ActionBan,
+			pattern: " , ",
+			wantErr: ErrInvalidRule,
+		},
+		{
+			name:    "Word without letters",
+			action:  ActionBan,
+			pattern: "!!!",
+			wantErr: ErrInvalidRule,
+		},
+		{
+			name:    "Pattern with Cyrillic range",
+			action:  ActionDelete,
+			pattern: "/[о-с]+/",
+			want:    Rule{Action: ActionDelete, Pattern: "[о-с]+"},
+		},
+		{
+			name:    "Invalid pattern",
+			action:  ActionBan,
+			pattern: "/crypto(/",
+			wantErr: ErrInvalidRule,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := NewRule(tt.action, tt.d, tt.pattern)
+			assert.ErrorIs(t, err, tt.wantErr)
+
+			got.re, got.folded, got.phrases = nil, nil, nil
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestRule_match(t *testing.T) {
+	t.Parallel()
+
+	words, err := NewRule(ActionDelete, 0, "казино, free crypto")
+	assert.NoError(t, err)
+
+	pattern, err := NewRule(ActionDelete, 0, `/заработ\pL+ от \d+/`)
+	assert.NoError(t, err)
+
+	cyrillic, err := NewRule(ActionDelete, 0, `/казино|ставк[аи]/`)
+	assert.NoError(t, err)
+
+	ranged, err := NewRule(ActionDelete, 0, `/^[а-яё]+$/`)
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name string
+		rule Rule
+		text string
+		want bool
+	}{
+		{
+			name: "Word",
+			rule: words,
+			text: "Лучшее КАЗИНО в телеграме",
+			want: true,
+		},
+		{
+			name: "Word with look-alikes and zero-width space",
+			rule: words,
+			text: "лучшее кa\u200bзинo",
+			want: true,
+		},
+		{
+			name: "Part of a word",
+			rule: words,
+			text: "казинолог",
+		},
+		{
+			name: "Phrase across punctuation",
+			rule: words,
+			text: "Get FREE... crypto!",
+			want: true,
+		},
+		{
+			name: "Phrase words apart",
+			rule: words,
+			text: "free and crypto",
+		},
+		{
+			name: "Pattern",
+			rule: pattern,
+			text: "Заработок от 1000$ в день",
+			want: true,
+		},
+		{
+			name: "Pattern with zero-width space",
+			rule: pattern,
+			text: "заработ\u200bок от 1000",
+			want: true,
+		},
+		{
+			name: "Pattern mismatch",
+			rule: pattern,
+			text: "заработок есть",
+		},
+		{
+			name: "Cyrillic pattern",
+			rule: cyrillic,
+			text: "Лучшее КАЗИНО в телеграме",
+			want: true,
+		},
+		{
+			name: "Cyrillic pattern with mixed alphabets",
+			rule: cyrillic,
+			text: "лучшее кaзинo",
+			want: true,
+		},
+		{
+			name: "Cyrillic pattern with mixed alphabets in the class",
+			rule: cyrillic,
+			text: "ставкa на спорт",
+			want: true,
+		},
+		{
+			name: "Cyrillic range",
+			rule: ranged,
+			text: "Привет",
+			want: true,
+		},
+		{
+			name: "Cyrillic range is not folded",
+			rule: ranged,
+			text: "hello",
+		},
+		{
+			name: "Cyrillic pattern mismatch",
+			rule: cyrillic,
+			text: "ставлю лайк",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, tt.rule.match(clean(tt.text), Normalize(tt.text)))
+		})
+	}
+}
@@ -0,0 +1,155 @@
+package filters
+
+import (
+	"fmt"
+	"sync"
+
+	"geeksonator/pkg/jsonfile"
+)
+
+// Store keeps the filter rules of the chats in the JSON file.
+type Store struct {
+	path string
+
+	mu    sync.RWMutex
+	rules map[int64][]Rule
+}
+
+// NewStore loads the rules from the file, the rules are kept in memory only if the path is empty.
+func NewStore(path string) (*Store, error) {
+	s := &Store{
+		path:  path,
+		rules: map[int64][]Rule{},
+	}
+
+	if path == "" {
+		return s, nil
+	}
+
+	if err := jsonfile.Load(path, &s.rules); err != nil {
+		return nil, fmt.Errorf("jsonfile.Load: %v", err)
+	}
+
+	for chatID, rules := range s.rules {
+		for i := range rules {
+			if err := rules[i].compile(); err != nil {
+				return nil, fmt.Errorf("chat %d rule %d: %v", chatID, rules[i].ID, err)
+			}
+		}
+	}
+
+	return s, nil
+}
+
+// Rules returns the rules of the chat in the order of addition.
+func (s *Store) Rules(chatID int64) []Rule {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return clone(s.rules[chatID])
+}
+
+// Add adds the rule created by NewRule to the chat and returns it with the assigned ID.
+func (s *Store) Add(chatID int64, rule Rule) (Rule, error) {
+	if err := rule.compile(); err != nil {
+		return Rule{}, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rules := s.rules[chatID]
+
+	rule.ID = 1
+	if len(rules) > 0 {
+		rule.ID = rules[len(rules)-1].ID + 1
+	}
+
+	if err := s.save(chatID, append(clone(rules), rule)); err != nil {
+		return Rule{}, fmt.Errorf("s.save: %v", err)
+	}
+
+	return rule, nil
+}
+
+// Remove removes the rule of the chat by its ID, false is returned if there's no such rule.
+func (s *Store) Remove(chatID int64, id int) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rules := make([]Rule, 0, len(s.rules[chatID]))
+	for _, rule := range s.rules[chatID] {
+		if rule.ID != id {
+			rules = append(rules, rule)
+		}
+	}
+
+	if len(rules) == len(s.rules[chatID]) {
+		return false, nil
+	}
+
+	if err := s.save(chatID, rules); err != nil {
+		return false, fmt.Errorf("s.save: %v", err)
+	}
+
+	return true, nil
+}
+
+// Match returns the first rule of the chat matching the text.
+func (s *Store) Match(chatID int64, text string) (Rule, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	rules := s.rules[chatID]
+	if len(rules) == 0 {
+		return Rule{}, false
+	}
+
+	cleaned := clean(text)
+	normalized := Normalize(text)
+
+	for _, rule := range rules {
+		if rule.match(cleaned, normalized) {
+			return rule, true
+		}
+	}
+
+	return Rule{}, false
+}
+
+// save replaces the chat rules and writes all the rules into the file.
+// The previous rules are restored if the file can't be written.
+func (s *Store) save(chatID int64, rules []Rule) error {
+	prev, ok := s.rules[chatID]
+
+	if len(rules) == 0 {
+		delete(s.rules, chatID)
+	} else {
+		s.rules[chatID] = rules
+	}
+
+	if s.path == "" {
+		return nil
+	}
+
+	if err := jsonfile.Save(s.path, s.rules); err != nil {
+		if ok {
+			s.rules[chatID] = prev
+		} else {
+			delete(s.rules, chatID)
+		}
+
+		return fmt.Errorf("jsonfile.Save: %v", err)
+	}
+
+	return nil
+}
+
+// clone returns the copy of the rules, so the caller can't change the stored ones.
+func clone(rules []Rule) []Rule {
+	if len(rules) == 0 {
+		return nil
+	}
+
+	return append([]Rule(nil), rules...)
+}
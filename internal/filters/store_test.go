@@ -0,0 +1,66 @@
+package filters
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStore(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "filters.json")
+
+	s, err := NewStore(path)
+	assert.NoError(t, err)
+
+	casino, err := NewRule(ActionDelete, 0, "казино")
+	assert.NoError(t, err)
+
+	crypto, err := NewRule(ActionBan, 0, `/crypto\s+invest/`)
+	assert.NoError(t, err)
+
+	added, err := s.Add(-100500, casino)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, added.ID)
+
+	added, err = s.Add(-100500, crypto)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, added.ID)
+
+	rule, ok := s.Match(-100500, "CRYPTO invest")
+	assert.True(t, ok)
+	assert.Equal(t, 2, rule.ID)
+
+	_, ok = s.Match(-100501, "казино")
+	assert.False(t, ok, "rules are per chat")
+
+	loaded, err := NewStore(path)
+	assert.NoError(t, err)
+	assert.Len(t, loaded.Rules(-100500), 2, "rules persist")
+
+	rule, ok = loaded.Match(-100500, "кaзинo")
+	assert.True(t, ok, "loaded rules are compiled")
+	assert.Equal(t, 1, rule.ID)
+
+	ok, err = loaded.Remove(-100500, 1)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = loaded.Remove(-100500, 1)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	added, err = loaded.Add(-100500, casino)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, added.ID, "ID follows the last rule")
+
+	loaded, err = NewStore(path)
+	assert.NoError(t, err)
+
+	rules := loaded.Rules(-100500)
+	assert.Len(t, rules, 2)
+	assert.Equal(t, 2, rules[0].ID)
+	assert.Equal(t, 3, rules[1].ID)
+}
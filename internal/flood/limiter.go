@@ -0,0 +1,90 @@
+package flood
+
+import (
+	"sync"
+	"time"
+)
+
+// sweepEvery is the number of the hits after which the idle users are forgotten.
+const sweepEvery = 4096
+
+// key identifies the user in the chat.
+type key struct {
+	chatID int64
+	userID int64
+}
+
+// window is the sliding window of the user messages.
+type window struct {
+	// hits are the times of the messages within the window in the ascending order.
+	hits []time.Time
+	// size is the window size, it's used to forget the idle users.
+	size time.Duration
+}
+
+// Limiter tracks the messages of the users in a sliding window per chat and user.
+// The windows are kept in memory.
+type Limiter struct {
+	mu      sync.Mutex
+	windows map[key]*window
+	count   int
+}
+
+// NewLimiter creates new limiter.
+func NewLimiter() *Limiter {
+	return &Limiter{
+		windows: make(map[key]*window),
+	}
+}
+
+// Allow records the message of the user and returns false if the user sent limit messages within
+// the window, e.g. the 15th message in 10 seconds with the limit of 15. The window of the user
+// is reset when the limit is reached, so the flood is reported once.
+func (l *Limiter) Allow(chatID, userID int64, at time.Time, limit int, size time.Duration) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.count++
+	if l.count%sweepEvery == 0 {
+		l.sweep(at)
+	}
+
+	k := key{chatID: chatID, userID: userID}
+
+	w, ok := l.windows[k]
+	if !ok {
+		w = &window{hits: make([]time.Time, 0, limit)}
+		l.windows[k] = w
+	}
+	w.size = size
+
+	// the hits are ascending, so the expired ones are at the beginning
+	start := at.Add(-size)
+	expired := 0
+	for expired < len(w.hits) && !w.hits[expired].After(start) {
+		expired++
+	}
+
+	if expired > 0 {
+		w.hits = append(w.hits[:0], w.hits[expired:]...)
+	}
+
+	w.hits = append(w.hits, at)
+
+	if len(w.hits) >= limit {
+		delete(l.windows, k)
+
+		return false
+	}
+
+	return true
+}
+
+// sweep forgets the users without messages within their windows.
+func (l *Limiter) sweep(at time.Time) {
+	for k, w := range l.windows {
+		if len(w.hits) == 0 || !w.hits[len(w.hits)-1].After(at.Add(-w.size)) {
+			delete(l.windows, k)
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package flood
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLimiter_Allow(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2024, 3, 8, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		offset []time.Duration
+		want   []bool
+	}{
+		{
+			name:   "Below the limit",
+			offset: []time.Duration{0, time.Second},
+			want:   []bool{true, true},
+		},
+		{
+			name:   "Limit is reached",
+			offset: []time.Duration{0, time.Second, 2 * time.Second},
+			want:   []bool{true, true, false},
+		},
+		{
+			name:   "Limit is reached at the window end",
+			offset: []time.Duration{0, 5 * time.Second, 10*time.Second - time.Millisecond},
+			want:   []bool{true, true, false},
+		},
+		{
+			name:   "Window slides",
+			offset: []time.Duration{0, time.Second, 10 * time.Second, 11 * time.Second, 20 * time.Second},
+			want:   []bool{true, true, true, true, true},
+		},
+		{
+			name:   "Window is reset after the flood",
+			offset: []time.Duration{0, 0, 0, 0, 0},
+			want:   []bool{true, true, false, true, true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			l := NewLimiter()
+
+			got := make([]bool, 0, len(tt.offset))
+			for _, offset := range tt.offset {
+				got = append(got, l.Allow(-100500, 42, start.Add(offset), 3, 10*time.Second))
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestLimiter_Allow_PerUser(t *testing.T) {
+	t.Parallel()
+
+	at := time.Date(2024, 3, 8, 12, 0, 0, 0, time.UTC)
+
+	l := NewLimiter()
+
+	assert.True(t, l.Allow(-100500, 42, at, 2, time.Minute))
+	assert.True(t, l.Allow(-100500, 43, at, 2, time.Minute), "other user")
+	assert.True(t, l.Allow(-100501, 42, at, 2, time.Minute), "other chat")
+	assert.False(t, l.Allow(-100500, 42, at, 2, time.Minute))
+}
+
+func TestLimiter_sweep(t *testing.T) {
+	t.Parallel()
+
+	at := time.Date(2024, 3, 8, 12, 0, 0, 0, time.UTC)
+
+	l := NewLimiter()
+
+	l.Allow(-100500, 42, at, 15, 10*time.Second)
+	l.Allow(-100500, 43, at.Add(5*time.Second), 15, 10*time.Second)
+
+	l.sweep(at.Add(12 * time.Second))
+	assert.Len(t, l.windows, 1)
+	assert.Contains(t, l.windows, key{chatID: -100500, userID: 43})
+}
+
+// BenchmarkLimiter_Allow measures the busy chat: 1000 active users, 15 messages in 10 seconds.
+func BenchmarkLimiter_Allow(b *testing.B) {
+	l := NewLimiter()
+	start := time.Date(2024, 3, 8, 12, 0, 0, 0, time.UTC)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := range b.N {
+		l.Allow(-100500, int64(i%1000), start.Add(time.Duration(i)*time.Millisecond), 15, 10*time.Second)
+	}
+}
+
+// BenchmarkLimiter_Allow_Parallel measures the limiter shared by the chats processed concurrently.
+func BenchmarkLimiter_Allow_Parallel(b *testing.B) {
+	l := NewLimiter()
+	start := time.Date(2024, 3, 8, 12, 0, 0, 0, time.UTC)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			i++
+			l.Allow(int64(i%10), int64(i%1000), start.Add(time.Duration(i)*time.Millisecond), 15, 10*time.Second)
+		}
+	})
+}
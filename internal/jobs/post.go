@@ -0,0 +1,129 @@
+package jobs
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// Field is the required field of the job post.
+type Field string
+
+const (
+	FieldPosition Field = "position"
+	FieldCompany  Field = "company"
+	FieldSalary   Field = "salary"
+	FieldFormat   Field = "format"
+	FieldContact  Field = "contact"
+)
+
+// Fields are the required fields in the order of the template.
+var Fields = []Field{FieldPosition, FieldCompany, FieldSalary, FieldFormat, FieldContact} //nolint:gochecknoglobals // it's a constant list
+
+// labels are the labels of the fields in the posts, in lower case.
+var labels = map[string]Field{ //nolint:gochecknoglobals // it's a constant map
+	"должность":     FieldPosition,
+	"позиция":       FieldPosition,
+	"вакансия":      FieldPosition,
+	"position":      FieldPosition,
+	"role":          FieldPosition,
+	"компания":      FieldCompany,
+	"company":       FieldCompany,
+	"зарплата":      FieldSalary,
+	"зп":            FieldSalary,
+	"вилка":         FieldSalary,
+	"salary":        FieldSalary,
+	"формат":        FieldFormat,
+	"формат работы": FieldFormat,
+	"локация":       FieldFormat,
+	"город":         FieldFormat,
+	"format":        FieldFormat,
+	"location":      FieldFormat,
+	"контакт":       FieldContact,
+	"контакты":      FieldContact,
+	"связь":         FieldContact,
+	"contact":       FieldContact,
+	"contacts":      FieldContact,
+}
+
+// hashtagRe matches the hashtags, e.g. #вакансия or #remote.
+var hashtagRe = regexp.MustCompile(`#[\p{L}\p{N}_]+`)
+
+// Post is the parsed job post.
+type Post struct {
+	// Fields are the values of the labeled lines, e.g. "Зарплата: 250k" is the salary.
+	Fields map[Field]string
+	// Hashtags are the hashtags of the post in lower case.
+	Hashtags []string
+}
+
+// Parse parses the labeled lines and the hashtags of the job post, e.g. "Компания: Geeks".
+// The label may be decorated with emoji or markdown, the first value of the field is kept.
+func Parse(text string) Post {
+	post := Post{Fields: map[Field]string{}}
+
+	for _, line := range strings.Split(text, "\n") {
+		label, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+
+		field, ok := labels[normalizeLabel(label)]
+		if !ok {
+			continue
+		}
+
+		value = strings.TrimSpace(value)
+		if _, seen := post.Fields[field]; !seen && value != "" {
+			post.Fields[field] = value
+		}
+	}
+
+	for _, tag := range hashtagRe.FindAllString(text, -1) {
+		post.Hashtags = append(post.Hashtags, strings.ToLower(tag))
+	}
+
+	return post
+}
+
+// Missing returns the required fields missing in the post.
+func (p Post) Missing() []Field {
+	var missing []Field
+
+	for _, field := range Fields {
+		if _, ok := p.Fields[field]; !ok {
+			missing = append(missing, field)
+		}
+	}
+
+	return missing
+}
+
+// MissingHashtags returns the required hashtags missing in the post.
+func (p Post) MissingHashtags(required []string) []string {
+	var missing []string
+
+	for _, tag := range required {
+		found := false
+		for _, have := range p.Hashtags {
+			if strings.EqualFold(have, tag) {
+				found = true
+
+				break
+			}
+		}
+
+		if !found {
+			missing = append(missing, tag)
+		}
+	}
+
+	return missing
+}
+
+// normalizeLabel returns the label in lower case without the decorations, e.g. "💰 *Зарплата*" is "зарплата".
+func normalizeLabel(label string) string {
+	return strings.ToLower(strings.TrimFunc(label, func(r rune) bool {
+		return !unicode.IsLetter(r)
+	}))
+}
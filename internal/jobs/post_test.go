@@ -0,0 +1,30 @@
+package jobs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	post := Parse(`#Вакансия #remote
+💼 *Должность*: Senior Go developer
+Компания: Geeks
+ЗП: 300-400k
+Компания: Other
+Стек: Go, PostgreSQL
+Контакт:
+Contacts: @hr_geeks`)
+
+	assert.Equal(t, map[Field]string{
+		FieldPosition: "Senior Go developer",
+		FieldCompany:  "Geeks",
+		FieldSalary:   "300-400k",
+		FieldContact:  "@hr_geeks",
+	}, post.Fields)
+	assert.Equal(t, []string{"#вакансия", "#remote"}, post.Hashtags)
+	assert.Equal(t, []Field{FieldFormat}, post.Missing())
+	assert.Equal(t, []string{"#php"}, post.MissingHashtags([]string{"#вакансия", "#php"}))
+}
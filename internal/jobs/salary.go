@@ -0,0 +1,201 @@
+package jobs
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	// thousand is the multiplier of the "k" suffix, e.g. 250k.
+	thousand = 1000
+	// hoursPerMonth converts the hourly rate to the monthly salary.
+	hoursPerMonth = 160
+	// monthsPerYear converts the annual salary to the monthly one.
+	monthsPerYear = 12
+)
+
+var (
+	ErrNoSalary        = errors.New("no salary amount")
+	ErrUnknownCurrency = errors.New("unknown currency")
+)
+
+// currencies are the currency markers of the salaries, the symbols and the words in lower case.
+var currencies = map[string]string{ //nolint:gochecknoglobals // it's a constant map
+	"₽":     "RUB",
+	"руб":   "RUB",
+	"р.":    "RUB",
+	"rub":   "RUB",
+	"rur":   "RUB",
+	"$":     "USD",
+	"usd":   "USD",
+	"долл":  "USD",
+	"€":     "EUR",
+	"eur":   "EUR",
+	"евро":  "EUR",
+	"£":     "GBP",
+	"gbp":   "GBP",
+	"₸":     "KZT",
+	"kzt":   "KZT",
+	"тенге": "KZT",
+}
+
+// thousandSuffixes are the suffixes of the amounts in thousands, e.g. 250k or 250 тыс.
+var thousandSuffixes = []string{"тыс", "т.р", "тр", "k", "к"} //nolint:gochecknoglobals // it's a constant list
+
+// amountRe matches the amounts with the thousands separators or the decimal fraction, e.g. 250 000, 3,000 or 2.5.
+var amountRe = regexp.MustCompile(`\d{1,3}(?:[ .,']\d{3})+|\d+(?:[.,]\d+)?`)
+
+// Salary is the monthly salary range, the zero bound is open, e.g. "от 250k" has no maximum.
+type Salary struct {
+	Min      float64
+	Max      float64
+	Currency string
+}
+
+// ParseSalary parses the salary range, e.g. "250-300k", "от 250 000 ₽", "$3000" or "$120k/year".
+// The annual and hourly amounts are converted to the monthly ones, the amounts without the currency are in the base one.
+// The currencies of the rates are recognized by their codes, e.g. AMD.
+func ParseSalary(text, base string, rates map[string]float64) (Salary, error) {
+	text = strings.ToLower(strings.NewReplacer("\u00a0", " ", "\u2009", " ", "\u202f", " ").Replace(text))
+
+	var amounts []float64
+	var scaled []bool
+
+	for _, loc := range amountRe.FindAllStringIndex(text, -1) {
+		amount, err := parseAmount(text[loc[0]:loc[1]])
+		if err != nil {
+			return Salary{}, err
+		}
+
+		thousands := hasThousandSuffix(text[loc[1]:])
+		if thousands {
+			amount *= thousand
+		}
+
+		amounts = append(amounts, amount)
+		scaled = append(scaled, thousands)
+	}
+
+	if len(amounts) == 0 {
+		return Salary{}, ErrNoSalary
+	}
+
+	// the suffix of the range applies to both bounds, e.g. 200-300k
+	if len(amounts) > 1 && scaled[1] && !scaled[0] && amounts[0] < thousand {
+		amounts[0] *= thousand
+	}
+
+	s := Salary{Min: amounts[0], Currency: detectCurrency(text, base, rates)}
+
+	switch {
+	case len(amounts) > 1:
+		s.Max = amounts[1]
+	case strings.HasPrefix(text, "до ") || strings.HasPrefix(text, "up to "):
+		s.Min, s.Max = 0, amounts[0]
+	case !strings.HasPrefix(text, "от ") && !strings.HasPrefix(text, "from "):
+		s.Max = amounts[0]
+	}
+
+	switch {
+	case containsAny(text, "в год", "/год", "year", "/yr", "annual"):
+		s.Min, s.Max = s.Min/monthsPerYear, s.Max/monthsPerYear
+	case containsAny(text, "в час", "/час", "/ч", "hour", "/hr", "/h"):
+		s.Min, s.Max = s.Min*hoursPerMonth, s.Max*hoursPerMonth
+	}
+
+	return s, nil
+}
+
+// Convert returns the salary in the base currency, the rates are the prices of the currencies in the base one.
+func (s Salary) Convert(base string, rates map[string]float64) (Salary, error) {
+	if s.Currency == base {
+		return s, nil
+	}
+
+	rate, ok := rates[s.Currency]
+	if !ok {
+		return Salary{}, fmt.Errorf("%w: %s", ErrUnknownCurrency, s.Currency)
+	}
+
+	return Salary{Min: s.Min * rate, Max: s.Max * rate, Currency: base}, nil
+}
+
+// Upper returns the upper bound of the salary, the lower one if the range has no maximum.
+func (s Salary) Upper() float64 {
+	if s.Max > 0 {
+		return s.Max
+	}
+
+	return s.Min
+}
+
+// parseAmount parses the amount with the thousands separators or the decimal fraction.
+func parseAmount(s string) (float64, error) {
+	// the separator followed by three digits groups the thousands, e.g. 250 000 or 3,000
+	if len(s) > 4 && strings.ContainsAny(s[len(s)-4:len(s)-3], " .,'") {
+		s = strings.NewReplacer(" ", "", ".", "", ",", "", "'", "").Replace(s)
+	}
+
+	amount, err := strconv.ParseFloat(strings.ReplaceAll(s, ",", "."), 64)
+	if err != nil {
+		return 0, fmt.Errorf("strconv.ParseFloat: %v", err)
+	}
+
+	return amount, nil
+}
+
+// hasThousandSuffix returns true if the text after the amount starts with the thousands suffix, e.g. "k" or " тыс.".
+func hasThousandSuffix(rest string) bool {
+	rest = strings.TrimLeft(rest, " ")
+
+	for _, suffix := range thousandSuffixes {
+		after, ok := strings.CutPrefix(rest, suffix)
+		if !ok {
+			continue
+		}
+
+		// the suffix is a separate word, e.g. 250k but not 250 kzt
+		if r, _ := utf8.DecodeRuneInString(after); after == "" || !unicode.IsLetter(r) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// detectCurrency returns the first currency mentioned in the text, the base currency if there's none.
+func detectCurrency(text, base string, rates map[string]float64) string {
+	currency, first := base, len(text)
+
+	mark := func(marker, code string) {
+		if i := strings.Index(text, marker); i >= 0 && i < first {
+			currency, first = code, i
+		}
+	}
+
+	for marker, code := range currencies {
+		mark(marker, code)
+	}
+
+	for code := range rates {
+		mark(strings.ToLower(code), code)
+	}
+
+	return currency
+}
+
+// containsAny returns true if the text contains any of the substrings.
+func containsAny(text string, subs ...string) bool {
+	for _, sub := range subs {
+		if strings.Contains(text, sub) {
+			return true
+		}
+	}
+
+	return false
+}
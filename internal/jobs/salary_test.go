@@ -0,0 +1,114 @@
+package jobs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSalary(t *testing.T) {
+	t.Parallel()
+
+	rates := map[string]float64{"USD": 90, "EUR": 100, "AMD": 0.23}
+
+	tests := []struct {
+		name    string
+		text    string
+		want    Salary
+		wantErr error
+	}{
+		{
+			name: "Thousands suffix",
+			text: "250k",
+			want: Salary{Min: 250000, Max: 250000, Currency: "RUB"},
+		},
+		{
+			name: "Separated thousands with the symbol",
+			text: "250 000 ₽",
+			want: Salary{Min: 250000, Max: 250000, Currency: "RUB"},
+		},
+		{
+			name: "Dollars",
+			text: "$3000",
+			want: Salary{Min: 3000, Max: 3000, Currency: "USD"},
+		},
+		{
+			name: "Range with the shared suffix",
+			text: "200-300к руб.",
+			want: Salary{Min: 200000, Max: 300000, Currency: "RUB"},
+		},
+		{
+			name: "Open range",
+			text: "от 250 тыс. рублей на руки",
+			want: Salary{Min: 250000, Currency: "RUB"},
+		},
+		{
+			name: "Upper bound only",
+			text: "до 3,500 EUR",
+			want: Salary{Max: 3500, Currency: "EUR"},
+		},
+		{
+			name: "Annual",
+			text: "$120k/year",
+			want: Salary{Min: 10000, Max: 10000, Currency: "USD"},
+		},
+		{
+			name: "Hourly",
+			text: "25 usd в час",
+			want: Salary{Min: 4000, Max: 4000, Currency: "USD"},
+		},
+		{
+			name: "Decimal thousands",
+			text: "2.5k€",
+			want: Salary{Min: 2500, Max: 2500, Currency: "EUR"},
+		},
+		{
+			name: "Currency of the rates",
+			text: "1 500 000 AMD",
+			want: Salary{Min: 1500000, Max: 1500000, Currency: "AMD"},
+		},
+		{
+			name: "Currency code isn't a suffix",
+			text: "500 000 KZT",
+			want: Salary{Min: 500000, Max: 500000, Currency: "KZT"},
+		},
+		{
+			name:    "No amount",
+			text:    "по договорённости",
+			wantErr: ErrNoSalary,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := ParseSalary(tt.text, "RUB", rates)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSalary_Convert(t *testing.T) {
+	t.Parallel()
+
+	rates := map[string]float64{"USD": 90}
+
+	got, err := Salary{Min: 3000, Max: 4000, Currency: "USD"}.Convert("RUB", rates)
+	assert.NoError(t, err)
+	assert.Equal(t, Salary{Min: 270000, Max: 360000, Currency: "RUB"}, got)
+
+	got, err = Salary{Min: 250000, Currency: "RUB"}.Convert("RUB", rates)
+	assert.NoError(t, err)
+	assert.Equal(t, Salary{Min: 250000, Currency: "RUB"}, got)
+
+	_, err = Salary{Min: 500000, Currency: "KZT"}.Convert("RUB", rates)
+	assert.ErrorIs(t, err, ErrUnknownCurrency)
+}
+
+func TestSalary_Upper(t *testing.T) {
+	t.Parallel()
+
+	assert.InDelta(t, 300000, Salary{Min: 200000, Max: 300000}.Upper(), 0)
+	assert.InDelta(t, 250000, Salary{Min: 250000}.Upper(), 0)
+}
@@ -0,0 +1,165 @@
+package members
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"geeksonator/pkg/jsonfile"
+)
+
+// Retention is the longest time the member is tracked, e.g. the silent members are forgotten after it.
+const Retention = 30 * 24 * time.Hour
+
+// Member is the tracked new chat member.
+type Member struct {
+	// Joined is the date the member joined the chat.
+	Joined time.Time `json:"joined"`
+	// Messages is the number of the messages the member sent since joining.
+	Messages int `json:"messages"`
+	// Warned is set after the member is warned about the restrictions.
+	Warned bool `json:"warned"`
+}
+
+// Store keeps the new chat members in the JSON file. Only the members seen joining are tracked,
+// they're removed when the restrictions no longer apply to them, when they leave or after the Retention.
+type Store struct {
+	path string
+
+	mu      sync.Mutex
+	members map[string]Member
+}
+
+// NewStore loads the members from the file, the members are kept in memory only if the path is empty.
+func NewStore(path string) (*Store, error) {
+	s := &Store{
+		path:    path,
+		members: map[string]Member{},
+	}
+
+	if path == "" {
+		return s, nil
+	}
+
+	if err := jsonfile.Load(path, &s.members); err != nil {
+		return nil, fmt.Errorf("jsonfile.Load: %v", err)
+	}
+
+	return s, nil
+}
+
+// Join starts tracking the member who joined the chat at the date, the rejoined member is tracked anew.
+// The members tracked longer than the Retention are forgotten.
+func (s *Store) Join(chatID, userID int64, at time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// the expired members are saved with the new one
+	for k, member := range s.members {
+		if member.Joined.Before(at.Add(-Retention)) {
+			delete(s.members, k)
+		}
+	}
+
+	if err := s.save(key(chatID, userID), &Member{Joined: at}); err != nil {
+		return fmt.Errorf("s.save: %v", err)
+	}
+
+	return nil
+}
+
+// Get returns the tracked member, false if the member isn't tracked.
+func (s *Store) Get(chatID, userID int64) (Member, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	member, ok := s.members[key(chatID, userID)]
+
+	return member, ok
+}
+
+// CountMessage increments the number of the messages of the tracked member.
+func (s *Store) CountMessage(chatID, userID int64) error {
+	return s.update(chatID, userID, func(member *Member) {
+		member.Messages++
+	})
+}
+
+// MarkWarned marks the tracked member as warned about the restrictions.
+func (s *Store) MarkWarned(chatID, userID int64) error {
+	return s.update(chatID, userID, func(member *Member) {
+		member.Warned = true
+	})
+}
+
+// Remove stops tracking the member.
+func (s *Store) Remove(chatID, userID int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	k := key(chatID, userID)
+	if _, ok := s.members[k]; !ok {
+		return nil
+	}
+
+	if err := s.save(k, nil); err != nil {
+		return fmt.Errorf("s.save: %v", err)
+	}
+
+	return nil
+}
+
+// update changes the tracked member, the untracked members are ignored.
+func (s *Store) update(chatID, userID int64, change func(member *Member)) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	k := key(chatID, userID)
+
+	member, ok := s.members[k]
+	if !ok {
+		return nil
+	}
+
+	change(&member)
+
+	if err := s.save(k, &member); err != nil {
+		return fmt.Errorf("s.save: %v", err)
+	}
+
+	return nil
+}
+
+// save replaces the member, nil removes it, and writes all the members into the file.
+// The previous member is restored if the file can't be written.
+func (s *Store) save(k string, member *Member) error {
+	prev, ok := s.members[k]
+
+	if member == nil {
+		delete(s.members, k)
+	} else {
+		s.members[k] = *member
+	}
+
+	if s.path == "" {
+		return nil
+	}
+
+	if err := jsonfile.Save(s.path, s.members); err != nil {
+		if ok {
+			s.members[k] = prev
+		} else {
+			delete(s.members, k)
+		}
+
+		return fmt.Errorf("jsonfile.Save: %v", err)
+	}
+
+	return nil
+}
+
+// key returns the key of the member, e.g. -100500:42.
+func key(chatID, userID int64) string {
+	return strconv.FormatInt(chatID, 10) + ":" + strconv.FormatInt(userID, 10)
+}
@@ -0,0 +1,79 @@
+package members
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStore(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "members.json")
+	joined := time.Date(2024, 3, 8, 12, 0, 0, 0, time.UTC)
+
+	s, err := NewStore(path)
+	assert.NoError(t, err)
+
+	assert.NoError(t, s.CountMessage(-100500, 42), "untracked member is ignored")
+
+	_, ok := s.Get(-100500, 42)
+	assert.False(t, ok)
+
+	assert.NoError(t, s.Join(-100500, 42, joined))
+	assert.NoError(t, s.CountMessage(-100500, 42))
+	assert.NoError(t, s.CountMessage(-100500, 42))
+	assert.NoError(t, s.MarkWarned(-100500, 42))
+
+	_, ok = s.Get(-100501, 42)
+	assert.False(t, ok, "members are per chat")
+
+	loaded, err := NewStore(path)
+	assert.NoError(t, err)
+
+	member, ok := loaded.Get(-100500, 42)
+	assert.True(t, ok, "members persist")
+	assert.Equal(t, Member{Joined: joined, Messages: 2, Warned: true}, member)
+
+	assert.NoError(t, loaded.Join(-100500, 42, joined.Add(time.Hour)))
+
+	member, _ = loaded.Get(-100500, 42)
+	assert.Equal(t, Member{Joined: joined.Add(time.Hour)}, member, "rejoined member is tracked anew")
+
+	assert.NoError(t, loaded.Remove(-100500, 42))
+	assert.NoError(t, loaded.Remove(-100500, 42))
+
+	loaded, err = NewStore(path)
+	assert.NoError(t, err)
+
+	_, ok = loaded.Get(-100500, 42)
+	assert.False(t, ok, "removal persists")
+}
+
+func TestStore_Join_Retention(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "members.json")
+	joined := time.Date(2024, 3, 8, 12, 0, 0, 0, time.UTC)
+
+	s, err := NewStore(path)
+	assert.NoError(t, err)
+
+	assert.NoError(t, s.Join(-100500, 42, joined))
+	assert.NoError(t, s.Join(-100500, 43, joined.Add(time.Hour)))
+	assert.NoError(t, s.Join(-100501, 44, joined.Add(Retention+time.Minute)))
+
+	loaded, err := NewStore(path)
+	assert.NoError(t, err)
+
+	_, ok := loaded.Get(-100500, 42)
+	assert.False(t, ok, "expired member is forgotten")
+
+	_, ok = loaded.Get(-100500, 43)
+	assert.True(t, ok)
+
+	_, ok = loaded.Get(-100501, 44)
+	assert.True(t, ok)
+}
@@ -0,0 +1,14 @@
+package menu
+
+import (
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// BotProvider interface for telegram bot.
+type BotProvider interface {
+	// SetMyCommands sets the list of the bot commands for the scope and the language.
+	SetMyCommands(scope tgbotapi.BotCommandScope, languageCode string, commands []tgbotapi.BotCommand) error
+
+	// DeleteMyCommands deletes the list of the bot commands for the scope and the language.
+	DeleteMyCommands(scope tgbotapi.BotCommandScope, languageCode string) error
+}
@@ -0,0 +1,122 @@
+// Code generated by mockery v2.36.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// BotProviderMock is an autogenerated mock type for the BotProvider type
+type BotProviderMock struct {
+	mock.Mock
+}
+
+type BotProviderMock_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *BotProviderMock) EXPECT() *BotProviderMock_Expecter {
+	return &BotProviderMock_Expecter{mock: &_m.Mock}
+}
+
+// DeleteMyCommands provides a mock function with given fields: scope, languageCode
+func (_m *BotProviderMock) DeleteMyCommands(scope tgbotapi.BotCommandScope, languageCode string) error {
+	ret := _m.Called(scope, languageCode)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(tgbotapi.BotCommandScope, string) error); ok {
+		r0 = rf(scope, languageCode)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// BotProviderMock_DeleteMyCommands_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteMyCommands'
+type BotProviderMock_DeleteMyCommands_Call struct {
+	*mock.Call
+}
+
+// DeleteMyCommands is a helper method to define mock.On call
+//   - scope tgbotapi.BotCommandScope
+//   - languageCode string
+func (_e *BotProviderMock_Expecter) DeleteMyCommands(scope interface{}, languageCode interface{}) *BotProviderMock_DeleteMyCommands_Call {
+	return &BotProviderMock_DeleteMyCommands_Call{Call: _e.mock.On("DeleteMyCommands", scope, languageCode)}
+}
+
+func (_c *BotProviderMock_DeleteMyCommands_Call) Run(run func(scope tgbotapi.BotCommandScope, languageCode string)) *BotProviderMock_DeleteMyCommands_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(tgbotapi.BotCommandScope), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *BotProviderMock_DeleteMyCommands_Call) Return(_a0 error) *BotProviderMock_DeleteMyCommands_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *BotProviderMock_DeleteMyCommands_Call) RunAndReturn(run func(tgbotapi.BotCommandScope, string) error) *BotProviderMock_DeleteMyCommands_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetMyCommands provides a mock function with given fields: scope, languageCode, commands
+func (_m *BotProviderMock) SetMyCommands(scope tgbotapi.BotCommandScope, languageCode string, commands []tgbotapi.BotCommand) error {
+	ret := _m.Called(scope, languageCode, commands)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(tgbotapi.BotCommandScope, string, []tgbotapi.BotCommand) error); ok {
+		r0 = rf(scope, languageCode, commands)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// BotProviderMock_SetMyCommands_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetMyCommands'
+type BotProviderMock_SetMyCommands_Call struct {
+	*mock.Call
+}
+
+// SetMyCommands is a helper method to define mock.On call
+//   - scope tgbotapi.BotCommandScope
+//   - languageCode string
+//   - commands []tgbotapi.BotCommand
+func (_e *BotProviderMock_Expecter) SetMyCommands(scope interface{}, languageCode interface{}, commands interface{}) *BotProviderMock_SetMyCommands_Call {
+	return &BotProviderMock_SetMyCommands_Call{Call: _e.mock.On("SetMyCommands", scope, languageCode, commands)}
+}
+
+func (_c *BotProviderMock_SetMyCommands_Call) Run(run func(scope tgbotapi.BotCommandScope, languageCode string, commands []tgbotapi.BotCommand)) *BotProviderMock_SetMyCommands_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(tgbotapi.BotCommandScope), args[1].(string), args[2].([]tgbotapi.BotCommand))
+	})
+	return _c
+}
+
+func (_c *BotProviderMock_SetMyCommands_Call) Return(_a0 error) *BotProviderMock_SetMyCommands_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *BotProviderMock_SetMyCommands_Call) RunAndReturn(run func(tgbotapi.BotCommandScope, string, []tgbotapi.BotCommand) error) *BotProviderMock_SetMyCommands_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewBotProviderMock creates a new instance of BotProviderMock. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewBotProviderMock(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *BotProviderMock {
+	mock := &BotProviderMock{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,102 @@
+package menu
+
+import (
+	"fmt"
+	"sync"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"go.uber.org/zap"
+
+	"geeksonator/internal/catalog"
+)
+
+// Syncer publishes the catalog commands to the Telegram "/" menu.
+type Syncer struct {
+	bot    BotProvider
+	logger *zap.Logger
+
+	mu sync.Mutex
+	// published are the menus set by the previous sync, they're deleted when they disappear from the catalog.
+	published map[catalog.MenuKey]struct{}
+}
+
+// NewSyncer creates new syncer of the Telegram menu.
+func NewSyncer(bot BotProvider, logger *zap.Logger) *Syncer {
+	return &Syncer{
+		bot:       bot,
+		logger:    logger.Named("menu_syncer"),
+		published: make(map[catalog.MenuKey]struct{}),
+	}
+}
+
+// Sync sets the menus of the catalog, empty menus and menus removed since the previous sync are deleted.
+// Telegram can't show an empty menu: the empty list is the same as the deleted one, and a chat without
+// its own menu falls back to the global menu of the scope, e.g. a chat without member commands shows the default menu.
+func (s *Syncer) Sync(c *catalog.Catalog) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	menus := c.Menus()
+
+	current := make(map[catalog.MenuKey]struct{}, len(menus))
+	for _, menu := range menus {
+		current[menu.MenuKey] = struct{}{}
+	}
+
+	for key := range s.published {
+		if _, ok := current[key]; ok {
+			continue
+		}
+
+		if err := s.bot.DeleteMyCommands(scope(key.Scope), key.Language); err != nil {
+			return fmt.Errorf("s.bot.DeleteMyCommands: %v", err)
+		}
+
+		delete(s.published, key)
+	}
+
+	for _, menu := range menus {
+		if len(menu.Commands) == 0 {
+			if err := s.bot.DeleteMyCommands(scope(menu.Scope), menu.Language); err != nil {
+				return fmt.Errorf("s.bot.DeleteMyCommands: %v", err)
+			}
+
+			delete(s.published, menu.MenuKey)
+
+			continue
+		}
+
+		if err := s.bot.SetMyCommands(scope(menu.Scope), menu.Language, commands(menu.Commands)); err != nil {
+			return fmt.Errorf("s.bot.SetMyCommands: %v", err)
+		}
+
+		s.published[menu.MenuKey] = struct{}{}
+	}
+
+	s.logger.Info("Commands menu synced",
+		zap.Int("menus", len(s.published)),
+	)
+
+	return nil
+}
+
+// scope converts the catalog scope into the Bot API scope.
+func scope(sc catalog.Scope) tgbotapi.BotCommandScope {
+	return tgbotapi.BotCommandScope{
+		Type:   string(sc.Type),
+		ChatID: sc.ChatID,
+	}
+}
+
+// commands converts the menu commands into the Bot API commands.
+func commands(menuCommands []catalog.MenuCommand) []tgbotapi.BotCommand {
+	botCommands := make([]tgbotapi.BotCommand, 0, len(menuCommands))
+	for _, cmd := range menuCommands {
+		botCommands = append(botCommands, tgbotapi.BotCommand{
+			Command:     cmd.Name,
+			Description: cmd.Description,
+		})
+	}
+
+	return botCommands
+}
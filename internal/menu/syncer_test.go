@@ -0,0 +1,192 @@
+package menu
+
+import (
+	"errors"
+	"testing"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"geeksonator/internal/catalog"
+	"geeksonator/internal/menu/mocks"
+)
+
+// parseCatalog parses the YAML catalog.
+func parseCatalog(t *testing.T, data string) *catalog.Catalog {
+	t.Helper()
+
+	c, err := catalog.Parse([]byte(data), catalog.FormatYAML)
+	assert.NoError(t, err)
+
+	return c
+}
+
+func TestNewSyncer(t *testing.T) {
+	t.Parallel()
+
+	logger := zap.NewNop()
+
+	got := NewSyncer(nil, logger)
+	assert.Equal(t, &Syncer{
+		bot:       nil,
+		logger:    logger.Named("menu_syncer"),
+		published: map[catalog.MenuKey]struct{}{},
+	}, got)
+}
+
+func TestSyncer_Sync(t *testing.T) {
+	t.Parallel()
+
+	c := parseCatalog(t, `version: 1
+commands:
+  - name: help
+    description: Список команд
+    role: member
+    handler: help
+  - name: go
+    description: Go
+    response: "@golangGeeks"
+`)
+
+	defaultScope := tgbotapi.NewBotCommandScopeDefault()
+	adminsScope := tgbotapi.NewBotCommandScopeAllChatAdministrators()
+	chatScope := tgbotapi.NewBotCommandScopeChat(-100500)
+
+	tests := []struct {
+		name          string
+		syncer        func() *Syncer
+		wantErr       bool
+		wantPublished map[catalog.MenuKey]struct{}
+	}{
+		{
+			name: "Success",
+			syncer: func() *Syncer {
+				bot := mocks.NewBotProviderMock(t)
+
+				bot.EXPECT().
+					SetMyCommands(defaultScope, "", []tgbotapi.BotCommand{
+						{Command: "help", Description: "Список команд"},
+					}).
+					Return(nil)
+
+				bot.EXPECT().
+					SetMyCommands(adminsScope, "", []tgbotapi.BotCommand{
+						{Command: "help", Description: "Список команд"},
+						{Command: "go", Description: "Go"},
+					}).
+					Return(nil)
+
+				return NewSyncer(bot, zap.NewNop())
+			},
+			wantErr: false,
+			wantPublished: map[catalog.MenuKey]struct{}{
+				{Scope: catalog.Scope{Type: catalog.ScopeDefault}}:               {},
+				{Scope: catalog.Scope{Type: catalog.ScopeAllChatAdministrators}}: {},
+			},
+		},
+		{
+			name: "Removed menu is deleted",
+			syncer: func() *Syncer {
+				bot := mocks.NewBotProviderMock(t)
+
+				bot.EXPECT().
+					DeleteMyCommands(chatScope, "").
+					Return(nil)
+
+				bot.EXPECT().
+					SetMyCommands(defaultScope, "", []tgbotapi.BotCommand{
+						{Command: "help", Description: "Список команд"},
+					}).
+					Return(nil)
+
+				bot.EXPECT().
+					SetMyCommands(adminsScope, "", []tgbotapi.BotCommand{
+						{Command: "help", Description: "Список команд"},
+						{Command: "go", Description: "Go"},
+					}).
+					Return(nil)
+
+				s := NewSyncer(bot, zap.NewNop())
+				s.published[catalog.MenuKey{Scope: catalog.Scope{Type: catalog.ScopeChat, ChatID: -100500}}] = struct{}{}
+
+				return s
+			},
+			wantErr: false,
+			wantPublished: map[catalog.MenuKey]struct{}{
+				{Scope: catalog.Scope{Type: catalog.ScopeDefault}}:               {},
+				{Scope: catalog.Scope{Type: catalog.ScopeAllChatAdministrators}}: {},
+			},
+		},
+		{
+			name: "Error",
+			syncer: func() *Syncer {
+				bot := mocks.NewBotProviderMock(t)
+
+				bot.EXPECT().
+					SetMyCommands(defaultScope, "", []tgbotapi.BotCommand{
+						{Command: "help", Description: "Список команд"},
+					}).
+					Return(errors.New("error"))
+
+				return NewSyncer(bot, zap.NewNop())
+			},
+			wantErr:       true,
+			wantPublished: map[catalog.MenuKey]struct{}{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			s := tt.syncer()
+
+			err := s.Sync(c)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.wantPublished, s.published)
+		})
+	}
+}
+
+func TestSyncer_Sync_EmptyMenu(t *testing.T) {
+	t.Parallel()
+
+	c := parseCatalog(t, `version: 1
+commands:
+  - name: go
+    description: Go
+    response: "@golangGeeks"
+chats:
+  - id: -100500
+`)
+
+	bot := mocks.NewBotProviderMock(t)
+
+	// the empty menus are deleted, the chat members see the default menu of Telegram
+	bot.EXPECT().
+		DeleteMyCommands(tgbotapi.NewBotCommandScopeDefault(), "").
+		Return(nil)
+	bot.EXPECT().
+		DeleteMyCommands(tgbotapi.NewBotCommandScopeChat(-100500), "").
+		Return(nil)
+
+	bot.EXPECT().
+		SetMyCommands(tgbotapi.NewBotCommandScopeAllChatAdministrators(), "", []tgbotapi.BotCommand{
+			{Command: "go", Description: "Go"},
+		}).
+		Return(nil)
+	bot.EXPECT().
+		SetMyCommands(tgbotapi.NewBotCommandScopeChatAdministrators(-100500), "", []tgbotapi.BotCommand{
+			{Command: "go", Description: "Go"},
+		}).
+		Return(nil)
+
+	s := NewSyncer(bot, zap.NewNop())
+	s.published[catalog.MenuKey{Scope: catalog.Scope{Type: catalog.ScopeChat, ChatID: -100500}}] = struct{}{}
+
+	assert.NoError(t, s.Sync(c))
+	assert.Equal(t, map[catalog.MenuKey]struct{}{
+		{Scope: catalog.Scope{Type: catalog.ScopeAllChatAdministrators}}:               {},
+		{Scope: catalog.Scope{Type: catalog.ScopeChatAdministrators, ChatID: -100500}}: {},
+	}, s.published)
+}
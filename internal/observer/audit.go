@@ -0,0 +1,150 @@
+package observer
+
+import (
+	"fmt"
+	"html"
+	"strconv"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"go.uber.org/zap"
+
+	"geeksonator/internal/audit"
+	"geeksonator/pkg/duration"
+)
+
+// auditQuoteLength is the maximum length of the message quote in the log chat, so the event fits into a message.
+const auditQuoteLength = 1000
+
+// auditEvent returns the event of the action applied to the message and its author.
+// The actor is nil for the automatic actions of the bot.
+func (m *Manager) auditEvent(action audit.Action, chat *tgbotapi.Chat, message *tgbotapi.Message, actor *tgbotapi.User) audit.Event {
+	e := audit.Event{
+		Date:   m.timeNow(),
+		Action: action,
+		ChatID: chat.ID,
+		Chat:   chat.Title,
+	}
+
+	if actor != nil {
+		e.ActorID, e.Actor = actor.ID, userName(actor)
+	}
+
+	if message == nil {
+		return e
+	}
+
+	if message.From != nil {
+		e.TargetID, e.Target = message.From.ID, userName(message.From)
+	}
+
+	e.Link = messageLink(chat, message.MessageID)
+
+	return e
+}
+
+// auditAction logs the automatic action of the bot applied to the author of the message.
+func (m *Manager) auditAction(message *tgbotapi.Message, action audit.Action, d time.Duration, reason string) {
+	e := m.auditEvent(action, message.Chat, message, nil)
+	e.Duration = auditDuration(d)
+	e.Reason = reason
+	m.audit(message.Chat, e)
+}
+
+// audit writes the event to the log file and posts it to the log chat.
+// The action is already applied, so the failures are only logged.
+func (m *Manager) audit(chat *tgbotapi.Chat, e audit.Event) {
+	if m.auditor == nil {
+		return
+	}
+
+	if err := m.auditor.Write(e); err != nil {
+		m.log("Write audit event",
+			zap.String("action", string(e.Action)),
+			zap.Error(err),
+		)
+	}
+
+	logChat := m.commands.Audit(chatRef(chat)).LogChat
+	if logChat == 0 {
+		return
+	}
+
+	msg := m.bot.NewMessage(logChat, auditText(e))
+	msg.ParseMode = "html"
+	msg.DisableWebPagePreview = true
+
+	if _, err := m.bot.Send(msg); err != nil {
+		m.log("Send audit event",
+			zap.String("action", string(e.Action)),
+			zap.Error(err),
+		)
+	}
+}
+
+// auditText returns the message of the event in the log chat, the action is a hashtag to search the events.
+func auditText(e audit.Event) string {
+	text := "#" + string(e.Action) + "\nЧат: " + html.EscapeString(e.Chat) + " (" + strconv.FormatInt(e.ChatID, 10) + ")"
+
+	actor := "бот"
+	if e.ActorID != 0 {
+		actor = userLink(e.ActorID, e.Actor)
+	}
+	text += "\nАдминистратор: " + actor
+
+	if e.TargetID != 0 {
+		text += "\nПользователь: " + userLink(e.TargetID, e.Target) + " (" + strconv.FormatInt(e.TargetID, 10) + ")"
+	}
+
+	switch e.Action {
+	case audit.ActionBan, audit.ActionMute, audit.ActionFlood:
+		if e.Duration == 0 {
+			text += "\nСрок: навсегда"
+		} else {
+			text += "\nСрок: " + duration.Format(time.Duration(e.Duration)*time.Second)
+		}
+	default:
+	}
+
+	if e.Reason != "" {
+		text += "\nПричина: " + html.EscapeString(e.Reason)
+	}
+
+	if e.Link != "" {
+		text += "\nСообщение: " + e.Link
+	}
+
+	if e.Content != "" {
+		text += "\n<blockquote>" + html.EscapeString(shorten(e.Content, auditQuoteLength)) + "</blockquote>"
+	}
+
+	return text
+}
+
+// auditDuration returns the duration of the event in seconds.
+func auditDuration(d time.Duration) int64 {
+	return int64(d / time.Second)
+}
+
+// userName returns the plain text name of the user, e.g. @username or the full name.
+func userName(user *tgbotapi.User) string {
+	if user.UserName != "" {
+		return "@" + user.UserName
+	}
+
+	return fullName(user)
+}
+
+// userLink returns the HTML link to the user with the name.
+func userLink(id int64, name string) string {
+	return fmt.Sprintf(`<a href="tg://user?id=%d">%s</a>`, id, html.EscapeString(name))
+}
+
+// content returns the text or the caption of the message.
+func content(message *tgbotapi.Message) string {
+	if message.Text != "" {
+		return message.Text
+	}
+
+	return message.Caption
+}
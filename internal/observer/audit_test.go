@@ -0,0 +1,290 @@
+package observer
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/stretchr/testify/assert"
+
+	"geeksonator/internal/audit"
+	"geeksonator/internal/captcha"
+	"geeksonator/internal/catalog"
+	"geeksonator/internal/observer/mocks"
+)
+
+func Test_auditText(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		event audit.Event
+		want  string
+	}{
+		{
+			name: "Ban with content",
+			event: audit.Event{
+				Action:   audit.ActionBan,
+				ChatID:   -100300600,
+				Chat:     "PHP <Geeks>",
+				ActorID:  100500,
+				Actor:    "@admin",
+				TargetID: 100501,
+				Target:   "@spammer",
+				Duration: 86400,
+				Reason:   "спам",
+				Link:     "https://t.me/phpGeeks/41",
+				Content:  "buy <now>",
+			},
+			want: "#ban\nЧат: PHP &lt;Geeks&gt; (-100300600)" +
+				"\nАдминистратор: <a href=\"tg://user?id=100500\">@admin</a>" +
+				"\nПользователь: <a href=\"tg://user?id=100501\">@spammer</a> (100501)" +
+				"\nСрок: 1 день" +
+				"\nПричина: спам" +
+				"\nСообщение: https://t.me/phpGeeks/41" +
+				"\n<blockquote>buy &lt;now&gt;</blockquote>",
+		},
+		{
+			name: "Permanent mute by the bot",
+			event: audit.Event{
+				Action:   audit.ActionMute,
+				ChatID:   300600,
+				TargetID: 100501,
+				Target:   "John",
+			},
+			want: "#mute\nЧат:  (300600)" +
+				"\nАдминистратор: бот" +
+				"\nПользователь: <a href=\"tg://user?id=100501\">John</a> (100501)" +
+				"\nСрок: навсегда",
+		},
+		{
+			name: "Unwarn",
+			event: audit.Event{
+				Action:   audit.ActionUnwarn,
+				ChatID:   300600,
+				Chat:     "Geeks",
+				ActorID:  100500,
+				Actor:    "Admin",
+				TargetID: 100501,
+				Target:   "@spammer",
+			},
+			want: "#unwarn\nЧат: Geeks (300600)" +
+				"\nАдминистратор: <a href=\"tg://user?id=100500\">Admin</a>" +
+				"\nПользователь: <a href=\"tg://user?id=100501\">@spammer</a> (100501)",
+		},
+		{
+			name: "Long content is shortened",
+			event: audit.Event{
+				Action:  audit.ActionDelete,
+				ChatID:  300600,
+				Chat:    "Geeks",
+				Content: strings.Repeat("спам ", 1000),
+			},
+			want: "#delete\nЧат: Geeks (300600)" +
+				"\nАдминистратор: бот" +
+				"\n<blockquote>" + strings.Repeat("спам ", 199) + "спам…</blockquote>",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := auditText(tt.event)
+			assert.Equal(t, tt.want, got)
+			assert.LessOrEqual(t, utf8.RuneCountInString(got), 4096, "fits into a message")
+		})
+	}
+}
+
+func TestManager_audit(t *testing.T) {
+	t.Parallel()
+
+	chat := &tgbotapi.Chat{ID: 300600, Title: "Geeks"}
+	event := audit.Event{
+		Date:   moderationNow,
+		Action: audit.ActionDelete,
+		ChatID: 300600,
+		Chat:   "Geeks",
+	}
+
+	tests := []struct {
+		name     string
+		auditor  bool
+		writeErr error
+		logChat  int64
+		sendErr  error
+	}{
+		{
+			name: "Disabled",
+		},
+		{
+			name:    "File only",
+			auditor: true,
+		},
+		{
+			name:    "File and log chat",
+			auditor: true,
+			logChat: -100700,
+		},
+		{
+			name:     "Failures are only logged",
+			auditor:  true,
+			writeErr: errors.New("disk full"),
+			logChat:  -100700,
+			sendErr:  errors.New("chat not found"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			botProvider := mocks.NewBotProviderMock(t)
+			commands := mocks.NewCommandsMock(t)
+
+			m := &Manager{
+				bot:      botProvider,
+				commands: commands,
+			}
+
+			if tt.auditor {
+				auditor := mocks.NewAuditorMock(t)
+
+				auditor.EXPECT().
+					Write(event).
+					Return(tt.writeErr)
+
+				commands.EXPECT().
+					Audit(catalog.ChatRef{ID: 300600}).
+					Return(&catalog.Audit{LogChat: tt.logChat})
+
+				m.auditor = auditor
+			}
+
+			if tt.logChat != 0 {
+				msg := tgbotapi.NewMessage(tt.logChat, auditText(event))
+
+				botProvider.EXPECT().
+					NewMessage(tt.logChat, auditText(event)).
+					Return(msg)
+
+				msg.ParseMode = "html"
+				msg.DisableWebPagePreview = true
+
+				botProvider.EXPECT().
+					Send(msg).
+					Return(tgbotapi.Message{}, tt.sendErr)
+			}
+
+			m.audit(chat, event)
+		})
+	}
+}
+
+func TestManager_ban_Audit(t *testing.T) {
+	t.Parallel()
+
+	spammer := &tgbotapi.User{ID: 100501, UserName: "spammer"}
+	req := moderationRequest(catalog.HandlerBan, "1d -d spam", spammer)
+	req.message.From.FirstName = "Admin"
+	req.message.ReplyToMessage.Text = "buy now"
+
+	botProvider := mocks.NewBotProviderMock(t)
+	commands := mocks.NewCommandsMock(t)
+	auditor := mocks.NewAuditorMock(t)
+
+	botProvider.EXPECT().
+		BanChatMember(int64(300600), int64(100501), moderationNow.Add(24*time.Hour), true).
+		Return(nil)
+
+	auditor.EXPECT().
+		Write(audit.Event{
+			Date:     moderationNow,
+			Action:   audit.ActionBan,
+			ChatID:   300600,
+			ActorID:  100500,
+			Actor:    "Admin",
+			TargetID: 100501,
+			Target:   "@spammer",
+			Duration: 86400,
+			Reason:   "spam",
+			Content:  "buy now",
+		}).
+		Return(nil)
+
+	commands.EXPECT().
+		Audit(catalog.ChatRef{ID: 300600}).
+		Return(&catalog.Audit{})
+
+	m := &Manager{
+		bot:      botProvider,
+		cache:    adminsCache(t, 100500),
+		commands: commands,
+		auditor:  auditor,
+		now:      func() time.Time { return moderationNow },
+	}
+
+	got, err := m.ban(req)
+	assert.NoError(t, err)
+	assert.Len(t, got, 1)
+}
+
+func TestManager_expireChallenge_Audit(t *testing.T) {
+	t.Parallel()
+
+	key := challengeKey{chatID: 300600, userID: 100501}
+
+	botProvider := mocks.NewBotProviderMock(t)
+	commands := mocks.NewCommandsMock(t)
+	auditor := mocks.NewAuditorMock(t)
+
+	botProvider.EXPECT().
+		BanChatMember(int64(300600), int64(100501), time.Time{}, false).
+		Return(nil)
+	botProvider.EXPECT().
+		UnbanChatMember(int64(300600), int64(100501)).
+		Return(nil)
+	botProvider.EXPECT().
+		DeleteMessage(int64(300600), 43).
+		Return(nil)
+
+	auditor.EXPECT().
+		Write(audit.Event{
+			Date:     moderationNow,
+			Action:   audit.ActionCaptcha,
+			ChatID:   300600,
+			Chat:     "Geeks",
+			TargetID: 100501,
+			Target:   "Bot Net",
+			Reason:   "нет ответа",
+		}).
+		Return(nil)
+
+	commands.EXPECT().
+		Audit(catalog.ChatRef{ID: 300600}).
+		Return(&catalog.Audit{})
+
+	m := &Manager{
+		bot:      botProvider,
+		commands: commands,
+		auditor:  auditor,
+		now:      func() time.Time { return moderationNow },
+		challenges: map[challengeKey]*pendingChallenge{
+			key: {
+				Pending: captcha.Pending{
+					ChatID:    300600,
+					ChatTitle: "Geeks",
+					UserID:    100501,
+					FirstName: "Bot",
+					LastName:  "Net",
+					MessageID: 43,
+				},
+				timer: time.NewTimer(time.Hour),
+			},
+		},
+	}
+
+	m.expireChallenge(key)
+}
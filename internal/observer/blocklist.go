@@ -0,0 +1,99 @@
+package observer
+
+import (
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"go.uber.org/zap"
+
+	"geeksonator/internal/audit"
+	"geeksonator/internal/blocklist"
+)
+
+// processingJoinRequest declines the request of the blocked or federation-banned user to join the chat,
+// the other requests are left to the admins.
+func (m *Manager) processingJoinRequest(req *tgbotapi.ChatJoinRequest) {
+	if m.declineBlocklisted(req) {
+		return
+	}
+
+	m.declineFederated(req)
+}
+
+// declineBlocklisted declines the request of the blocked user, true is returned if the user is blocked.
+func (m *Manager) declineBlocklisted(req *tgbotapi.ChatJoinRequest) bool {
+	if m.blocklist == nil {
+		return false
+	}
+
+	entry, ok := m.blocklist.Get(req.From.ID)
+	if !ok {
+		return false
+	}
+
+	err := m.bot.DeclineChatJoinRequest(req.Chat.ID, req.From.ID)
+	if err != nil {
+		m.log("Decline join request",
+			zap.Int64("userID", req.From.ID),
+			zap.Error(err),
+		)
+
+		return true
+	}
+
+	e := m.auditEvent(audit.ActionBlocklist, &req.Chat, nil, nil)
+	e.TargetID, e.Target = req.From.ID, userName(&req.From)
+	e.Reason = "заявка на вступление отклонена, " + blocklistReason(entry)
+	m.audit(&req.Chat, e)
+
+	return true
+}
+
+// banBlocklisted bans the blocked new members and removes them from the new members of the message,
+// so they're neither tracked nor challenged. The members are kept if the ban fails.
+// The chat isn't notified, the bans are only logged to the audit log.
+func (m *Manager) banBlocklisted(message *tgbotapi.Message) {
+	if m.blocklist == nil {
+		return
+	}
+
+	joined := make([]tgbotapi.User, 0, len(message.NewChatMembers))
+
+	for _, member := range message.NewChatMembers {
+		entry, ok := m.blocklist.Get(member.ID)
+		if !ok {
+			joined = append(joined, member)
+
+			continue
+		}
+
+		err := m.bot.BanChatMember(message.Chat.ID, member.ID, time.Time{}, false)
+		if err != nil {
+			m.log("Ban blocked member",
+				zap.Int64("userID", member.ID),
+				zap.Error(err),
+			)
+
+			joined = append(joined, member)
+
+			continue
+		}
+
+		e := m.auditEvent(audit.ActionBlocklist, message.Chat, nil, nil)
+		e.TargetID, e.Target = member.ID, userName(&member)
+		e.Reason = "бан при входе, " + blocklistReason(entry)
+		m.audit(message.Chat, e)
+	}
+
+	message.NewChatMembers = joined
+}
+
+// blocklistReason returns the reason of the blocklist entry with its source, e.g. "в списке spam.csv: scam".
+func blocklistReason(entry blocklist.Entry) string {
+	reason := "в списке " + entry.Source
+	if entry.Reason != "" {
+		reason += ": " + entry.Reason
+	}
+
+	return reason
+}
@@ -0,0 +1,148 @@
+package observer
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/stretchr/testify/assert"
+
+	"geeksonator/internal/audit"
+	"geeksonator/internal/blocklist"
+	"geeksonator/internal/catalog"
+	"geeksonator/internal/observer/mocks"
+)
+
+func TestManager_processingUpdate_JoinRequest(t *testing.T) {
+	t.Parallel()
+
+	entry := blocklist.Entry{UserID: 100501, Reason: "scam", Source: "spam.csv"}
+
+	tests := []struct {
+		name       string
+		blocked    bool
+		declineErr error
+		wantAudit  bool
+	}{
+		{
+			name:      "Blocked user is declined",
+			blocked:   true,
+			wantAudit: true,
+		},
+		{
+			name:       "Decline failed",
+			blocked:    true,
+			declineErr: errors.New("not enough rights"),
+		},
+		{
+			name: "Other users are left to the admins",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			botProvider := mocks.NewBotProviderMock(t)
+			list := mocks.NewBlocklistMock(t)
+			auditor := mocks.NewAuditorMock(t)
+			commands := mocks.NewCommandsMock(t)
+
+			list.EXPECT().
+				Get(int64(100501)).
+				Return(entry, tt.blocked)
+
+			if tt.blocked {
+				botProvider.EXPECT().
+					DeclineChatJoinRequest(int64(300600), int64(100501)).
+					Return(tt.declineErr)
+			}
+
+			if tt.wantAudit {
+				auditor.EXPECT().
+					Write(audit.Event{
+						Date:     moderationNow,
+						Action:   audit.ActionBlocklist,
+						ChatID:   300600,
+						Chat:     "Geeks",
+						TargetID: 100501,
+						Target:   "@spammer",
+						Reason:   "заявка на вступление отклонена, в списке spam.csv: scam",
+					}).
+					Return(nil)
+
+				commands.EXPECT().
+					Audit(catalog.ChatRef{ID: 300600}).
+					Return(&catalog.Audit{})
+			}
+
+			m := &Manager{
+				bot:       botProvider,
+				commands:  commands,
+				blocklist: list,
+				auditor:   auditor,
+				now:       func() time.Time { return moderationNow },
+			}
+
+			err := m.processingUpdate(tgbotapi.Update{
+				ChatJoinRequest: &tgbotapi.ChatJoinRequest{
+					Chat: tgbotapi.Chat{ID: 300600, Title: "Geeks"},
+					From: tgbotapi.User{ID: 100501, UserName: "spammer"},
+				},
+			})
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestManager_processingMessage_BlocklistJoin(t *testing.T) {
+	t.Parallel()
+
+	botProvider := mocks.NewBotProviderMock(t)
+	commands := mocks.NewCommandsMock(t)
+	list := mocks.NewBlocklistMock(t)
+
+	list.EXPECT().
+		Get(int64(100501)).
+		Return(blocklist.Entry{UserID: 100501, Source: "spam.csv"}, true)
+	list.EXPECT().
+		Get(int64(100502)).
+		Return(blocklist.Entry{}, false)
+	list.EXPECT().
+		Get(int64(100503)).
+		Return(blocklist.Entry{UserID: 100503, Source: "spam.csv"}, true)
+
+	botProvider.EXPECT().
+		BanChatMember(int64(300600), int64(100501), time.Time{}, false).
+		Return(nil)
+	botProvider.EXPECT().
+		BanChatMember(int64(300600), int64(100503), time.Time{}, false).
+		Return(errors.New("not enough rights"))
+
+	commands.EXPECT().
+		Captcha(catalog.ChatRef{ID: 300600}).
+		Return(nil, false)
+
+	m := &Manager{
+		bot:       botProvider,
+		commands:  commands,
+		blocklist: list,
+	}
+
+	message := &tgbotapi.Message{
+		Chat: &tgbotapi.Chat{ID: 300600},
+		NewChatMembers: []tgbotapi.User{
+			{ID: 100501, UserName: "spammer"},
+			{ID: 100502, UserName: "newbie"},
+			{ID: 100503, UserName: "scammer"},
+		},
+	}
+
+	got, err := m.processingMessage(message)
+	assert.NoError(t, err)
+	assert.Empty(t, got.texts)
+	assert.Equal(t, []tgbotapi.User{
+		{ID: 100502, UserName: "newbie"},
+		{ID: 100503, UserName: "scammer"},
+	}, message.NewChatMembers, "the member is kept if the ban fails")
+}
@@ -0,0 +1,372 @@
+package observer
+
+import (
+	"math/rand/v2"
+	"strconv"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"go.uber.org/zap"
+
+	"geeksonator/internal/audit"
+	"geeksonator/internal/captcha"
+	"geeksonator/pkg/duration"
+)
+
+// captchaPrefix is the prefix of the callback data of the challenge buttons: captcha:<userID>:<option>.
+const captchaPrefix = "captcha:"
+
+// challengeKey identifies the challenge of the new member.
+type challengeKey struct {
+	chatID int64
+	userID int64
+}
+
+// pendingChallenge is the challenge waiting for the answer.
+type pendingChallenge struct {
+	captcha.Pending
+
+	// timer kicks the member if the challenge isn't solved in time.
+	timer *time.Timer
+}
+
+// chat returns the chat of the challenge logged to the audit log.
+func (p *pendingChallenge) chat() *tgbotapi.Chat {
+	return &tgbotapi.Chat{ID: p.ChatID, Title: p.ChatTitle}
+}
+
+// member returns the challenged member logged to the audit log.
+func (p *pendingChallenge) member() *tgbotapi.User {
+	return &tgbotapi.User{ID: p.UserID, UserName: p.UserName, FirstName: p.FirstName, LastName: p.LastName}
+}
+
+// challengeNewMembers restricts the new chat members and posts the challenges to them.
+func (m *Manager) challengeNewMembers(message *tgbotapi.Message) {
+	cfg, ok := m.commands.Captcha(chatRef(message.Chat))
+	if !ok {
+		return
+	}
+
+	for i := range message.NewChatMembers {
+		member := &message.NewChatMembers[i]
+		if member.IsBot {
+			continue
+		}
+
+		m.challenge(message.Chat, member, cfg.TimeoutValue())
+	}
+}
+
+// challenge restricts the member until the timeout and posts the challenge with the inline buttons.
+// The restriction expires with the timeout, so the member isn't restricted forever if the bot restarts.
+func (m *Manager) challenge(chat *tgbotapi.Chat, member *tgbotapi.User, timeout time.Duration) {
+	chatID := chat.ID
+
+	err := m.bot.RestrictChatMember(chatID, member.ID, tgbotapi.ChatPermissions{}, m.timeNow().Add(timeout))
+	if err != nil {
+		m.log("Restrict new member",
+			zap.Int64("userID", member.ID),
+			zap.Error(err),
+		)
+
+		return
+	}
+
+	ch := captcha.New(m.random())
+
+	buttons := make([]tgbotapi.InlineKeyboardButton, 0, len(ch.Options))
+	for i, option := range ch.Options {
+		data := captchaPrefix + strconv.FormatInt(member.ID, 10) + ":" + strconv.Itoa(i)
+		buttons = append(buttons, tgbotapi.NewInlineKeyboardButtonData(option, data))
+	}
+
+	msg := m.bot.NewMessage(chatID, mention(member)+", добро пожаловать! Чтобы писать в чат, ответьте на вопрос за "+
+		duration.Format(timeout)+".\n"+ch.Question)
+	msg.ParseMode = "html"
+	msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(buttons)
+
+	sent, err := m.bot.Send(msg)
+	if err != nil {
+		m.log("Send challenge",
+			zap.Int64("userID", member.ID),
+			zap.Error(err),
+		)
+
+		return
+	}
+
+	p := captcha.Pending{
+		ChatID:    chatID,
+		ChatTitle: chat.Title,
+		UserID:    member.ID,
+		UserName:  member.UserName,
+		FirstName: member.FirstName,
+		LastName:  member.LastName,
+		MessageID: sent.MessageID,
+		Answer:    ch.Answer,
+		Deadline:  m.timeNow().Add(timeout),
+	}
+	p.Scheduled = m.scheduleChallengeDeletion(p)
+
+	if m.challengeStore != nil {
+		if err := m.challengeStore.Add(p); err != nil {
+			m.log("Save challenge",
+				zap.Int64("userID", member.ID),
+				zap.Error(err),
+			)
+		}
+	}
+
+	m.armChallenge(p)
+}
+
+// scheduleChallengeDeletion schedules the deletion of the challenge message at the deadline,
+// so the message is deleted even if the bot is down then. It returns false if the deletion isn't scheduled.
+func (m *Manager) scheduleChallengeDeletion(p captcha.Pending) bool {
+	if m.scheduler == nil {
+		return false
+	}
+
+	if err := m.scheduler.Schedule(p.ChatID, p.MessageID, p.Deadline); err != nil {
+		m.log("Schedule challenge deletion",
+			zap.Int("messageID", p.MessageID),
+			zap.Error(err),
+		)
+
+		return false
+	}
+
+	return true
+}
+
+// restoreChallenges arms the challenges pending before the restart, the overdue ones expire at once.
+func (m *Manager) restoreChallenges() {
+	if m.challengeStore == nil {
+		return
+	}
+
+	for _, p := range m.challengeStore.List() {
+		m.armChallenge(p)
+	}
+}
+
+// armChallenge starts waiting for the answer to the challenge until its deadline.
+func (m *Manager) armChallenge(p captcha.Pending) {
+	key := challengeKey{chatID: p.ChatID, userID: p.UserID}
+
+	m.challengesMu.Lock()
+	defer m.challengesMu.Unlock()
+
+	if m.challenges == nil {
+		m.challenges = make(map[challengeKey]*pendingChallenge)
+	}
+
+	// the member rejoined before the previous challenge expired
+	if prev, ok := m.challenges[key]; ok {
+		prev.timer.Stop()
+	}
+
+	m.challenges[key] = &pendingChallenge{
+		Pending: p,
+		timer: time.AfterFunc(p.Deadline.Sub(m.timeNow()), func() {
+			m.expireChallenge(key)
+		}),
+	}
+}
+
+// takeChallenge removes the pending challenge and returns it, false if there's no such challenge.
+func (m *Manager) takeChallenge(key challengeKey) (*pendingChallenge, bool) {
+	m.challengesMu.Lock()
+	defer m.challengesMu.Unlock()
+
+	pending, ok := m.challenges[key]
+	if !ok {
+		return nil, false
+	}
+
+	delete(m.challenges, key)
+	pending.timer.Stop()
+
+	if m.challengeStore != nil {
+		if err := m.challengeStore.Remove(key.chatID, key.userID); err != nil {
+			m.log("Remove challenge",
+				zap.Int64("userID", key.userID),
+				zap.Error(err),
+			)
+		}
+	}
+
+	return pending, true
+}
+
+// expireChallenge kicks the member who didn't solve the challenge in time. The challenge message
+// is deleted by the scheduler at the deadline, it's deleted here only if the deletion isn't scheduled.
+func (m *Manager) expireChallenge(key challengeKey) {
+	pending, ok := m.takeChallenge(key)
+	if !ok {
+		return
+	}
+
+	if m.kick(key) {
+		m.auditCaptcha(pending.chat(), pending.member(), "нет ответа")
+	}
+
+	if !pending.Scheduled {
+		m.deleteChallenge(pending)
+	}
+}
+
+// processingCallback processes the inline button press of the challenge or the report.
+func (m *Manager) processingCallback(query *tgbotapi.CallbackQuery) {
+	if strings.HasPrefix(query.Data, reportPrefix) {
+		m.processingReportCallback(query)
+
+		return
+	}
+
+	userID, option, ok := parseCaptchaData(query.Data)
+	if !ok || query.Message == nil || query.Message.Chat == nil {
+		return
+	}
+
+	if query.From == nil || query.From.ID != userID {
+		m.answerCallback(query.ID, "Это проверка для другого участника.")
+
+		return
+	}
+
+	key := challengeKey{chatID: query.Message.Chat.ID, userID: userID}
+
+	pending, ok := m.takeChallenge(key)
+	if !ok {
+		m.answerCallback(query.ID, "Проверка уже завершена.")
+
+		return
+	}
+
+	m.deleteChallenge(pending)
+
+	if option != pending.Answer {
+		if m.kick(key) {
+			m.auditCaptcha(query.Message.Chat, query.From, "неверный ответ")
+		}
+
+		m.answerCallback(query.ID, "Неверный ответ.")
+
+		return
+	}
+
+	err := m.bot.RestrictChatMember(key.chatID, key.userID, memberPermissions(), time.Time{})
+	if err != nil {
+		m.log("Lift new member restriction",
+			zap.Int64("userID", key.userID),
+			zap.Error(err),
+		)
+	}
+
+	m.answerCallback(query.ID, "Добро пожаловать!")
+}
+
+// parseCaptchaData parses the callback data of the challenge button.
+func parseCaptchaData(data string) (int64, int, bool) {
+	rest, ok := strings.CutPrefix(data, captchaPrefix)
+	if !ok {
+		return 0, 0, false
+	}
+
+	user, opt, ok := strings.Cut(rest, ":")
+	if !ok {
+		return 0, 0, false
+	}
+
+	userID, err := strconv.ParseInt(user, 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+
+	option, err := strconv.Atoi(opt)
+	if err != nil {
+		return 0, 0, false
+	}
+
+	return userID, option, true
+}
+
+// kick removes the member from the chat, the member can join again.
+// It returns false if the member isn't removed.
+func (m *Manager) kick(key challengeKey) bool {
+	err := m.bot.BanChatMember(key.chatID, key.userID, time.Time{}, false)
+	if err != nil {
+		m.log("Kick member",
+			zap.Int64("userID", key.userID),
+			zap.Error(err),
+		)
+
+		return false
+	}
+
+	m.forgetMember(key.chatID, key.userID)
+
+	err = m.bot.UnbanChatMember(key.chatID, key.userID)
+	if err != nil {
+		m.log("Unban kicked member",
+			zap.Int64("userID", key.userID),
+			zap.Error(err),
+		)
+	}
+
+	return true
+}
+
+// auditCaptcha logs the member kicked for the failed challenge.
+func (m *Manager) auditCaptcha(chat *tgbotapi.Chat, member *tgbotapi.User, reason string) {
+	if chat == nil || member == nil {
+		return
+	}
+
+	e := m.auditEvent(audit.ActionCaptcha, chat, nil, nil)
+	e.TargetID, e.Target = member.ID, userName(member)
+	e.Reason = reason
+	m.audit(chat, e)
+}
+
+// deleteChallenge deletes the challenge message and cancels its scheduled deletion, the failures are only logged.
+func (m *Manager) deleteChallenge(pending *pendingChallenge) {
+	if err := m.bot.DeleteMessage(pending.ChatID, pending.MessageID); err != nil {
+		m.log("Delete challenge",
+			zap.Int("messageID", pending.MessageID),
+			zap.Error(err),
+		)
+	}
+
+	if !pending.Scheduled {
+		return
+	}
+
+	if err := m.scheduler.Cancel(pending.ChatID, pending.MessageID); err != nil {
+		m.log("Cancel challenge deletion",
+			zap.Int("messageID", pending.MessageID),
+			zap.Error(err),
+		)
+	}
+}
+
+// answerCallback shows the notification to the user who pressed the button, the failure is only logged.
+func (m *Manager) answerCallback(queryID, text string) {
+	if err := m.bot.AnswerCallbackQuery(queryID, text); err != nil {
+		m.log("Answer callback query",
+			zap.String("queryID", queryID),
+			zap.Error(err),
+		)
+	}
+}
+
+// random returns the random number function of the challenges.
+func (m *Manager) random() func(n int) int {
+	if m.intn != nil {
+		return m.intn
+	}
+
+	return rand.IntN
+}
@@ -0,0 +1,458 @@
+package observer
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/stretchr/testify/assert"
+
+	"geeksonator/internal/captcha"
+	"geeksonator/internal/catalog"
+	"geeksonator/internal/observer/mocks"
+)
+
+// challengeManager returns the manager with the pending challenge of the user 100501 in the chat 300600,
+// the answer is the first option.
+func challengeManager(botProvider BotProvider) *Manager {
+	return &Manager{
+		bot: botProvider,
+		challenges: map[challengeKey]*pendingChallenge{
+			{chatID: 300600, userID: 100501}: {
+				Pending: captcha.Pending{ChatID: 300600, UserID: 100501, MessageID: 50, Answer: 0},
+				timer:   time.NewTimer(time.Hour),
+			},
+		},
+	}
+}
+
+func TestManager_challengeNewMembers(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 3, 8, 12, 0, 0, 0, time.UTC)
+	text := "@newbie, добро пожаловать! Чтобы писать в чат, ответьте на вопрос за 2 минуты.\nВыберите слона PHP."
+
+	botProvider := mocks.NewBotProviderMock(t)
+
+	botProvider.EXPECT().
+		RestrictChatMember(int64(300600), int64(100501), tgbotapi.ChatPermissions{}, now.Add(2*time.Minute)).
+		Return(nil)
+
+	botProvider.EXPECT().
+		RestrictChatMember(int64(300600), int64(100502), tgbotapi.ChatPermissions{}, now.Add(2*time.Minute)).
+		Return(errors.New("not enough rights"))
+
+	botProvider.EXPECT().
+		NewMessage(int64(300600), text).
+		Return(tgbotapi.MessageConfig{Text: text})
+
+	botProvider.EXPECT().
+		Send(tgbotapi.MessageConfig{
+			BaseChat: tgbotapi.BaseChat{
+				ReplyMarkup: tgbotapi.NewInlineKeyboardMarkup([]tgbotapi.InlineKeyboardButton{
+					tgbotapi.NewInlineKeyboardButtonData("🐘", "captcha:100501:0"),
+					tgbotapi.NewInlineKeyboardButtonData("🐹", "captcha:100501:1"),
+					tgbotapi.NewInlineKeyboardButtonData("🦀", "captcha:100501:2"),
+					tgbotapi.NewInlineKeyboardButtonData("🐳", "captcha:100501:3"),
+				}),
+			},
+			Text:      text,
+			ParseMode: "html",
+		}).
+		Return(tgbotapi.Message{MessageID: 50}, nil)
+
+	commands := mocks.NewCommandsMock(t)
+
+	commands.EXPECT().
+		Captcha(catalog.ChatRef{ID: 300600}).
+		Return(&catalog.Captcha{}, true)
+
+	scheduler := mocks.NewSchedulerMock(t)
+
+	scheduler.EXPECT().
+		Schedule(int64(300600), 50, now.Add(2*time.Minute)).
+		Return(nil)
+
+	store := mocks.NewChallengesMock(t)
+
+	store.EXPECT().
+		Add(captcha.Pending{
+			ChatID:    300600,
+			UserID:    100501,
+			UserName:  "newbie",
+			MessageID: 50,
+			Deadline:  now.Add(2 * time.Minute),
+			Scheduled: true,
+		}).
+		Return(errors.New("disk full"))
+
+	store.EXPECT().
+		Remove(int64(300600), int64(100501)).
+		Return(nil)
+
+	m := &Manager{
+		bot:            botProvider,
+		commands:       commands,
+		scheduler:      scheduler,
+		challengeStore: store,
+		now:            func() time.Time { return now },
+		intn:           func(int) int { return 0 },
+	}
+
+	got, err := m.processingMessage(&tgbotapi.Message{
+		Chat: &tgbotapi.Chat{ID: 300600},
+		NewChatMembers: []tgbotapi.User{
+			{ID: 100501, UserName: "newbie"},
+			{ID: 100502, UserName: "readonly"},
+			{ID: 100503, UserName: "other_bot", IsBot: true},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Empty(t, got.texts)
+
+	pending, ok := m.takeChallenge(challengeKey{chatID: 300600, userID: 100501})
+	assert.True(t, ok)
+	assert.Equal(t, captcha.Pending{
+		ChatID:    300600,
+		UserID:    100501,
+		UserName:  "newbie",
+		MessageID: 50,
+		Answer:    0,
+		Deadline:  now.Add(2 * time.Minute),
+		Scheduled: true,
+	}, pending.Pending)
+
+	_, ok = m.takeChallenge(challengeKey{chatID: 300600, userID: 100502})
+	assert.False(t, ok, "the member isn't challenged if it can't be restricted")
+}
+
+func TestManager_challengeNewMembers_Disabled(t *testing.T) {
+	t.Parallel()
+
+	commands := mocks.NewCommandsMock(t)
+
+	commands.EXPECT().
+		Captcha(catalog.ChatRef{ID: 300600}).
+		Return(nil, false)
+
+	m := &Manager{
+		commands: commands,
+	}
+
+	got, err := m.processingMessage(&tgbotapi.Message{
+		Chat:           &tgbotapi.Chat{ID: 300600},
+		NewChatMembers: []tgbotapi.User{{ID: 100501}},
+	})
+	assert.NoError(t, err)
+	assert.Empty(t, got.texts)
+}
+
+func TestManager_processingCallback(t *testing.T) {
+	t.Parallel()
+
+	query := func(from int64, data string) *tgbotapi.CallbackQuery {
+		return &tgbotapi.CallbackQuery{
+			ID:   "query",
+			From: &tgbotapi.User{ID: from},
+			Message: &tgbotapi.Message{
+				MessageID: 50,
+				Chat:      &tgbotapi.Chat{ID: 300600},
+			},
+			Data: data,
+		}
+	}
+
+	tests := []struct {
+		name        string
+		man         func() *Manager
+		query       *tgbotapi.CallbackQuery
+		wantPending bool
+	}{
+		{
+			name: "Correct answer",
+			man: func() *Manager {
+				botProvider := mocks.NewBotProviderMock(t)
+
+				botProvider.EXPECT().
+					DeleteMessage(int64(300600), 50).
+					Return(nil)
+
+				botProvider.EXPECT().
+					RestrictChatMember(int64(300600), int64(100501), memberPermissions(), time.Time{}).
+					Return(nil)
+
+				botProvider.EXPECT().
+					AnswerCallbackQuery("query", "Добро пожаловать!").
+					Return(nil)
+
+				return challengeManager(botProvider)
+			},
+			query:       query(100501, "captcha:100501:0"),
+			wantPending: false,
+		},
+		{
+			name: "Wrong answer",
+			man: func() *Manager {
+				botProvider := mocks.NewBotProviderMock(t)
+
+				botProvider.EXPECT().
+					DeleteMessage(int64(300600), 50).
+					Return(nil)
+
+				botProvider.EXPECT().
+					BanChatMember(int64(300600), int64(100501), time.Time{}, false).
+					Return(nil)
+
+				botProvider.EXPECT().
+					UnbanChatMember(int64(300600), int64(100501)).
+					Return(nil)
+
+				botProvider.EXPECT().
+					AnswerCallbackQuery("query", "Неверный ответ.").
+					Return(errors.New("query is too old"))
+
+				return challengeManager(botProvider)
+			},
+			query:       query(100501, "captcha:100501:2"),
+			wantPending: false,
+		},
+		{
+			name: "Other user",
+			man: func() *Manager {
+				botProvider := mocks.NewBotProviderMock(t)
+
+				botProvider.EXPECT().
+					AnswerCallbackQuery("query", "Это проверка для другого участника.").
+					Return(nil)
+
+				return challengeManager(botProvider)
+			},
+			query:       query(100502, "captcha:100501:0"),
+			wantPending: true,
+		},
+		{
+			name: "Finished challenge",
+			man: func() *Manager {
+				botProvider := mocks.NewBotProviderMock(t)
+
+				botProvider.EXPECT().
+					AnswerCallbackQuery("query", "Проверка уже завершена.").
+					Return(nil)
+
+				return &Manager{
+					bot: botProvider,
+				}
+			},
+			query:       query(100501, "captcha:100501:0"),
+			wantPending: false,
+		},
+		{
+			name: "Unknown button",
+			man: func() *Manager {
+				return challengeManager(mocks.NewBotProviderMock(t))
+			},
+			query:       query(100501, "vote:1"),
+			wantPending: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			m := tt.man()
+
+			err := m.processingUpdate(tgbotapi.Update{CallbackQuery: tt.query})
+			assert.NoError(t, err)
+
+			_, ok := m.takeChallenge(challengeKey{chatID: 300600, userID: 100501})
+			assert.Equal(t, tt.wantPending, ok)
+		})
+	}
+}
+
+func TestManager_expireChallenge(t *testing.T) {
+	t.Parallel()
+
+	botProvider := mocks.NewBotProviderMock(t)
+
+	botProvider.EXPECT().
+		BanChatMember(int64(300600), int64(100501), time.Time{}, false).
+		Return(nil)
+
+	botProvider.EXPECT().
+		UnbanChatMember(int64(300600), int64(100501)).
+		Return(nil)
+
+	botProvider.EXPECT().
+		DeleteMessage(int64(300600), 50).
+		Return(nil)
+
+	m := challengeManager(botProvider)
+
+	key := challengeKey{chatID: 300600, userID: 100501}
+
+	m.expireChallenge(key)
+	// the challenge is already solved or expired
+	m.expireChallenge(key)
+}
+
+func TestManager_expireChallenge_Scheduled(t *testing.T) {
+	t.Parallel()
+
+	botProvider := mocks.NewBotProviderMock(t)
+
+	botProvider.EXPECT().
+		BanChatMember(int64(300600), int64(100501), time.Time{}, false).
+		Return(nil)
+
+	botProvider.EXPECT().
+		UnbanChatMember(int64(300600), int64(100501)).
+		Return(nil)
+
+	m := challengeManager(botProvider)
+	m.challenges[challengeKey{chatID: 300600, userID: 100501}].Scheduled = true
+
+	// the challenge message is deleted by the scheduler
+	m.expireChallenge(challengeKey{chatID: 300600, userID: 100501})
+}
+
+func TestManager_processingCallback_Scheduled(t *testing.T) {
+	t.Parallel()
+
+	botProvider := mocks.NewBotProviderMock(t)
+	scheduler := mocks.NewSchedulerMock(t)
+
+	botProvider.EXPECT().
+		DeleteMessage(int64(300600), 50).
+		Return(nil)
+
+	scheduler.EXPECT().
+		Cancel(int64(300600), 50).
+		Return(errors.New("disk full"))
+
+	botProvider.EXPECT().
+		RestrictChatMember(int64(300600), int64(100501), memberPermissions(), time.Time{}).
+		Return(nil)
+
+	botProvider.EXPECT().
+		AnswerCallbackQuery("query", "Добро пожаловать!").
+		Return(nil)
+
+	m := challengeManager(botProvider)
+	m.scheduler = scheduler
+	m.challenges[challengeKey{chatID: 300600, userID: 100501}].Scheduled = true
+
+	m.processingCallback(&tgbotapi.CallbackQuery{
+		ID:      "query",
+		From:    &tgbotapi.User{ID: 100501},
+		Message: &tgbotapi.Message{MessageID: 50, Chat: &tgbotapi.Chat{ID: 300600}},
+		Data:    "captcha:100501:0",
+	})
+}
+
+func TestManager_restoreChallenges(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 3, 8, 12, 0, 0, 0, time.UTC)
+	overdue := captcha.Pending{ChatID: 300600, UserID: 100501, MessageID: 50, Deadline: now.Add(-time.Minute), Scheduled: true}
+	waiting := captcha.Pending{ChatID: 300600, UserID: 100502, MessageID: 51, Deadline: now.Add(time.Hour), Scheduled: true}
+
+	kicked := make(chan struct{})
+
+	botProvider := mocks.NewBotProviderMock(t)
+
+	botProvider.EXPECT().
+		BanChatMember(int64(300600), int64(100501), time.Time{}, false).
+		Return(nil)
+
+	botProvider.EXPECT().
+		UnbanChatMember(int64(300600), int64(100501)).
+		RunAndReturn(func(int64, int64) error {
+			close(kicked)
+
+			return nil
+		})
+
+	store := mocks.NewChallengesMock(t)
+
+	store.EXPECT().
+		List().
+		Return([]captcha.Pending{overdue, waiting})
+
+	store.EXPECT().
+		Remove(int64(300600), int64(100501)).
+		Return(nil)
+
+	m := &Manager{
+		bot:            botProvider,
+		challengeStore: store,
+		now:            func() time.Time { return now },
+	}
+
+	m.restoreChallenges()
+
+	select {
+	case <-kicked:
+	case <-time.After(time.Second):
+		t.Fatal("the overdue challenge isn't expired")
+	}
+
+	m.challengesMu.Lock()
+	defer m.challengesMu.Unlock()
+
+	pending, ok := m.challenges[challengeKey{chatID: 300600, userID: 100502}]
+	assert.True(t, ok, "the challenge waits for the answer")
+	assert.Equal(t, waiting, pending.Pending)
+	pending.timer.Stop()
+}
+
+func Test_parseCaptchaData(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		data       string
+		wantUserID int64
+		wantOption int
+		wantOk     bool
+	}{
+		{
+			name:       "Success",
+			data:       "captcha:100501:3",
+			wantUserID: 100501,
+			wantOption: 3,
+			wantOk:     true,
+		},
+		{
+			name:   "Other prefix",
+			data:   "vote:100501:3",
+			wantOk: false,
+		},
+		{
+			name:   "No option",
+			data:   "captcha:100501",
+			wantOk: false,
+		},
+		{
+			name:   "Invalid user",
+			data:   "captcha:user:3",
+			wantOk: false,
+		},
+		{
+			name:   "Invalid option",
+			data:   "captcha:100501:x",
+			wantOk: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			userID, option, ok := parseCaptchaData(tt.data)
+			assert.Equal(t, tt.wantUserID, userID)
+			assert.Equal(t, tt.wantOption, option)
+			assert.Equal(t, tt.wantOk, ok)
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package observer
+
+import (
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"go.uber.org/zap"
+
+	"geeksonator/internal/catalog"
+)
+
+// deleteCommand deletes the command message, the failure is only logged.
+func (m *Manager) deleteCommand(message *tgbotapi.Message) {
+	err := m.bot.DeleteMessage(message.Chat.ID, message.MessageID)
+	if err != nil {
+		m.log("Delete command message",
+			zap.Int("messageID", message.MessageID),
+			zap.Error(err),
+		)
+	}
+}
+
+// scheduleCleanup schedules the deletion of the sent reply after the cleanup delay.
+// The failure is only logged, the reply is kept then.
+func (m *Manager) scheduleCleanup(sent tgbotapi.Message, cleanup catalog.Cleanup) {
+	if m.scheduler == nil || cleanup.Reply <= 0 || sent.Chat == nil {
+		return
+	}
+
+	err := m.scheduler.Schedule(sent.Chat.ID, sent.MessageID, m.timeNow().Add(time.Duration(cleanup.Reply)))
+	if err != nil {
+		m.log("Schedule reply deletion",
+			zap.Int("messageID", sent.MessageID),
+			zap.Error(err),
+		)
+	}
+}
+
+// deleteLater deletes the message of the bot after the suggestion lifetime. The deletion is scheduled
+// with the scheduler, so it survives restarts, the timer is used without the scheduler or if it fails.
+func (m *Manager) deleteLater(chatID int64, messageID int) {
+	if m.scheduler != nil {
+		err := m.scheduler.Schedule(chatID, messageID, m.timeNow().Add(m.suggestionLifetime()))
+		if err == nil {
+			return
+		}
+
+		m.log("Schedule message deletion",
+			zap.Int("messageID", messageID),
+			zap.Error(err),
+		)
+	}
+
+	time.AfterFunc(m.suggestionLifetime(), func() {
+		if err := m.bot.DeleteMessage(chatID, messageID); err != nil {
+			m.log("Delete message",
+				zap.Int("messageID", messageID),
+				zap.Error(err),
+			)
+		}
+	})
+}
@@ -0,0 +1,136 @@
+package observer
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/stretchr/testify/assert"
+
+	"geeksonator/internal/catalog"
+	"geeksonator/internal/observer/mocks"
+)
+
+func TestManager_processingUpdate_Cleanup(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 3, 8, 12, 0, 0, 0, time.UTC)
+	chatCleanup := &catalog.Cleanup{Command: true, Reply: catalog.Duration(10 * time.Minute)}
+
+	tests := []struct {
+		name string
+		cmd  *catalog.Command
+		man  func(commands *mocks.CommandsMock) *Manager
+	}{
+		{
+			name: "Chat cleanup",
+			cmd:  &catalog.Command{Name: "lara", Response: laraTxt},
+			man: func(commands *mocks.CommandsMock) *Manager {
+				botProvider := mocks.NewBotProviderMock(t)
+
+				botProvider.EXPECT().
+					DeleteMessage(int64(300600), 42).
+					Return(nil)
+
+				botProvider.EXPECT().
+					NewMessage(int64(300600), laraTxt).
+					Return(tgbotapi.MessageConfig{Text: laraTxt})
+
+				botProvider.EXPECT().
+					Send(tgbotapi.MessageConfig{Text: laraTxt, ParseMode: "html", DisableWebPagePreview: true}).
+					Return(tgbotapi.Message{MessageID: 43, Chat: &tgbotapi.Chat{ID: 300600}}, nil)
+
+				scheduler := mocks.NewSchedulerMock(t)
+
+				scheduler.EXPECT().
+					Schedule(int64(300600), 43, now.Add(10*time.Minute)).
+					Return(errors.New("disk full"))
+
+				return &Manager{
+					bot:       botProvider,
+					cache:     adminsCache(t, 100500),
+					commands:  commands,
+					scheduler: scheduler,
+					now:       func() time.Time { return now },
+				}
+			},
+		},
+		{
+			name: "Command keeps the messages",
+			cmd: &catalog.Command{
+				Name:     "lara",
+				Response: laraTxt,
+				Cleanup:  &catalog.Cleanup{},
+			},
+			man: func(commands *mocks.CommandsMock) *Manager {
+				botProvider := mocks.NewBotProviderMock(t)
+
+				botProvider.EXPECT().
+					NewMessage(int64(300600), laraTxt).
+					Return(tgbotapi.MessageConfig{Text: laraTxt})
+
+				botProvider.EXPECT().
+					Send(tgbotapi.MessageConfig{Text: laraTxt, ParseMode: "html", DisableWebPagePreview: true}).
+					Return(tgbotapi.Message{MessageID: 43, Chat: &tgbotapi.Chat{ID: 300600}}, nil)
+
+				return &Manager{
+					bot:       botProvider,
+					cache:     adminsCache(t, 100500),
+					commands:  commands,
+					scheduler: mocks.NewSchedulerMock(t),
+				}
+			},
+		},
+		{
+			name: "Without scheduler",
+			cmd:  &catalog.Command{Name: "lara", Response: laraTxt},
+			man: func(commands *mocks.CommandsMock) *Manager {
+				botProvider := mocks.NewBotProviderMock(t)
+
+				botProvider.EXPECT().
+					DeleteMessage(int64(300600), 42).
+					Return(errors.New("message can't be deleted"))
+
+				botProvider.EXPECT().
+					NewMessage(int64(300600), laraTxt).
+					Return(tgbotapi.MessageConfig{Text: laraTxt})
+
+				botProvider.EXPECT().
+					Send(tgbotapi.MessageConfig{Text: laraTxt, ParseMode: "html", DisableWebPagePreview: true}).
+					Return(tgbotapi.Message{MessageID: 43, Chat: &tgbotapi.Chat{ID: 300600}}, nil)
+
+				return &Manager{
+					bot:      botProvider,
+					cache:    adminsCache(t, 100500),
+					commands: commands,
+				}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			commands := mocks.NewCommandsMock(t)
+
+			commands.EXPECT().
+				Lookup(catalog.ChatRef{ID: 300600}, "lara").
+				Return(tt.cmd, true)
+
+			commands.EXPECT().
+				Chat(catalog.ChatRef{ID: 300600}).
+				Return(&catalog.Chat{ID: 300600, Cleanup: chatCleanup}, true)
+
+			err := tt.man(commands).processingUpdate(tgbotapi.Update{
+				Message: &tgbotapi.Message{
+					MessageID: 42,
+					Chat:      &tgbotapi.Chat{ID: 300600},
+					From:      &tgbotapi.User{ID: 100500},
+					Text:      laraCmd,
+				},
+			})
+			assert.NoError(t, err)
+		})
+	}
+}
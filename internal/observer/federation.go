@@ -0,0 +1,254 @@
+package observer
+
+import (
+	"fmt"
+	"html"
+	"strconv"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"go.uber.org/zap"
+
+	"geeksonator/internal/audit"
+	"geeksonator/internal/catalog"
+	"geeksonator/internal/federation"
+)
+
+const (
+	// federationDisabledTxt is the answer to the federation commands if the federation bans storage isn't set.
+	federationDisabledTxt = "Федерация отключена."
+	// notFederatedTxt is the answer to the federation commands in the chat outside of the federation.
+	notFederatedTxt = "Чат не входит в федерацию."
+	// funbanUsageTxt is the answer to /funban without the reply and the user ID.
+	funbanUsageTxt = "Ответьте на сообщение пользователя или укажите его ID: <code>/funban 123456789</code>."
+)
+
+// fban bans the author of the replied message in all the federation chats and stores the ban,
+// so the user is banned on joining any of them later.
+func (m *Manager) fban(req *request) ([]string, error) {
+	if m.federation == nil {
+		return []string{federationDisabledTxt}, nil
+	}
+
+	chat := req.message.Chat
+
+	cfg, ok := m.commands.Federation(chatRef(chat))
+	if !ok {
+		return []string{notFederatedTxt}, nil
+	}
+
+	target, refusal, err := m.moderationTarget(req)
+	if err != nil {
+		return nil, fmt.Errorf("m.moderationTarget: %v", err)
+	}
+
+	if target == nil {
+		return []string{refusal}, nil
+	}
+
+	reason := strings.Join(strings.Fields(req.args), " ")
+
+	err = m.federation.Ban(target.ID, federation.Ban{
+		Name:   userName(target),
+		Reason: reason,
+		By:     req.message.From.ID,
+		ChatID: chat.ID,
+		Date:   m.timeNow(),
+	})
+	if err != nil {
+		m.log("Add federation ban",
+			zap.Int64("userID", target.ID),
+			zap.Error(err),
+		)
+
+		return []string{"Не удалось сохранить бан в федерации: " + html.EscapeString(err.Error())}, nil
+	}
+
+	failed := m.applyFederation(cfg, target.ID, "Federation ban", func(chatID int64) error {
+		if err := m.bot.BanChatMember(chatID, target.ID, time.Time{}, false); err != nil {
+			return err
+		}
+
+		m.forgetMember(chatID, target.ID)
+
+		return nil
+	})
+
+	e := m.auditEvent(audit.ActionFban, chat, req.message.ReplyToMessage, req.message.From)
+	e.Reason = reason
+	m.audit(chat, e)
+
+	text := "Пользователь " + mention(target) + " забанен во всех чатах федерации." + reasonLine(reason)
+
+	return []string{text + federationFailures(failed)}, nil
+}
+
+// funban lifts the federation ban of the author of the replied message or of the user with the ID from the arguments
+// and unbans the user in all the federation chats.
+func (m *Manager) funban(req *request) ([]string, error) {
+	if m.federation == nil {
+		return []string{federationDisabledTxt}, nil
+	}
+
+	chat := req.message.Chat
+
+	cfg, ok := m.commands.Federation(chatRef(chat))
+	if !ok {
+		return []string{notFederatedTxt}, nil
+	}
+
+	userID, ok := funbanTarget(req)
+	if !ok {
+		return []string{funbanUsageTxt}, nil
+	}
+
+	ban, ok := m.federation.Get(userID)
+	if !ok {
+		return []string{"Пользователь " + strconv.FormatInt(userID, 10) + " не забанен в федерации."}, nil
+	}
+
+	if _, err := m.federation.Unban(userID); err != nil {
+		m.log("Remove federation ban",
+			zap.Int64("userID", userID),
+			zap.Error(err),
+		)
+
+		return []string{"Не удалось снять бан в федерации: " + html.EscapeString(err.Error())}, nil
+	}
+
+	failed := m.applyFederation(cfg, userID, "Federation unban", func(chatID int64) error {
+		return m.bot.UnbanChatMember(chatID, userID)
+	})
+
+	e := m.auditEvent(audit.ActionFunban, chat, nil, req.message.From)
+	e.TargetID, e.Target = userID, ban.Name
+	m.audit(chat, e)
+
+	text := "Пользователь " + userLink(userID, ban.Name) + " разбанен во всех чатах федерации."
+
+	return []string{text + federationFailures(failed)}, nil
+}
+
+// funbanTarget returns the author of the replied message or the user ID from the arguments.
+func funbanTarget(req *request) (int64, bool) {
+	if reply := req.message.ReplyToMessage; reply != nil && reply.From != nil {
+		return reply.From.ID, true
+	}
+
+	userID, err := strconv.ParseInt(strings.TrimSpace(req.args), 10, 64)
+	if err != nil || userID <= 0 {
+		return 0, false
+	}
+
+	return userID, true
+}
+
+// applyFederation applies the action to the user in all the federation chats and returns the chats it failed in,
+// e.g. the bot has no rights to ban there. The failures are logged.
+func (m *Manager) applyFederation(cfg *catalog.Federation, userID int64, name string, apply func(chatID int64) error) []int64 {
+	var failed []int64
+
+	for _, chatID := range cfg.Chats {
+		if err := apply(chatID); err != nil {
+			m.log(name,
+				zap.Int64("chatID", chatID),
+				zap.Int64("userID", userID),
+				zap.Error(err),
+			)
+
+			failed = append(failed, chatID)
+		}
+	}
+
+	return failed
+}
+
+// federationFailures returns the line with the chats the federation action failed in, empty if there're none.
+func federationFailures(failed []int64) string {
+	if len(failed) == 0 {
+		return ""
+	}
+
+	ids := make([]string, 0, len(failed))
+	for _, chatID := range failed {
+		ids = append(ids, strconv.FormatInt(chatID, 10))
+	}
+
+	return "\nНе удалось применить в чатах: " + strings.Join(ids, ", ") + ", проверьте права бота."
+}
+
+// declineFederated declines the request of the user banned in the federation of the chat.
+// The chat isn't notified, the decline is only logged to the audit log.
+func (m *Manager) declineFederated(req *tgbotapi.ChatJoinRequest) {
+	if m.federation == nil {
+		return
+	}
+
+	if _, ok := m.commands.Federation(chatRef(&req.Chat)); !ok {
+		return
+	}
+
+	if _, ok := m.federation.Get(req.From.ID); !ok {
+		return
+	}
+
+	err := m.bot.DeclineChatJoinRequest(req.Chat.ID, req.From.ID)
+	if err != nil {
+		m.log("Decline federation join request",
+			zap.Int64("userID", req.From.ID),
+			zap.Error(err),
+		)
+
+		return
+	}
+
+	e := m.auditEvent(audit.ActionBan, &req.Chat, nil, nil)
+	e.TargetID, e.Target = req.From.ID, userName(&req.From)
+	e.Reason = "заявка на вступление отклонена, бан в федерации"
+	m.audit(&req.Chat, e)
+}
+
+// banFederated bans the new members banned in the federation and removes them from the new members of the message,
+// so they're neither tracked nor challenged. The members are kept if the ban fails.
+func (m *Manager) banFederated(message *tgbotapi.Message) {
+	if m.federation == nil {
+		return
+	}
+
+	if _, ok := m.commands.Federation(chatRef(message.Chat)); !ok {
+		return
+	}
+
+	joined := make([]tgbotapi.User, 0, len(message.NewChatMembers))
+
+	for _, member := range message.NewChatMembers {
+		ban, ok := m.federation.Get(member.ID)
+		if !ok {
+			joined = append(joined, member)
+
+			continue
+		}
+
+		err := m.bot.BanChatMember(message.Chat.ID, member.ID, time.Time{}, false)
+		if err != nil {
+			m.log("Ban federation member",
+				zap.Int64("userID", member.ID),
+				zap.Error(err),
+			)
+
+			joined = append(joined, member)
+
+			continue
+		}
+
+		e := m.auditEvent(audit.ActionBan, message.Chat, nil, nil)
+		e.TargetID, e.Target = member.ID, userName(&member)
+		e.Reason = "бан в федерации"
+		m.audit(message.Chat, e)
+
+		m.postNotice(message.Chat.ID, "Пользователь "+mention(&member)+" забанен в федерации и удалён из чата."+reasonLine(ban.Reason))
+	}
+
+	message.NewChatMembers = joined
+}
@@ -0,0 +1,340 @@
+package observer
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/stretchr/testify/assert"
+
+	"geeksonator/internal/audit"
+	"geeksonator/internal/blocklist"
+	"geeksonator/internal/catalog"
+	"geeksonator/internal/federation"
+	"geeksonator/internal/observer/mocks"
+)
+
+// federationConfig returns the commands of the chat in the federation of the tests.
+func federationConfig(t *testing.T) *mocks.CommandsMock {
+	t.Helper()
+
+	commands := mocks.NewCommandsMock(t)
+
+	commands.EXPECT().
+		Federation(catalog.ChatRef{ID: 300600}).
+		Return(&catalog.Federation{Chats: []int64{300600, 300601}}, true)
+
+	return commands
+}
+
+func TestManager_fban(t *testing.T) {
+	t.Parallel()
+
+	spammer := &tgbotapi.User{ID: 100501, UserName: "spammer"}
+	ban := federation.Ban{
+		Name:   "@spammer",
+		Reason: "spam",
+		By:     100500,
+		ChatID: 300600,
+		Date:   moderationNow,
+	}
+
+	tests := []struct {
+		name string
+		man  func() *Manager
+		want []string
+	}{
+		{
+			name: "Banned in all the chats",
+			man: func() *Manager {
+				botProvider := mocks.NewBotProviderMock(t)
+				store := mocks.NewFederationMock(t)
+
+				store.EXPECT().
+					Ban(int64(100501), ban).
+					Return(nil)
+
+				botProvider.EXPECT().
+					BanChatMember(int64(300600), int64(100501), time.Time{}, false).
+					Return(nil)
+				botProvider.EXPECT().
+					BanChatMember(int64(300601), int64(100501), time.Time{}, false).
+					Return(errors.New("not enough rights"))
+
+				return &Manager{
+					bot:        botProvider,
+					cache:      adminsCache(t, 100500),
+					commands:   federationConfig(t),
+					federation: store,
+					now:        func() time.Time { return moderationNow },
+				}
+			},
+			want: []string{`Пользователь @spammer забанен во всех чатах федерации.
+Причина: spam
+Не удалось применить в чатах: 300601, проверьте права бота.`},
+		},
+		{
+			name: "Storage failed",
+			man: func() *Manager {
+				store := mocks.NewFederationMock(t)
+
+				store.EXPECT().
+					Ban(int64(100501), ban).
+					Return(errors.New("disk full"))
+
+				return &Manager{
+					cache:      adminsCache(t, 100500),
+					commands:   federationConfig(t),
+					federation: store,
+					now:        func() time.Time { return moderationNow },
+				}
+			},
+			want: []string{"Не удалось сохранить бан в федерации: disk full"},
+		},
+		{
+			name: "Not federated",
+			man: func() *Manager {
+				commands := mocks.NewCommandsMock(t)
+
+				commands.EXPECT().
+					Federation(catalog.ChatRef{ID: 300600}).
+					Return(nil, false)
+
+				return &Manager{
+					commands:   commands,
+					federation: mocks.NewFederationMock(t),
+				}
+			},
+			want: []string{notFederatedTxt},
+		},
+		{
+			name: "Disabled",
+			man: func() *Manager {
+				return &Manager{}
+			},
+			want: []string{federationDisabledTxt},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := tt.man().fban(moderationRequest(catalog.HandlerFban, "spam", spammer))
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestManager_funban(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		args   string
+		target *tgbotapi.User
+		banned bool
+		want   []string
+	}{
+		{
+			name:   "Reply",
+			target: &tgbotapi.User{ID: 100501, UserName: "spammer"},
+			banned: true,
+			want:   []string{`Пользователь <a href="tg://user?id=100501">@spammer</a> разбанен во всех чатах федерации.`},
+		},
+		{
+			name:   "User ID",
+			args:   " 100501 ",
+			banned: true,
+			want:   []string{`Пользователь <a href="tg://user?id=100501">@spammer</a> разбанен во всех чатах федерации.`},
+		},
+		{
+			name: "Not banned",
+			args: "100501",
+			want: []string{"Пользователь 100501 не забанен в федерации."},
+		},
+		{
+			name: "No target",
+			args: "spammer",
+			want: []string{funbanUsageTxt},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			botProvider := mocks.NewBotProviderMock(t)
+			store := mocks.NewFederationMock(t)
+
+			if tt.want[0] != funbanUsageTxt {
+				store.EXPECT().
+					Get(int64(100501)).
+					Return(federation.Ban{Name: "@spammer"}, tt.banned)
+			}
+
+			if tt.banned {
+				store.EXPECT().
+					Unban(int64(100501)).
+					Return(true, nil)
+
+				botProvider.EXPECT().
+					UnbanChatMember(int64(300600), int64(100501)).
+					Return(nil)
+				botProvider.EXPECT().
+					UnbanChatMember(int64(300601), int64(100501)).
+					Return(nil)
+			}
+
+			m := &Manager{
+				bot:        botProvider,
+				commands:   federationConfig(t),
+				federation: store,
+			}
+
+			got, err := m.funban(moderationRequest(catalog.HandlerFunban, tt.args, tt.target))
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestManager_processingMessage_FederationJoin(t *testing.T) {
+	t.Parallel()
+
+	botProvider := mocks.NewBotProviderMock(t)
+	commands := federationConfig(t)
+	store := mocks.NewFederationMock(t)
+
+	store.EXPECT().
+		Get(int64(100501)).
+		Return(federation.Ban{Reason: "spam"}, true)
+	store.EXPECT().
+		Get(int64(100502)).
+		Return(federation.Ban{}, false)
+
+	botProvider.EXPECT().
+		BanChatMember(int64(300600), int64(100501), time.Time{}, false).
+		Return(nil)
+
+	notice := tgbotapi.NewMessage(300600, "Пользователь @spammer забанен в федерации и удалён из чата.\nПричина: spam")
+
+	botProvider.EXPECT().
+		NewMessage(int64(300600), notice.Text).
+		Return(notice)
+
+	notice.ParseMode = "html"
+
+	botProvider.EXPECT().
+		Send(notice).
+		Return(tgbotapi.Message{}, nil)
+
+	// only the member who isn't banned is challenged
+	commands.EXPECT().
+		Captcha(catalog.ChatRef{ID: 300600}).
+		Return(nil, false)
+
+	m := &Manager{
+		bot:        botProvider,
+		commands:   commands,
+		federation: store,
+	}
+
+	message := &tgbotapi.Message{
+		Chat: &tgbotapi.Chat{ID: 300600},
+		NewChatMembers: []tgbotapi.User{
+			{ID: 100501, UserName: "spammer"},
+			{ID: 100502, UserName: "newbie"},
+		},
+	}
+
+	got, err := m.processingMessage(message)
+	assert.NoError(t, err)
+	assert.Empty(t, got.texts)
+	assert.Equal(t, []tgbotapi.User{{ID: 100502, UserName: "newbie"}}, message.NewChatMembers)
+}
+
+func TestManager_processingUpdate_FederationJoinRequest(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		banned     bool
+		declineErr error
+		wantAudit  bool
+	}{
+		{
+			name:      "Banned user is declined",
+			banned:    true,
+			wantAudit: true,
+		},
+		{
+			name:       "Decline failed",
+			banned:     true,
+			declineErr: errors.New("not enough rights"),
+		},
+		{
+			name: "Other users are left to the admins",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			botProvider := mocks.NewBotProviderMock(t)
+			commands := federationConfig(t)
+			list := mocks.NewBlocklistMock(t)
+			store := mocks.NewFederationMock(t)
+			auditor := mocks.NewAuditorMock(t)
+
+			list.EXPECT().
+				Get(int64(100501)).
+				Return(blocklist.Entry{}, false)
+
+			store.EXPECT().
+				Get(int64(100501)).
+				Return(federation.Ban{Reason: "spam"}, tt.banned)
+
+			if tt.banned {
+				botProvider.EXPECT().
+					DeclineChatJoinRequest(int64(300600), int64(100501)).
+					Return(tt.declineErr)
+			}
+
+			if tt.wantAudit {
+				auditor.EXPECT().
+					Write(audit.Event{
+						Date:     moderationNow,
+						Action:   audit.ActionBan,
+						ChatID:   300600,
+						Chat:     "Geeks",
+						TargetID: 100501,
+						Target:   "@spammer",
+						Reason:   "заявка на вступление отклонена, бан в федерации",
+					}).
+					Return(nil)
+
+				commands.EXPECT().
+					Audit(catalog.ChatRef{ID: 300600}).
+					Return(&catalog.Audit{})
+			}
+
+			m := &Manager{
+				bot:        botProvider,
+				commands:   commands,
+				blocklist:  list,
+				federation: store,
+				auditor:    auditor,
+				now:        func() time.Time { return moderationNow },
+			}
+
+			err := m.processingUpdate(tgbotapi.Update{
+				ChatJoinRequest: &tgbotapi.ChatJoinRequest{
+					Chat: tgbotapi.Chat{ID: 300600, Title: "Geeks"},
+					From: tgbotapi.User{ID: 100501, UserName: "spammer"},
+				},
+			})
+			assert.NoError(t, err)
+		})
+	}
+}
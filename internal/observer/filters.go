@@ -0,0 +1,306 @@
+package observer
+
+import (
+	"html"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"go.uber.org/zap"
+
+	"geeksonator/internal/audit"
+	"geeksonator/internal/filters"
+	"geeksonator/pkg/duration"
+)
+
+const (
+	// filtersDisabledTxt is the answer to /filters if the filters storage isn't set.
+	filtersDisabledTxt = "Фильтры отключены."
+	// filtersUsageTxt is the answer to /filters with unknown arguments.
+	filtersUsageTxt = "Использование: <code>/filters</code>, <code>/filters add действие [срок] слово, фраза</code>, " +
+		"<code>/filters add действие [срок] /regexp/</code> или <code>/filters del номер</code>.\n" +
+		"Действия: delete, warn, mute, ban, report."
+	// reportQuoteLength is the maximum length of the message quote in the report to the admins.
+	reportQuoteLength = 200
+)
+
+// filtersCmd lists, adds or removes the filter rules of the chat:
+// /filters, /filters add <action> [duration] <words or /regexp/>, /filters del <id>.
+func (m *Manager) filtersCmd(req *request) ([]string, error) {
+	if m.filters == nil {
+		return []string{filtersDisabledTxt}, nil
+	}
+
+	sub, rest := nextField(req.args)
+
+	switch strings.ToLower(sub) {
+	case "", "list":
+		return []string{m.listFilters(req.message.Chat.ID)}, nil
+	case "add":
+		return []string{m.addFilter(req.message.Chat.ID, rest)}, nil
+	case "del":
+		return []string{m.removeFilter(req.message.Chat.ID, rest)}, nil
+	default:
+		return []string{filtersUsageTxt}, nil
+	}
+}
+
+// listFilters returns the list of the chat rules.
+func (m *Manager) listFilters(chatID int64) string {
+	rules := m.filters.Rules(chatID)
+	if len(rules) == 0 {
+		return "Фильтров в чате нет."
+	}
+
+	var b strings.Builder
+
+	b.WriteString("Фильтры чата:")
+
+	for _, rule := range rules {
+		b.WriteString("\n" + describeRule(rule))
+	}
+
+	return b.String()
+}
+
+// addFilter adds the rule of the arguments: <action> [duration] <words or /regexp/>.
+func (m *Manager) addFilter(chatID int64, args string) string {
+	action, rest := nextField(args)
+
+	var d time.Duration
+	if field, pattern := nextField(rest); field != "" {
+		if parsed, err := duration.Parse(field); err == nil {
+			if parsed < minRestrictDuration || parsed > maxRestrictDuration {
+				return durationRangeTxt
+			}
+
+			d, rest = parsed, pattern
+		}
+	}
+
+	rule, err := filters.NewRule(filters.Action(strings.ToLower(action)), d, rest)
+	if err != nil {
+		return "Неверный фильтр: " + html.EscapeString(err.Error()) + "\n" + filtersUsageTxt
+	}
+
+	rule, err = m.filters.Add(chatID, rule)
+	if err != nil {
+		m.log("Add filter",
+			zap.Int64("chatID", chatID),
+			zap.Error(err),
+		)
+
+		return "Не удалось сохранить фильтр: " + html.EscapeString(err.Error())
+	}
+
+	return "Фильтр добавлен:\n" + describeRule(rule)
+}
+
+// removeFilter removes the rule by the ID from the arguments.
+func (m *Manager) removeFilter(chatID int64, args string) string {
+	id, err := strconv.Atoi(strings.TrimPrefix(strings.TrimSpace(args), "#"))
+	if err != nil {
+		return filtersUsageTxt
+	}
+
+	ok, err := m.filters.Remove(chatID, id)
+	if err != nil {
+		m.log("Remove filter",
+			zap.Int64("chatID", chatID),
+			zap.Error(err),
+		)
+
+		return "Не удалось удалить фильтр: " + html.EscapeString(err.Error())
+	}
+
+	if !ok {
+		return "Фильтр #" + strconv.Itoa(id) + " не найден."
+	}
+
+	return "Фильтр #" + strconv.Itoa(id) + " удалён."
+}
+
+// checkFilters applies the first chat rule matching the message text or caption.
+// Administrators aren't filtered, the message is kept if the admins can't be fetched.
+// It returns true if the message is filtered.
+func (m *Manager) checkFilters(message *tgbotapi.Message) bool {
+	if m.filters == nil || message.From == nil || message.Chat == nil || message.Chat.IsPrivate() {
+		return false
+	}
+
+	text := message.Text
+	if text == "" {
+		text = message.Caption
+	}
+
+	if text == "" {
+		return false
+	}
+
+	rule, ok := m.filters.Match(message.Chat.ID, text)
+	if !ok {
+		return false
+	}
+
+	admins, err := m.getAdmins(message.Chat.ChatConfig())
+	if err != nil {
+		m.log("Get chat admins",
+			zap.Int64("chatID", message.Chat.ID),
+			zap.Error(err),
+		)
+
+		return false
+	}
+
+	if authorIsAdmin(admins, message.From.ID) {
+		return false
+	}
+
+	m.applyFilter(message, rule)
+
+	return true
+}
+
+// applyFilter applies the action of the rule to the message and its author.
+// The reported message is kept and reported like /report, the message is deleted for the other actions.
+func (m *Manager) applyFilter(message *tgbotapi.Message, rule filters.Rule) {
+	reason := "фильтр #" + strconv.Itoa(rule.ID)
+
+	e := m.auditEvent(audit.ActionFilter, message.Chat, message, nil)
+	e.Reason = reason + ", " + string(rule.Action)
+	e.Content = content(message)
+	m.audit(message.Chat, e)
+
+	if rule.Action == filters.ActionReport {
+		_, err := m.sendReport(message.Chat, message, message.From, "Сообщение попало под фильтр #"+strconv.Itoa(rule.ID)+".")
+		if err != nil {
+			m.log("Report filtered message",
+				zap.Int("messageID", message.MessageID),
+				zap.Error(err),
+			)
+		}
+
+		return
+	}
+
+	err := m.bot.DeleteMessage(message.Chat.ID, message.MessageID)
+	if err != nil {
+		m.log("Delete filtered message",
+			zap.Int("messageID", message.MessageID),
+			zap.Error(err),
+		)
+	}
+
+	switch rule.Action {
+	case filters.ActionWarn:
+		if m.warnings != nil {
+			m.postNotice(message.Chat.ID, m.warnMember(message.Chat, message, nil, reason))
+		}
+	case filters.ActionMute:
+		if text, ok := m.muteMember(message.Chat.ID, message.From, rule.Duration); ok {
+			m.auditAction(message, audit.ActionMute, rule.Duration, reason)
+			m.postNotice(message.Chat.ID, text+reasonLine(reason))
+		}
+	case filters.ActionBan:
+		if text, ok := m.banMember(message.Chat.ID, message.From, rule.Duration, false); ok {
+			m.auditAction(message, audit.ActionBan, rule.Duration, reason)
+			m.postNotice(message.Chat.ID, text+reasonLine(reason))
+		}
+	case filters.ActionDelete, filters.ActionReport:
+	}
+}
+
+// shorten cuts the text to the length in characters with the ellipsis at the end.
+func shorten(text string, length int) string {
+	runes := []rune(text)
+	if len(runes) <= length {
+		return text
+	}
+
+	return string(append(runes[:length-1], '…'))
+}
+
+// reportText returns the report about the message of the chat with the title, the author,
+// the quote of the message and the link to it.
+func reportText(chat *tgbotapi.Chat, message *tgbotapi.Message, title string) string {
+	quote := shorten(message.Text+message.Caption, reportQuoteLength)
+
+	text := title + "\nЧат: " + html.EscapeString(chat.Title)
+
+	if message.From != nil {
+		text += "\nАвтор: " + mention(message.From)
+	}
+
+	if quote != "" {
+		text += "\n<blockquote>" + html.EscapeString(quote) + "</blockquote>"
+	}
+
+	if link := messageLink(chat, message.MessageID); link != "" {
+		text += "\n" + link
+	}
+
+	return text
+}
+
+// messageLink returns the link to the message of the public chat or the supergroup, empty for other chats.
+func messageLink(chat *tgbotapi.Chat, messageID int) string {
+	if chat.UserName != "" {
+		return "https://t.me/" + chat.UserName + "/" + strconv.Itoa(messageID)
+	}
+
+	// the supergroup IDs are -100 followed by the internal ID used in the links
+	if id, ok := strings.CutPrefix(strconv.FormatInt(chat.ID, 10), "-100"); ok {
+		return "https://t.me/c/" + id + "/" + strconv.Itoa(messageID)
+	}
+
+	return ""
+}
+
+// describeRule returns the description of the rule, e.g. "#1 мут на 1 день: казино, ставки".
+func describeRule(rule filters.Rule) string {
+	var action string
+
+	switch rule.Action {
+	case filters.ActionDelete:
+		action = "удаление"
+	case filters.ActionWarn:
+		action = "предупреждение"
+	case filters.ActionMute:
+		action = "мут " + ruleTerm(rule.Duration)
+	case filters.ActionBan:
+		action = "бан " + ruleTerm(rule.Duration)
+	case filters.ActionReport:
+		action = "жалоба администраторам"
+	}
+
+	match := html.EscapeString(strings.Join(rule.Words, ", "))
+	if rule.Pattern != "" {
+		match = "<code>/" + html.EscapeString(rule.Pattern) + "/</code>"
+	}
+
+	return "#" + strconv.Itoa(rule.ID) + " " + action + ": " + match
+}
+
+// ruleTerm returns the term of the rule restriction, e.g. "на 1 день" or "навсегда".
+func ruleTerm(d time.Duration) string {
+	if d == 0 {
+		return "навсегда"
+	}
+
+	return "на " + duration.Format(d)
+}
+
+// nextField returns the first space-separated field of the text and the rest of the text
+// with the original spacing, so the regular expressions are kept intact.
+func nextField(text string) (string, string) {
+	text = strings.TrimLeftFunc(text, unicode.IsSpace)
+
+	end := strings.IndexFunc(text, unicode.IsSpace)
+	if end == -1 {
+		return text, ""
+	}
+
+	return text[:end], strings.TrimLeftFunc(text[end:], unicode.IsSpace)
+}
@@ -0,0 +1,479 @@
+package observer
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"geeksonator/internal/catalog"
+	"geeksonator/internal/filters"
+	"geeksonator/internal/observer/mocks"
+	"geeksonator/internal/warnings"
+)
+
+func TestManager_filtersCmd(t *testing.T) {
+	t.Parallel()
+
+	casino := filters.Rule{ID: 1, Action: filters.ActionDelete, Words: []string{"казино", "ставки"}}
+	crypto := filters.Rule{ID: 2, Action: filters.ActionMute, Duration: 24 * time.Hour, Pattern: `crypto\s+<invest>`}
+
+	tests := []struct {
+		name  string
+		store func() Filters
+		args  string
+		want  []string
+	}{
+		{
+			name:  "Disabled",
+			store: func() Filters { return nil },
+			want:  []string{filtersDisabledTxt},
+		},
+		{
+			name: "Empty list",
+			store: func() Filters {
+				store := mocks.NewFiltersMock(t)
+
+				store.EXPECT().
+					Rules(int64(300600)).
+					Return(nil)
+
+				return store
+			},
+			want: []string{"Фильтров в чате нет."},
+		},
+		{
+			name: "List",
+			store: func() Filters {
+				store := mocks.NewFiltersMock(t)
+
+				store.EXPECT().
+					Rules(int64(300600)).
+					Return([]filters.Rule{casino, crypto})
+
+				return store
+			},
+			args: "list",
+			want: []string{"Фильтры чата:\n#1 удаление: казино, ставки\n#2 мут на 1 день: <code>/crypto\\s+&lt;invest&gt;/</code>"},
+		},
+		{
+			name: "Add words",
+			store: func() Filters {
+				store := mocks.NewFiltersMock(t)
+
+				store.EXPECT().
+					Add(int64(300600), mock.MatchedBy(func(rule filters.Rule) bool {
+						return rule.Action == filters.ActionBan && rule.Duration == 0 &&
+							assert.ObjectsAreEqual([]string{"казино", "ставки"}, rule.Words)
+					})).
+					Return(filters.Rule{ID: 3, Action: filters.ActionBan, Words: []string{"казино", "ставки"}}, nil)
+
+				return store
+			},
+			args: "add BAN казино, ставки",
+			want: []string{"Фильтр добавлен:\n#3 бан навсегда: казино, ставки"},
+		},
+		{
+			name: "Add pattern with duration",
+			store: func() Filters {
+				store := mocks.NewFiltersMock(t)
+
+				store.EXPECT().
+					Add(int64(300600), mock.MatchedBy(func(rule filters.Rule) bool {
+						return rule.Action == filters.ActionMute && rule.Duration == 24*time.Hour && rule.Pattern == `crypto\s+<invest>`
+					})).
+					Return(crypto, nil)
+
+				return store
+			},
+			args: `add mute 1d /crypto\s+<invest>/`,
+			want: []string{"Фильтр добавлен:\n#2 мут на 1 день: <code>/crypto\\s+&lt;invest&gt;/</code>"},
+		},
+		{
+			name:  "Add with invalid action",
+			store: func() Filters { return mocks.NewFiltersMock(t) },
+			args:  "add kick казино",
+			want:  []string{"Неверный фильтр: invalid filter rule: unknown action &#34;kick&#34;\n" + filtersUsageTxt},
+		},
+		{
+			name:  "Add with duration out of range",
+			store: func() Filters { return mocks.NewFiltersMock(t) },
+			args:  "add ban 10s казино",
+			want:  []string{durationRangeTxt},
+		},
+		{
+			name: "Add failed",
+			store: func() Filters {
+				store := mocks.NewFiltersMock(t)
+
+				store.EXPECT().
+					Add(int64(300600), mock.Anything).
+					Return(filters.Rule{}, errors.New("disk is full"))
+
+				return store
+			},
+			args: "add delete казино",
+			want: []string{"Не удалось сохранить фильтр: disk is full"},
+		},
+		{
+			name: "Delete",
+			store: func() Filters {
+				store := mocks.NewFiltersMock(t)
+
+				store.EXPECT().
+					Remove(int64(300600), 2).
+					Return(true, nil)
+
+				return store
+			},
+			args: "del #2",
+			want: []string{"Фильтр #2 удалён."},
+		},
+		{
+			name: "Delete unknown",
+			store: func() Filters {
+				store := mocks.NewFiltersMock(t)
+
+				store.EXPECT().
+					Remove(int64(300600), 5).
+					Return(false, nil)
+
+				return store
+			},
+			args: "del 5",
+			want: []string{"Фильтр #5 не найден."},
+		},
+		{
+			name:  "Delete without ID",
+			store: func() Filters { return mocks.NewFiltersMock(t) },
+			args:  "del",
+			want:  []string{filtersUsageTxt},
+		},
+		{
+			name:  "Unknown subcommand",
+			store: func() Filters { return mocks.NewFiltersMock(t) },
+			args:  "clear",
+			want:  []string{filtersUsageTxt},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			m := &Manager{
+				filters: tt.store(),
+			}
+
+			got, err := m.filtersCmd(moderationRequest(catalog.HandlerFilters, tt.args, nil))
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestManager_processingMessage_Filters(t *testing.T) {
+	t.Parallel()
+
+	spammer := &tgbotapi.User{ID: 100501, UserName: "spammer"}
+	since := moderationNow.Add(-30 * 24 * time.Hour)
+
+	tests := []struct {
+		name      string
+		rule      *filters.Rule
+		adminID   int64
+		adminsErr error
+		expect    func(botProvider *mocks.BotProviderMock, store *mocks.WarningsMock)
+	}{
+		{
+			name: "No match",
+		},
+		{
+			name:    "Admin is exempt",
+			rule:    &filters.Rule{ID: 1, Action: filters.ActionBan},
+			adminID: 100501,
+		},
+		{
+			name:      "Kept if the admins can't be fetched",
+			rule:      &filters.Rule{ID: 1, Action: filters.ActionBan},
+			adminsErr: errors.New("Too Many Requests: retry after 5"),
+		},
+		{
+			name: "Delete",
+			rule: &filters.Rule{ID: 1, Action: filters.ActionDelete},
+			expect: func(botProvider *mocks.BotProviderMock, _ *mocks.WarningsMock) {
+				botProvider.EXPECT().
+					DeleteMessage(int64(-1001234567890), 42).
+					Return(nil)
+			},
+		},
+		{
+			name: "Mute",
+			rule: &filters.Rule{ID: 2, Action: filters.ActionMute, Duration: 24 * time.Hour},
+			expect: func(botProvider *mocks.BotProviderMock, _ *mocks.WarningsMock) {
+				text := "Пользователь @spammer не может писать в чат на 1 день, до 09.03.2024 12:00.\nПричина: фильтр #2"
+
+				botProvider.EXPECT().
+					DeleteMessage(int64(-1001234567890), 42).
+					Return(errors.New("message not found"))
+
+				botProvider.EXPECT().
+					RestrictChatMember(int64(-1001234567890), int64(100501), tgbotapi.ChatPermissions{}, moderationNow.Add(24*time.Hour)).
+					Return(nil)
+
+				botProvider.EXPECT().
+					NewMessage(int64(-1001234567890), text).
+					Return(tgbotapi.MessageConfig{Text: text})
+
+				botProvider.EXPECT().
+					Send(tgbotapi.MessageConfig{Text: text, ParseMode: "html"}).
+					Return(tgbotapi.Message{}, nil)
+			},
+		},
+		{
+			name: "Ban failed",
+			rule: &filters.Rule{ID: 3, Action: filters.ActionBan},
+			expect: func(botProvider *mocks.BotProviderMock, _ *mocks.WarningsMock) {
+				botProvider.EXPECT().
+					DeleteMessage(int64(-1001234567890), 42).
+					Return(nil)
+
+				botProvider.EXPECT().
+					BanChatMember(int64(-1001234567890), int64(100501), time.Time{}, false).
+					Return(errors.New("not enough rights"))
+			},
+		},
+		{
+			name: "Warn",
+			rule: &filters.Rule{ID: 4, Action: filters.ActionWarn},
+			expect: func(botProvider *mocks.BotProviderMock, store *mocks.WarningsMock) {
+				text := "Пользователь @spammer получил предупреждение, всего: 1.\nПричина: фильтр #4"
+
+				botProvider.EXPECT().
+					DeleteMessage(int64(-1001234567890), 42).
+					Return(nil)
+
+				store.EXPECT().
+					Add(int64(-1001234567890), int64(100501), warnings.Warning{Reason: "фильтр #4", Date: moderationNow}, since).
+					Return(warningsList(1), nil)
+
+				botProvider.EXPECT().
+					NewMessage(int64(-1001234567890), text).
+					Return(tgbotapi.MessageConfig{Text: text})
+
+				botProvider.EXPECT().
+					Send(tgbotapi.MessageConfig{Text: text, ParseMode: "html"}).
+					Return(tgbotapi.Message{}, nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			botProvider := mocks.NewBotProviderMock(t)
+			cache := mocks.NewCacheMock(t)
+			commands := messageCommands(t)
+			warningsStore := mocks.NewWarningsMock(t)
+			store := mocks.NewFiltersMock(t)
+
+			if tt.rule != nil {
+				store.EXPECT().
+					Match(int64(-1001234567890), "Лучшее казино <3").
+					Return(*tt.rule, true)
+
+				admins := []tgbotapi.ChatMember{
+					{User: &tgbotapi.User{ID: 100500}},
+					{User: &tgbotapi.User{ID: 100502, IsBot: true}},
+				}
+				if tt.adminID != 0 {
+					admins = append(admins, tgbotapi.ChatMember{User: &tgbotapi.User{ID: tt.adminID}})
+				}
+
+				if tt.adminsErr != nil {
+					cache.EXPECT().
+						Get(int64(-1001234567890)).
+						Return(nil, false)
+
+					botProvider.EXPECT().
+						GetChatAdministrators(tgbotapi.ChatConfig{ChatID: -1001234567890}).
+						Return(nil, tt.adminsErr)
+				} else {
+					cache.EXPECT().
+						Get(int64(-1001234567890)).
+						Return(admins, true)
+				}
+			} else {
+				store.EXPECT().
+					Match(int64(-1001234567890), "Лучшее казино <3").
+					Return(filters.Rule{}, false)
+			}
+
+			if tt.rule != nil && tt.rule.Action == filters.ActionWarn {
+				commands.EXPECT().
+					Warnings(catalog.ChatRef{ID: -1001234567890}).
+					Return(&catalog.Warnings{Expire: catalog.Duration(30 * 24 * time.Hour)})
+			}
+
+			if tt.expect != nil {
+				tt.expect(botProvider, warningsStore)
+			}
+
+			m := &Manager{
+				bot:      botProvider,
+				cache:    cache,
+				commands: commands,
+				warnings: warningsStore,
+				filters:  store,
+				now:      func() time.Time { return moderationNow },
+			}
+
+			got, err := m.processingMessage(&tgbotapi.Message{
+				MessageID: 42,
+				From:      spammer,
+				Chat:      &tgbotapi.Chat{ID: -1001234567890, Type: "supergroup", Title: "PHP & Go"},
+				Caption:   "Лучшее казино <3",
+			})
+			assert.NoError(t, err)
+			assert.Empty(t, got.texts)
+		})
+	}
+}
+
+func TestManager_processingMessage_FilterReport(t *testing.T) {
+	t.Parallel()
+
+	text := "Сообщение попало под фильтр #5.\nЧат: PHP &amp; Go\nАвтор: @spammer" +
+		"\n<blockquote>Лучшее казино &lt;3</blockquote>\nhttps://t.me/c/1234567890/42"
+	key := reportKey{chatID: -1001234567890, messageID: 42}
+
+	tests := []struct {
+		name        string
+		logChat     int64
+		subscribers []int64
+		reported    bool
+		// sent are the chats the report is sent to
+		sent     []int64
+		wantSent []notification
+	}{
+		{
+			name:        "Log chat and subscribed admins",
+			logChat:     -100100,
+			subscribers: []int64{100500, 100503},
+			sent:        []int64{-100100, 100500},
+			wantSent:    []notification{{chatID: -100100, messageID: 7}, {chatID: 100500, messageID: 7}},
+		},
+		{
+			name:     "Already reported",
+			reported: true,
+		},
+		{
+			name: "Admins without the subscription aren't notified",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			botProvider := mocks.NewBotProviderMock(t)
+			cache := mocks.NewCacheMock(t)
+			commands := messageCommands(t)
+			store := mocks.NewFiltersMock(t)
+			reports := mocks.NewReportsMock(t)
+
+			store.EXPECT().
+				Match(int64(-1001234567890), "Лучшее казино <3").
+				Return(filters.Rule{ID: 5, Action: filters.ActionReport}, true)
+
+			cache.EXPECT().
+				Get(int64(-1001234567890)).
+				Return([]tgbotapi.ChatMember{{User: &tgbotapi.User{ID: 100500}}}, true)
+
+			if !tt.reported {
+				commands.EXPECT().
+					Reports(catalog.ChatRef{ID: -1001234567890}).
+					Return(&catalog.Reports{LogChat: tt.logChat})
+
+				reports.EXPECT().
+					Subscribers(int64(-1001234567890)).
+					Return(tt.subscribers)
+			}
+
+			for _, chatID := range tt.sent {
+				botProvider.EXPECT().
+					NewMessage(chatID, text).
+					Return(tgbotapi.MessageConfig{BaseChat: tgbotapi.BaseChat{ChatID: chatID}, Text: text})
+
+				botProvider.EXPECT().
+					Send(tgbotapi.MessageConfig{
+						BaseChat:              tgbotapi.BaseChat{ChatID: chatID, ReplyMarkup: reportKeyboard(key)},
+						Text:                  text,
+						ParseMode:             "html",
+						DisableWebPagePreview: true,
+					}).
+					Return(tgbotapi.Message{MessageID: 7}, nil)
+			}
+
+			m := &Manager{
+				bot:      botProvider,
+				cache:    cache,
+				commands: commands,
+				filters:  store,
+				reports:  reports,
+				now:      func() time.Time { return moderationNow },
+			}
+
+			spammer := &tgbotapi.User{ID: 100501, UserName: "spammer"}
+			if tt.reported {
+				m.reserveReport(key, spammer)
+			}
+
+			got, err := m.processingMessage(&tgbotapi.Message{
+				MessageID: 42,
+				From:      spammer,
+				Chat:      &tgbotapi.Chat{ID: -1001234567890, Type: "supergroup", Title: "PHP & Go"},
+				Caption:   "Лучшее казино <3",
+			})
+			assert.NoError(t, err)
+			assert.Empty(t, got.texts)
+
+			if tt.wantSent != nil {
+				assert.Equal(t, tt.wantSent, m.pendingReports[key].notifications)
+			}
+		})
+	}
+}
+
+func TestMessageLink(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		chat *tgbotapi.Chat
+		want string
+	}{
+		{
+			name: "Public chat",
+			chat: &tgbotapi.Chat{ID: -1001234567890, UserName: "phpGeeks"},
+			want: "https://t.me/phpGeeks/42",
+		},
+		{
+			name: "Private supergroup",
+			chat: &tgbotapi.Chat{ID: -1001234567890},
+			want: "https://t.me/c/1234567890/42",
+		},
+		{
+			name: "Basic group",
+			chat: &tgbotapi.Chat{ID: -300600},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, messageLink(tt.chat, 42))
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package observer
+
+import (
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	"geeksonator/internal/audit"
+)
+
+// floodReasonTxt is the reason line of the flood notice.
+const floodReasonTxt = "\nПричина: флуд."
+
+// checkFlood counts the message of the author and mutes the author who exceeded the limit of the chat.
+// Administrators are never muted. It returns true if the author is muted for the flood.
+func (m *Manager) checkFlood(message *tgbotapi.Message) bool {
+	if m.flood == nil || message.From == nil || message.Chat == nil || message.Chat.IsPrivate() {
+		return false
+	}
+
+	cfg, ok := m.commands.Flood(chatRef(message.Chat))
+	if !ok {
+		return false
+	}
+
+	if m.flood.Allow(message.Chat.ID, message.From.ID, m.timeNow(), cfg.Messages, time.Duration(cfg.Window)) {
+		return false
+	}
+
+	if m.exemptAuthor(message) {
+		return false
+	}
+
+	text, ok := m.muteMember(message.Chat.ID, message.From, cfg.MuteValue())
+	if !ok {
+		// the failure is logged by muteMember, the chat isn't flooded with the failures
+		return false
+	}
+
+	m.auditAction(message, audit.ActionFlood, cfg.MuteValue(), "флуд")
+	m.postNotice(message.Chat.ID, text+floodReasonTxt)
+
+	return true
+}
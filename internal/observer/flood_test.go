@@ -0,0 +1,149 @@
+package observer
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/stretchr/testify/assert"
+
+	"geeksonator/internal/catalog"
+	"geeksonator/internal/observer/mocks"
+)
+
+func TestManager_processingMessage_Flood(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 3, 8, 12, 0, 0, 0, time.UTC)
+	cfg := &catalog.Flood{Messages: 15, Window: catalog.Duration(10 * time.Second)}
+	notice := "Пользователь @flooder не может писать в чат на 10 минут, до 08.03.2024 12:10.\nПричина: флуд."
+
+	tests := []struct {
+		name        string
+		cfg         *catalog.Flood
+		allow       bool
+		admin       bool
+		adminsErr   error
+		restrictErr error
+		wantNotice  bool
+	}{
+		{
+			name:  "Within the limit",
+			cfg:   cfg,
+			allow: true,
+		},
+		{
+			name:       "Flooder is muted",
+			cfg:        cfg,
+			wantNotice: true,
+		},
+		{
+			name:  "Admin is exempt",
+			cfg:   cfg,
+			admin: true,
+		},
+		{
+			name:      "Flooder is kept if the admins can't be fetched",
+			cfg:       cfg,
+			adminsErr: errors.New("Too Many Requests: retry after 5"),
+		},
+		{
+			name:        "Mute failed",
+			cfg:         cfg,
+			restrictErr: errors.New("not enough rights"),
+		},
+		{
+			name: "Disabled",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			botProvider := mocks.NewBotProviderMock(t)
+			cache := mocks.NewCacheMock(t)
+			commands := messageCommands(t)
+			limiter := mocks.NewFloodLimiterMock(t)
+
+			commands.EXPECT().
+				Flood(catalog.ChatRef{ID: 300600}).
+				Return(tt.cfg, tt.cfg != nil)
+
+			if tt.cfg != nil {
+				limiter.EXPECT().
+					Allow(int64(300600), int64(100501), now, 15, 10*time.Second).
+					Return(tt.allow)
+			}
+
+			if tt.adminsErr != nil {
+				cache.EXPECT().
+					Get(int64(300600)).
+					Return(nil, false)
+
+				botProvider.EXPECT().
+					GetChatAdministrators(tgbotapi.ChatConfig{ChatID: 300600}).
+					Return(nil, tt.adminsErr)
+			}
+
+			if tt.cfg != nil && !tt.allow && tt.adminsErr == nil {
+				admins := []tgbotapi.ChatMember{{User: &tgbotapi.User{ID: 100500}}}
+				if tt.admin {
+					admins = append(admins, tgbotapi.ChatMember{User: &tgbotapi.User{ID: 100501}})
+				}
+
+				cache.EXPECT().
+					Get(int64(300600)).
+					Return(admins, true)
+			}
+
+			if tt.cfg != nil && !tt.allow && !tt.admin && tt.adminsErr == nil {
+				botProvider.EXPECT().
+					RestrictChatMember(int64(300600), int64(100501), tgbotapi.ChatPermissions{}, now.Add(10*time.Minute)).
+					Return(tt.restrictErr)
+			}
+
+			if tt.wantNotice {
+				botProvider.EXPECT().
+					NewMessage(int64(300600), notice).
+					Return(tgbotapi.MessageConfig{Text: notice})
+
+				botProvider.EXPECT().
+					Send(tgbotapi.MessageConfig{Text: notice, ParseMode: "html"}).
+					Return(tgbotapi.Message{}, nil)
+			}
+
+			m := &Manager{
+				bot:      botProvider,
+				cache:    cache,
+				commands: commands,
+				flood:    limiter,
+				now:      func() time.Time { return now },
+			}
+
+			got, err := m.processingMessage(&tgbotapi.Message{
+				From: &tgbotapi.User{ID: 100501, UserName: "flooder"},
+				Chat: &tgbotapi.Chat{ID: 300600, Type: "supergroup"},
+				Text: "hello",
+			})
+			assert.NoError(t, err)
+			assert.Empty(t, got.texts)
+		})
+	}
+}
+
+func TestManager_processingMessage_FloodPrivate(t *testing.T) {
+	t.Parallel()
+
+	m := &Manager{
+		flood: mocks.NewFloodLimiterMock(t),
+	}
+
+	got, err := m.processingMessage(&tgbotapi.Message{
+		From: &tgbotapi.User{ID: 100501},
+		Chat: &tgbotapi.Chat{ID: 100501, Type: "private"},
+		Text: "hello",
+	})
+	assert.NoError(t, err)
+	assert.Empty(t, got.texts)
+}
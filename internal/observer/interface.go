@@ -1,7 +1,19 @@
 package observer
 
 import (
+	"time"
+
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	"geeksonator/internal/audit"
+	"geeksonator/internal/blocklist"
+	"geeksonator/internal/captcha"
+	"geeksonator/internal/catalog"
+	"geeksonator/internal/federation"
+	"geeksonator/internal/filters"
+	"geeksonator/internal/members"
+	"geeksonator/internal/posts"
+	"geeksonator/internal/warnings"
 )
 
 // BotProvider interface for telegram bot.
@@ -14,6 +26,27 @@ type BotProvider interface {
 
 	// Send sends message.
 	Send(c tgbotapi.Chattable) (tgbotapi.Message, error)
+
+	// DeleteMessage deletes message.
+	DeleteMessage(chatID int64, messageID int) error
+
+	// BanChatMember bans the user in the chat until the date, the zero date bans forever.
+	BanChatMember(chatID, userID int64, untilDate time.Time, revokeMessages bool) error
+
+	// UnbanChatMember unbans the user in the chat.
+	UnbanChatMember(chatID, userID int64) error
+
+	// EditMessageText replaces the text of the message with the HTML text and removes its inline keyboard.
+	EditMessageText(chatID int64, messageID int, text string) error
+
+	// AnswerCallbackQuery answers the callback query of the inline button, the text is shown as a notification.
+	AnswerCallbackQuery(callbackID, text string) error
+
+	// DeclineChatJoinRequest declines the request of the user to join the chat.
+	DeclineChatJoinRequest(chatID, userID int64) error
+
+	// RestrictChatMember sets the permissions of the user in the chat until the date, the zero date restricts forever.
+	RestrictChatMember(chatID, userID int64, permissions tgbotapi.ChatPermissions, untilDate time.Time) error
 }
 
 // Cache interface for cache.
@@ -24,3 +57,165 @@ type Cache interface {
 	// Set adds a value to the cache.
 	Set(key int64, value []tgbotapi.ChatMember) error
 }
+
+// Commands interface for commands catalog.
+type Commands interface {
+	// Lookup returns the command of the chat by its name or alias without the leading slash.
+	Lookup(chat catalog.ChatRef, name string) (*catalog.Command, bool)
+
+	// Help returns the list of the commands of the chat available for the role.
+	Help(chat catalog.ChatRef, role catalog.Role) []string
+
+	// Chat returns the configuration of the chat, false if the chat has no configuration.
+	Chat(chat catalog.ChatRef) (*catalog.Chat, bool)
+
+	// Suggest returns the command name of the chat closest to the unknown name.
+	Suggest(chat catalog.ChatRef, name string, role catalog.Role) (string, bool)
+
+	// Warnings returns the warnings configuration of the chat.
+	Warnings(chat catalog.ChatRef) *catalog.Warnings
+
+	// Captcha returns the captcha configuration of the chat, false if the captcha is disabled.
+	Captcha(chat catalog.ChatRef) (*catalog.Captcha, bool)
+
+	// Flood returns the flood protection configuration of the chat, false if the protection is disabled.
+	Flood(chat catalog.ChatRef) (*catalog.Flood, bool)
+
+	// Newcomers returns the newcomers restrictions of the chat, false if the restrictions are disabled.
+	Newcomers(chat catalog.ChatRef) (*catalog.Newcomers, bool)
+
+	// Reports returns the reports configuration of the chat.
+	Reports(chat catalog.ChatRef) *catalog.Reports
+
+	// Audit returns the audit log configuration of the chat.
+	Audit(chat catalog.ChatRef) *catalog.Audit
+
+	// Federation returns the federation of the chat, false if the chat isn't its member.
+	Federation(chat catalog.ChatRef) (*catalog.Federation, bool)
+
+	// Jobs returns the job board configuration of the chat, false if the chat isn't a job board.
+	Jobs(chat catalog.ChatRef) (*catalog.Jobs, bool)
+
+	// Posts returns the posts rules of the chat, false if the chat has none.
+	Posts(chat catalog.ChatRef) (*catalog.Posts, bool)
+}
+
+// Warnings interface for warnings storage.
+type Warnings interface {
+	// Add adds the warning to the member and returns the member warnings given after the since date.
+	Add(chatID, userID int64, w warnings.Warning, since time.Time) ([]warnings.Warning, error)
+
+	// RemoveLast removes the last member warning given after the since date and returns the rest of them.
+	RemoveLast(chatID, userID int64, since time.Time) ([]warnings.Warning, bool, error)
+
+	// List returns the member warnings given after the since date.
+	List(chatID, userID int64, since time.Time) []warnings.Warning
+
+	// Reset removes all the member warnings.
+	Reset(chatID, userID int64) error
+}
+
+// Scheduler interface for delayed messages deletion.
+type Scheduler interface {
+	// Schedule schedules the deletion of the message.
+	Schedule(chatID int64, messageID int, at time.Time) error
+
+	// Cancel cancels the scheduled deletions of the message.
+	Cancel(chatID int64, messageID int) error
+}
+
+// FloodLimiter interface for messages rate limiter.
+type FloodLimiter interface {
+	// Allow records the message of the user and returns false if the user sent limit messages within the window.
+	Allow(chatID, userID int64, at time.Time, limit int, window time.Duration) bool
+}
+
+// Filters interface for content filter rules storage.
+type Filters interface {
+	// Rules returns the rules of the chat in the order of addition.
+	Rules(chatID int64) []filters.Rule
+
+	// Add adds the rule to the chat and returns it with the assigned ID.
+	Add(chatID int64, rule filters.Rule) (filters.Rule, error)
+
+	// Remove removes the rule of the chat by its ID, false is returned if there's no such rule.
+	Remove(chatID int64, id int) (bool, error)
+
+	// Match returns the first rule of the chat matching the text.
+	Match(chatID int64, text string) (filters.Rule, bool)
+}
+
+// Challenges interface for pending captcha challenges storage.
+type Challenges interface {
+	// Add saves the pending challenge, the previous challenge of the member in the chat is replaced.
+	Add(p captcha.Pending) error
+
+	// Remove removes the pending challenge of the member in the chat.
+	Remove(chatID, userID int64) error
+
+	// List returns the pending challenges in the order of the deadlines.
+	List() []captcha.Pending
+}
+
+// Members interface for new chat members storage.
+type Members interface {
+	// Join starts tracking the member who joined the chat at the date.
+	Join(chatID, userID int64, at time.Time) error
+
+	// Get returns the tracked member, false if the member isn't tracked.
+	Get(chatID, userID int64) (members.Member, bool)
+
+	// CountMessage increments the number of the messages of the tracked member.
+	CountMessage(chatID, userID int64) error
+
+	// MarkWarned marks the tracked member as warned about the restrictions.
+	MarkWarned(chatID, userID int64) error
+
+	// Remove stops tracking the member.
+	Remove(chatID, userID int64) error
+}
+
+// Reports interface for reports subscriptions storage.
+type Reports interface {
+	// Subscribe subscribes the admin to the reports of the chat.
+	Subscribe(chatID, userID int64) error
+
+	// Unsubscribe unsubscribes the admin from the reports of the chat.
+	Unsubscribe(chatID, userID int64) error
+
+	// Subscribers returns the admins subscribed to the reports of the chat.
+	Subscribers(chatID int64) []int64
+}
+
+// Auditor interface for moderation audit log.
+type Auditor interface {
+	// Write appends the event to the log.
+	Write(e audit.Event) error
+}
+
+// Federation interface for federation bans storage.
+type Federation interface {
+	// Ban adds the ban of the user, the previous ban of the user is replaced.
+	Ban(userID int64, ban federation.Ban) error
+
+	// Unban removes the ban of the user, false is returned if the user isn't banned.
+	Unban(userID int64) (bool, error)
+
+	// Get returns the ban of the user, false if the user isn't banned.
+	Get(userID int64) (federation.Ban, bool)
+}
+
+// Blocklist interface for the blocklist of the known spam accounts.
+type Blocklist interface {
+	// Get returns the entry of the user, false if the user isn't blocked.
+	Get(userID int64) (blocklist.Entry, bool)
+}
+
+// Posts interface for the recent posts storage.
+type Posts interface {
+	// Add adds the post to the chat, the posts of the chat published before the since date are dropped.
+	Add(chatID int64, post posts.Post, since time.Time) error
+
+	// Recent returns the posts of the chat published after the since date in the order of publication.
+	Recent(chatID int64, since time.Time) []posts.Post
+}
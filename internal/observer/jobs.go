@@ -0,0 +1,165 @@
+package observer
+
+import (
+	"errors"
+	"html"
+	"strconv"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"go.uber.org/zap"
+
+	"geeksonator/internal/audit"
+	"geeksonator/internal/catalog"
+	"geeksonator/internal/jobs"
+)
+
+// jobFieldNames are the names of the job post fields in the template.
+var jobFieldNames = map[jobs.Field]string{ //nolint:gochecknoglobals // it's a constant map
+	jobs.FieldPosition: "Должность",
+	jobs.FieldCompany:  "Компания",
+	jobs.FieldSalary:   "Зарплата",
+	jobs.FieldFormat:   "Формат",
+	jobs.FieldContact:  "Контакт",
+}
+
+// jobFieldExamples are the example values of the job post fields in the template.
+var jobFieldExamples = map[jobs.Field]string{ //nolint:gochecknoglobals // it's a constant map
+	jobs.FieldPosition: "Senior PHP developer",
+	jobs.FieldCompany:  "Geeks",
+	jobs.FieldSalary:   "250 000 - 350 000 ₽",
+	jobs.FieldFormat:   "удалённо / офис, город",
+	jobs.FieldContact:  "@username",
+}
+
+// checkJobPost deletes the post of the job board which doesn't follow the template or offers a salary below the minimum,
+// the author gets the template privately. Admins, commands, replies and messages without text aren't checked.
+// The post is kept if the admins can't be fetched. It returns true if the post is deleted.
+func (m *Manager) checkJobPost(message *tgbotapi.Message) bool {
+	if message.From == nil || message.Chat == nil || message.Chat.IsPrivate() || repliedMessage(message) != nil {
+		return false
+	}
+
+	text := content(message)
+	if text == "" || strings.HasPrefix(text, "/") {
+		return false
+	}
+
+	cfg, ok := m.commands.Jobs(chatRef(message.Chat))
+	if !ok {
+		return false
+	}
+
+	problems := jobPostProblems(text, cfg)
+	if len(problems) == 0 {
+		return false
+	}
+
+	if m.exemptAuthor(message) {
+		return false
+	}
+
+	err := m.bot.DeleteMessage(message.Chat.ID, message.MessageID)
+	if err != nil {
+		m.log("Delete job post",
+			zap.Int("messageID", message.MessageID),
+			zap.Error(err),
+		)
+
+		return false
+	}
+
+	e := m.auditEvent(audit.ActionJobPost, message.Chat, message, nil)
+	e.Reason = strings.Join(problems, "; ")
+	e.Content = text
+	m.audit(message.Chat, e)
+
+	m.notifyAuthor(message, "Вакансия удалена: "+html.EscapeString(strings.Join(problems, "; "))+".\n\n"+jobTemplate(cfg))
+
+	return true
+}
+
+// jobPostProblems returns the problems of the job post: the missing fields and hashtags,
+// the salary without the amount or in an unknown currency, the salary below the minimum.
+func jobPostProblems(text string, cfg *catalog.Jobs) []string {
+	post := jobs.Parse(text)
+
+	var problems []string
+
+	if missing := post.Missing(); len(missing) > 0 {
+		names := make([]string, 0, len(missing))
+		for _, field := range missing {
+			names = append(names, strings.ToLower(jobFieldNames[field]))
+		}
+
+		problems = append(problems, "нет полей "+strings.Join(names, ", "))
+	}
+
+	if missing := post.MissingHashtags(cfg.Hashtags); len(missing) > 0 {
+		problems = append(problems, "нет хэштегов "+strings.Join(missing, " "))
+	}
+
+	salaryText, ok := post.Fields[jobs.FieldSalary]
+	if !ok {
+		return problems
+	}
+
+	currency := cfg.CurrencyValue()
+
+	salary, err := jobs.ParseSalary(salaryText, currency, cfg.Rates)
+	if err == nil {
+		salary, err = salary.Convert(currency, cfg.Rates)
+	}
+
+	switch {
+	case errors.Is(err, jobs.ErrNoSalary):
+		problems = append(problems, "в зарплате нет суммы")
+	case errors.Is(err, jobs.ErrUnknownCurrency):
+		problems = append(problems, "неизвестная валюта зарплаты")
+	case err != nil:
+		problems = append(problems, "не удалось разобрать зарплату")
+	case salary.Upper() < float64(cfg.MinSalary):
+		problems = append(problems, "зарплата ниже "+formatAmount(cfg.MinSalary)+" "+currency+" в месяц")
+	}
+
+	return problems
+}
+
+// jobTemplate returns the job post template of the job board.
+func jobTemplate(cfg *catalog.Jobs) string {
+	var b strings.Builder
+
+	b.WriteString("Шаблон вакансии:\n<code>")
+
+	for i, field := range jobs.Fields {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+
+		b.WriteString(jobFieldNames[field] + ": " + jobFieldExamples[field])
+	}
+
+	if len(cfg.Hashtags) > 0 {
+		b.WriteString("\n" + html.EscapeString(strings.Join(cfg.Hashtags, " ")))
+	}
+
+	b.WriteString("</code>")
+
+	if cfg.MinSalary > 0 {
+		b.WriteString("\nЗарплата от " + formatAmount(cfg.MinSalary) + " " + cfg.CurrencyValue() +
+			" в месяц, можно в другой валюте, например $3000, или в тысячах, например 250k.")
+	}
+
+	return b.String()
+}
+
+// formatAmount returns the amount with the thousands separated by spaces, e.g. 250 000.
+func formatAmount(amount int64) string {
+	s := strconv.FormatInt(amount, 10)
+
+	for i := len(s) - 3; i > 0; i -= 3 {
+		s = s[:i] + " " + s[i:]
+	}
+
+	return s
+}
@@ -0,0 +1,245 @@
+package observer
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/stretchr/testify/assert"
+
+	"geeksonator/internal/catalog"
+	"geeksonator/internal/observer/mocks"
+)
+
+// jobsConfig is the job board configuration of the tests.
+func jobsConfig() *catalog.Jobs {
+	return &catalog.Jobs{
+		MinSalary: 250000,
+		Rates:     map[string]float64{"USD": 90},
+		Hashtags:  []string{"#вакансия"},
+	}
+}
+
+// jobPost returns the job post of the tests with the salary.
+func jobPost(salary string) string {
+	return "#вакансия\nДолжность: Senior PHP developer\nКомпания: Geeks\nЗарплата: " + salary +
+		"\nФормат: удалённо\nКонтакт: @hr"
+}
+
+func Test_jobPostProblems(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		text string
+		want []string
+	}{
+		{
+			name: "Compliant",
+			text: jobPost("250k"),
+			want: nil,
+		},
+		{
+			name: "Compliant in dollars",
+			text: jobPost("$2500-3000"),
+			want: nil,
+		},
+		{
+			name: "Below the minimum",
+			text: jobPost("150 000 - 200 000 ₽"),
+			want: []string{"зарплата ниже 250 000 RUB в месяц"},
+		},
+		{
+			name: "Unknown currency",
+			text: jobPost("500 000 KZT"),
+			want: []string{"неизвестная валюта зарплаты"},
+		},
+		{
+			name: "No amount",
+			text: jobPost("по договорённости"),
+			want: []string{"в зарплате нет суммы"},
+		},
+		{
+			name: "Free text",
+			text: "Ищем сеньора, пишите в личку",
+			want: []string{
+				"нет полей должность, компания, зарплата, формат, контакт",
+				"нет хэштегов #вакансия",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, jobPostProblems(tt.text, jobsConfig()))
+		})
+	}
+}
+
+func Test_jobTemplate(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "Шаблон вакансии:\n<code>Должность: Senior PHP developer\nКомпания: Geeks\n"+
+		"Зарплата: 250 000 - 350 000 ₽\nФормат: удалённо / офис, город\nКонтакт: @username\n#вакансия</code>\n"+
+		"Зарплата от 250 000 RUB в месяц, можно в другой валюте, например $3000, или в тысячах, например 250k.",
+		jobTemplate(jobsConfig()))
+
+	assert.Equal(t, "Шаблон вакансии:\n<code>Должность: Senior PHP developer\nКомпания: Geeks\n"+
+		"Зарплата: 250 000 - 350 000 ₽\nФормат: удалённо / офис, город\nКонтакт: @username</code>",
+		jobTemplate(&catalog.Jobs{}))
+}
+
+func Test_formatAmount(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "250", formatAmount(250))
+	assert.Equal(t, "3 000", formatAmount(3000))
+	assert.Equal(t, "250 000", formatAmount(250000))
+	assert.Equal(t, "1 250 000", formatAmount(1250000))
+}
+
+func TestManager_processingMessage_JobPost(t *testing.T) {
+	t.Parallel()
+
+	reason := "Вакансия удалена: зарплата ниже 250 000 RUB в месяц.\n\n" + jobTemplate(jobsConfig())
+	private := "Чат: Jobs\n" + reason + "\n\nВаш текст:\n<blockquote>" + jobPost("100k") + "</blockquote>"
+
+	tests := []struct {
+		name       string
+		text       string
+		admin      bool
+		adminsErr  error
+		topic      bool
+		privateErr error
+	}{
+		{
+			name:  "Admin post",
+			text:  jobPost("100k"),
+			admin: true,
+		},
+		{
+			name:      "Kept if the admins can't be fetched",
+			text:      jobPost("100k"),
+			adminsErr: errors.New("Too Many Requests: retry after 5"),
+		},
+		{
+			name: "Template sent privately",
+			text: jobPost("100k"),
+		},
+		{
+			name:  "Post in the forum topic",
+			text:  jobPost("100k"),
+			topic: true,
+		},
+		{
+			name:       "Template posted to the chat",
+			text:       jobPost("100k"),
+			privateErr: errors.New("bot can't initiate conversation with a user"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			botProvider := mocks.NewBotProviderMock(t)
+			commands := mocks.NewCommandsMock(t)
+			cache := mocks.NewCacheMock(t)
+
+			m := &Manager{
+				bot:      botProvider,
+				cache:    cache,
+				commands: commands,
+				now:      func() time.Time { return moderationNow },
+			}
+
+			commands.EXPECT().
+				Jobs(catalog.ChatRef{ID: 300600}).
+				Return(jobsConfig(), true)
+
+			admins := []tgbotapi.ChatMember{{User: &tgbotapi.User{ID: 100500}}}
+			if tt.admin {
+				admins = append(admins, tgbotapi.ChatMember{User: &tgbotapi.User{ID: 100501}})
+			}
+
+			kept := tt.admin || tt.adminsErr != nil
+			if kept {
+				// the kept post is processed as a usual message
+				commands.EXPECT().
+					Lookup(catalog.ChatRef{ID: 300600}, "").
+					Return(nil, false).
+					Maybe()
+			}
+
+			if tt.adminsErr != nil {
+				cache.EXPECT().
+					Get(int64(300600)).
+					Return(nil, false)
+
+				botProvider.EXPECT().
+					GetChatAdministrators(tgbotapi.ChatConfig{ChatID: 300600}).
+					Return(nil, tt.adminsErr)
+			} else {
+				cache.EXPECT().
+					Get(int64(300600)).
+					Return(admins, true)
+			}
+
+			if !kept {
+				botProvider.EXPECT().
+					DeleteMessage(int64(300600), 42).
+					Return(nil)
+
+				msg := tgbotapi.NewMessage(100501, private)
+
+				botProvider.EXPECT().
+					NewMessage(int64(100501), private).
+					Return(msg)
+
+				msg.ParseMode = "html"
+
+				botProvider.EXPECT().
+					Send(msg).
+					Return(tgbotapi.Message{}, tt.privateErr)
+			}
+
+			if tt.privateErr != nil {
+				notice := tgbotapi.NewMessage(300600, "@author, "+reason)
+
+				botProvider.EXPECT().
+					NewMessage(int64(300600), notice.Text).
+					Return(notice)
+
+				notice.ParseMode = "html"
+
+				botProvider.EXPECT().
+					Send(notice).
+					Return(tgbotapi.Message{MessageID: 43}, nil)
+
+				scheduler := mocks.NewSchedulerMock(t)
+
+				scheduler.EXPECT().
+					Schedule(int64(300600), 43, moderationNow.Add(defaultSuggestionTTL)).
+					Return(nil)
+
+				m.scheduler = scheduler
+			}
+
+			message := &tgbotapi.Message{
+				MessageID: 42,
+				Chat:      &tgbotapi.Chat{ID: 300600, Title: "Jobs", Type: "supergroup"},
+				From:      &tgbotapi.User{ID: 100501, UserName: "author"},
+				Text:      tt.text,
+			}
+			if tt.topic {
+				// the post sent to the forum topic replies to the topic creation
+				message.ReplyToMessage = &tgbotapi.Message{MessageID: 10, From: &tgbotapi.User{ID: 100500}}
+			}
+
+			got, err := m.processingMessage(message)
+			assert.NoError(t, err)
+			assert.Empty(t, got.texts)
+		})
+	}
+}
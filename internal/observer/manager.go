@@ -3,27 +3,76 @@ package observer
 import (
 	"context"
 	"fmt"
+	"html"
+	"sync"
+	"time"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
+
+	"geeksonator/internal/catalog"
+	"geeksonator/pkg/layout"
 )
 
+// defaultSuggestionTTL is the time after which the suggestion reply in the chat is deleted.
+const defaultSuggestionTTL = 30 * time.Second
+
 // Manager is manager for observer.
 type Manager struct {
 	bot            BotProvider
 	chanUpdates    tgbotapi.UpdatesChannel
 	cache          Cache
+	commands       Commands
+	warnings       Warnings
+	scheduler      Scheduler
+	flood          FloodLimiter
+	filters        Filters
+	members        Members
+	challengeStore Challenges
+	reports        Reports
+	auditor        Auditor
+	federation     Federation
+	blocklist      Blocklist
+	posts          Posts
 	logger         *zap.Logger
+	botUsername    string
 	skipAdminCheck bool
+
+	now           func() time.Time
+	intn          func(n int) int
+	suggestionTTL time.Duration
+
+	challengesMu sync.Mutex
+	challenges   map[challengeKey]*pendingChallenge
+
+	reportsMu      sync.Mutex
+	pendingReports map[reportKey]*pendingReport
+}
+
+// response is the answer to the command message.
+type response struct {
+	// texts are the parts of the answer.
+	texts []string
+	// cleanup is the deletion of the command message and the answer.
+	cleanup catalog.Cleanup
+}
+
+// request is a command request of the message author.
+type request struct {
+	message *tgbotapi.Message
+	cmd     *catalog.Command
+	args    string
+	role    catalog.Role
 }
 
 // NewManager creates new manager.
-func NewManager(bot BotProvider, chanUpdates tgbotapi.UpdatesChannel, cache Cache, opts ...ManagerOption) *Manager {
+func NewManager(bot BotProvider, chanUpdates tgbotapi.UpdatesChannel, cache Cache, commands Commands, opts ...ManagerOption) *Manager {
 	m := &Manager{
 		bot:         bot,
 		chanUpdates: chanUpdates,
 		cache:       cache,
+		commands:    commands,
 	}
 
 	for _, opt := range opts {
@@ -43,6 +92,90 @@ func WithDebug(logger *zap.Logger) ManagerOption {
 	}
 }
 
+// WithBotUsername sets the bot username, commands addressed to other bots are ignored.
+func WithBotUsername(username string) ManagerOption {
+	return func(m *Manager) {
+		m.botUsername = username
+	}
+}
+
+// WithWarnings sets the warnings storage, /warn, /unwarn and /warns are unavailable without it.
+func WithWarnings(warnings Warnings) ManagerOption {
+	return func(m *Manager) {
+		m.warnings = warnings
+	}
+}
+
+// WithScheduler sets the scheduler of the delayed deletion, the bot replies are kept without it.
+func WithScheduler(scheduler Scheduler) ManagerOption {
+	return func(m *Manager) {
+		m.scheduler = scheduler
+	}
+}
+
+// WithFloodLimiter sets the messages rate limiter, the flood protection is disabled without it.
+func WithFloodLimiter(flood FloodLimiter) ManagerOption {
+	return func(m *Manager) {
+		m.flood = flood
+	}
+}
+
+// WithFilters sets the content filter rules storage, /filters is unavailable and messages aren't filtered without it.
+func WithFilters(filters Filters) ManagerOption {
+	return func(m *Manager) {
+		m.filters = filters
+	}
+}
+
+// WithMembers sets the new chat members storage, the newcomers aren't restricted without it.
+func WithMembers(members Members) ManagerOption {
+	return func(m *Manager) {
+		m.members = members
+	}
+}
+
+// WithChallenges sets the pending challenges storage, the challenges don't survive restarts without it.
+func WithChallenges(challenges Challenges) ManagerOption {
+	return func(m *Manager) {
+		m.challengeStore = challenges
+	}
+}
+
+// WithReports sets the reports subscriptions storage, the reports are posted only to the log chat without it.
+func WithReports(reports Reports) ManagerOption {
+	return func(m *Manager) {
+		m.reports = reports
+	}
+}
+
+// WithAuditor sets the moderation audit log, the moderation actions aren't logged without it.
+func WithAuditor(auditor Auditor) ManagerOption {
+	return func(m *Manager) {
+		m.auditor = auditor
+	}
+}
+
+// WithFederation sets the federation bans storage, /fban and /funban are disabled without it.
+func WithFederation(federation Federation) ManagerOption {
+	return func(m *Manager) {
+		m.federation = federation
+	}
+}
+
+// WithBlocklist sets the blocklist, the new members aren't screened without it.
+func WithBlocklist(blocklist Blocklist) ManagerOption {
+	return func(m *Manager) {
+		m.blocklist = blocklist
+	}
+}
+
+// WithPosts sets the recent posts storage, the reposts aren't throttled without it.
+func WithPosts(posts Posts) ManagerOption {
+	return func(m *Manager) {
+		m.posts = posts
+	}
+}
+
 // WithSkipAdminCheck skips admin check.
 func WithSkipAdminCheck() ManagerOption {
 	return func(m *Manager) {
@@ -52,6 +185,8 @@ func WithSkipAdminCheck() ManagerOption {
 
 // Run runs manager.
 func (m *Manager) Run(ctx context.Context) error {
+	m.restoreChallenges()
+
 	for update := range m.chanUpdates {
 		select {
 		case <-ctx.Done():
@@ -71,40 +206,138 @@ func (m *Manager) Run(ctx context.Context) error {
 
 // processingUpdate processes update.
 func (m *Manager) processingUpdate(update tgbotapi.Update) error {
-	msgText, err := m.processingMessage(update.Message)
-	if err != nil {
-		return fmt.Errorf("m.processingMessage: %v", err)
+	if update.CallbackQuery != nil {
+		m.processingCallback(update.CallbackQuery)
+
+		return nil
 	}
-	if msgText == "" {
+
+	if update.ChatJoinRequest != nil {
+		m.processingJoinRequest(update.ChatJoinRequest)
+
 		return nil
 	}
 
-	if err := m.sendMessage(update.Message, msgText); err != nil {
-		return fmt.Errorf("m.sendMessage: %v", err)
+	resp, err := m.processingMessage(update.Message)
+	if err != nil {
+		return fmt.Errorf("m.processingMessage: %v", err)
+	}
+
+	for i, msgText := range resp.texts {
+		updateMsg := update.Message
+		if i > 0 {
+			// Only the first part of the split response replies to the message.
+			updateMsg = &tgbotapi.Message{Chat: update.Message.Chat}
+		}
+
+		sent, err := m.sendMessage(updateMsg, msgText)
+		if err != nil {
+			return fmt.Errorf("m.sendMessage: %v", err)
+		}
+
+		m.scheduleCleanup(sent, resp.cleanup)
 	}
 
 	return nil
 }
 
 // processingMessage processes message.
-func (m *Manager) processingMessage(message *tgbotapi.Message) (string, error) {
+func (m *Manager) processingMessage(message *tgbotapi.Message) (response, error) {
 	if message == nil {
-		return "", nil
+		return response{}, nil
 	}
 	m.log("Received message",
 		zap.String("message", message.Text),
 	)
 
-	msgText := getMessageText(message.Text)
-	if msgText == "" {
-		return "", nil
+	if len(message.NewChatMembers) > 0 {
+		m.banBlocklisted(message)
+		m.banFederated(message)
+		m.trackNewMembers(message)
+		m.challengeNewMembers(message)
+
+		return response{}, nil
+	}
+
+	if message.LeftChatMember != nil {
+		m.forgetMember(message.Chat.ID, message.LeftChatMember.ID)
+
+		return response{}, nil
+	}
+
+	if m.checkFlood(message) {
+		return response{}, nil
+	}
+
+	if m.checkFilters(message) {
+		return response{}, nil
+	}
+
+	if m.checkNewcomer(message) {
+		return response{}, nil
+	}
+
+	if m.checkJobPost(message) {
+		return response{}, nil
+	}
+
+	if m.checkPost(message) {
+		return response{}, nil
+	}
+
+	cmd, parsed, ok := m.getCommand(message)
+	if !ok {
+		if err := m.suggestCommand(message); err != nil {
+			return response{}, fmt.Errorf("m.suggestCommand: %v", err)
+		}
+
+		return response{}, nil
+	}
+
+	role, err := m.authorRole(message)
+	if err != nil {
+		return response{}, fmt.Errorf("m.authorRole: %v", err)
+	}
+
+	if !cmd.AllowedFor(role) {
+		return response{}, nil
+	}
+
+	msgTexts, err := m.getMessageText(&request{
+		message: message,
+		cmd:     cmd,
+		args:    parsed.args,
+		role:    role,
+	})
+	if err != nil {
+		return response{}, fmt.Errorf("m.getMessageText: %v", err)
 	}
 	m.log("Output message",
-		zap.String("msgText", msgText),
+		zap.Strings("msgTexts", msgTexts),
 	)
 
+	if parsed.del && role == catalog.RoleAdmin {
+		m.deleteReplied(message)
+	}
+
+	chat, _ := m.commands.Chat(chatRef(message.Chat))
+	cleanup := cmd.CleanupIn(chat)
+
+	// /del deletes the command message itself
+	if cleanup.Command && cmd.Handler != catalog.HandlerDel {
+		m.deleteCommand(message)
+	}
+
+	return response{
+		texts:   msgTexts,
+		cleanup: cleanup,
+	}, nil
+}
+
+// authorRole returns the role of the message author.
+func (m *Manager) authorRole(message *tgbotapi.Message) (catalog.Role, error) {
 	if m.skipAdminCheck {
-		return msgText, nil
+		return catalog.RoleAdmin, nil
 	}
 
 	admins, err := m.getAdmins(message.Chat.ChatConfig())
@@ -113,47 +346,30 @@ func (m *Manager) processingMessage(message *tgbotapi.Message) (string, error) {
 	}
 
 	if authorIsAdmin(admins, message.From.ID) {
-		return msgText, nil
+		return catalog.RoleAdmin, nil
 	}
 
-	return "", nil
+	return catalog.RoleMember, nil
 }
 
 // sendMessage sends message.
-func (m *Manager) sendMessage(updateMsg *tgbotapi.Message, message string) error { //nolint:gocognit // it's ok
+func (m *Manager) sendMessage(updateMsg *tgbotapi.Message, message string) (tgbotapi.Message, error) {
 	msg := m.bot.NewMessage(updateMsg.Chat.ID, message)
 	msg.ParseMode = "html"
 	msg.DisableWebPagePreview = true
 
-	if updateMsg.ReplyToMessage != nil { //nolint:nestif // it's ok
+	if updateMsg.ReplyToMessage != nil {
 		msg.ReplyToMessageID = updateMsg.ReplyToMessage.MessageID
-
-		if updateMsg.ReplyToMessage.From != nil {
-			var fromUser string
-			if updateMsg.ReplyToMessage.From.UserName != "" {
-				fromUser = "@" + updateMsg.ReplyToMessage.From.UserName
-			} else {
-				uName := updateMsg.ReplyToMessage.From.FirstName
-				if updateMsg.ReplyToMessage.From.LastName != "" {
-					uName += " " + updateMsg.ReplyToMessage.From.LastName
-				}
-
-				fromUser = fmt.Sprintf(`<a href="tg://user?id=%d">%s</a>`,
-					updateMsg.ReplyToMessage.From.ID,
-					uName,
-				)
-			}
-
-			msg.Text = fromUser + " " + msg.Text
-		}
+		// the replied message may be already deleted, e.g. by a moderation command
+		msg.AllowSendingWithoutReply = true
 	}
 
-	_, err := m.bot.Send(msg)
+	sent, err := m.bot.Send(msg)
 	if err != nil {
-		return fmt.Errorf("m.bot.Send(%s): %v", msg.Text, err)
+		return tgbotapi.Message{}, fmt.Errorf("m.bot.Send(%s): %v", msg.Text, err)
 	}
 
-	return nil
+	return sent, nil
 }
 
 // log debug message.
@@ -184,6 +400,23 @@ func (m *Manager) getAdmins(chatCfg tgbotapi.ChatConfig) ([]tgbotapi.ChatMember,
 	return admins, nil
 }
 
+// exemptAuthor returns true if the author of the message is an admin of the chat, the automatic moderation
+// doesn't apply to the admins. The author is exempt if the admins can't be fetched, the failure is only logged,
+// so a temporary API failure doesn't stop the bot.
+func (m *Manager) exemptAuthor(message *tgbotapi.Message) bool {
+	admins, err := m.getAdmins(message.Chat.ChatConfig())
+	if err != nil {
+		m.log("Get chat admins",
+			zap.Int64("chatID", message.Chat.ID),
+			zap.Error(err),
+		)
+
+		return true
+	}
+
+	return authorIsAdmin(admins, message.From.ID)
+}
+
 // authorIsAdmin returns true if author is admin.
 func authorIsAdmin(admins []tgbotapi.ChatMember, userID int64) bool {
 	for _, admin := range admins {
@@ -195,54 +428,230 @@ func authorIsAdmin(admins []tgbotapi.ChatMember, userID int64) bool {
 	return false
 }
 
-// getMessageText returns message text.
-func getMessageText(text string) string {
-	switch text {
-	case "/help", "/хелп":
-		return `БОТ РАБОТАЕТ ТОЛЬКО У АДМИНОВ.
-
-Команды можно писать обычным сообщением и ответом на сообщение.
-
-Список доступных команд:
-[<code>/help</code>, <code>/хелп</code>] Список доступных команд бота
-[<code>/php</code>, <code>/пхп</code>] @phpGeeks - Best PHP chat
-[<code>/jun</code>, <code>/джун</code>] @phpGeeksJunior - Группа для новичков. Не стесняйтесь задавать вопросы по php.
-[<code>/go</code>, <code>/го</code>] @golangGeeks - Приветствуем всех в нашем гетеросексуальном чате гоферов!
-[<code>/db</code>, <code>/дб</code>] @dbGeeks - Чат про базы данных, их устройство и приемы работы с ними.
-[<code>/lara</code>, <code>/лара</code>] @laravel_pro - Официальный чат для всех Laravel программистов.
-[<code>/js</code>, <code>/жс</code>] @jsChat - Чат посвященный программированию на языке JavaScript.
-[<code>/hr</code>, <code>/хр</code>] @jobGeeks - Топ вакансии (250 000+ р/мес).
-[<code>/fl</code>, <code>/фл</code>] @freelanceGeeks - IT фриланс, ищем исполнителей и заказчиков, делимся опытом и проблемами связанными с фрилансом.
-[<code>/job</code>, <code>/раб</code>] Объединяет сразу две команды: <code>/hr</code> и <code>/fl</code>.
-[<code>/code</code>, <code>/код</code>] Код в нашем чате <a href="https://t.me/phpGeeks/1318040">ложут</a> на pastebin.org, gist.github.com или любой аналогичный ресурс (с)der_Igel
-[<code>/nometa</code>, <code>/номета</code>] nometa.xyz
-[<code>/wtf</code>, <code>/втф</code>] А причём тут пхп?`
-	case "/php", "/пхп":
-		return "@phpGeeks - Best PHP chat"
-	case "/jun", "/джун":
-		return "@phpGeeksJunior - Группа для новичков. Не стесняйтесь задавать вопросы по php."
-	case "/go", "/го":
-		return "@golangGeeks - Приветствуем всех в нашем гетеросексуальном чате гоферов!"
-	case "/db", "/бд":
-		return "@dbGeeks - Чат про базы данных, их устройство и приемы работы с ними."
-	case "/lara", "/лара":
-		return "@laravel_pro - Официальный чат для всех Laravel программистов."
-	case "/js", "/жс":
-		return "@jsChat - Чат посвященный программированию на языке JavaScript."
-	case "/hr", "/хр":
-		return "@jobGeeks - Топ вакансии (250 000+ р/мес)."
-	case "/fl", "/фл":
-		return "@freelanceGeeks - IT фриланс, ищем исполнителей и заказчиков, делимся опытом и проблемами связанными с фрилансом."
-	case "/job", "/раб":
-		return `@jobGeeks - Топ вакансии (250 000+ р/мес).
-@freelanceGeeks - IT фриланс, ищем исполнителей и заказчиков, делимся опытом и проблемами связанными с фрилансом.`
-	case "/code", "/код":
-		return "Код в нашем чате <a href=\"https://t.me/phpGeeks/1318040\">ложут</a> на pastebin.org, gist.github.com или любой аналогичный ресурс (с)der_Igel"
-	case "/nometa", "/номета":
-		return "nometa.xyz"
-	case "/wtf", "/втф":
-		return "А причём тут пхп?"
-	}
-
-	return ""
+// getCommand returns the catalog command of the message and the parsed command.
+func (m *Manager) getCommand(message *tgbotapi.Message) (*catalog.Command, command, bool) {
+	parsed, ok := parseCommand(message, m.botUsername)
+	if !ok {
+		return nil, command{}, false
+	}
+
+	chat := chatRef(message.Chat)
+
+	cmd, ok := m.commands.Lookup(chat, parsed.name)
+	if !ok && m.layoutFallback(chat) {
+		cmd, ok = m.commands.Lookup(chat, layout.Switch(parsed.name))
+	}
+
+	if !ok {
+		return nil, command{}, false
+	}
+
+	return cmd, parsed, true
+}
+
+// layoutFallback returns true if the chat recognizes the commands typed in the wrong keyboard layout.
+func (m *Manager) layoutFallback(chat catalog.ChatRef) bool {
+	config, ok := m.commands.Chat(chat)
+
+	return ok && config.LayoutFallback
+}
+
+// suggestCommand suggests the closest command to the admin who mistyped a command.
+// The suggestion is sent privately, if the bot can't write to the admin it replies in the chat
+// and deletes the reply after a while.
+func (m *Manager) suggestCommand(message *tgbotapi.Message) error {
+	if message.From == nil || message.Chat == nil || message.Chat.IsPrivate() {
+		return nil
+	}
+
+	parsed, ok := parseCommand(message, m.botUsername)
+	if !ok {
+		return nil
+	}
+
+	suggestion, ok := m.commands.Suggest(chatRef(message.Chat), parsed.name, catalog.RoleAdmin)
+	if !ok {
+		return nil
+	}
+
+	role, err := m.authorRole(message)
+	if err != nil {
+		return fmt.Errorf("m.authorRole: %v", err)
+	}
+
+	if role != catalog.RoleAdmin {
+		return nil
+	}
+
+	text := fmt.Sprintf("Команда <code>/%s</code> не найдена. Возможно, вы имели в виду <code>/%s</code>?",
+		html.EscapeString(parsed.name),
+		suggestion,
+	)
+
+	private := m.bot.NewMessage(message.From.ID, text+"\nЧат: "+html.EscapeString(message.Chat.Title))
+	private.ParseMode = "html"
+
+	// the bot can't write to the admin who hasn't started it, the suggestion falls back to the chat
+	_, err = m.bot.Send(private)
+	if err == nil {
+		return nil
+	}
+	m.log("Send suggestion privately",
+		zap.Error(err),
+	)
+
+	reply := m.bot.NewMessage(message.Chat.ID, text)
+	reply.ParseMode = "html"
+	reply.ReplyToMessageID = message.MessageID
+
+	// the suggestion is only a hint, the bot keeps working if it can't be posted
+	sent, err := m.bot.Send(reply)
+	if err != nil {
+		m.log("Send suggestion",
+			zap.Int64("chatID", message.Chat.ID),
+			zap.Error(err),
+		)
+
+		return nil
+	}
+
+	m.deleteLater(message.Chat.ID, sent.MessageID)
+
+	return nil
+}
+
+// suggestionLifetime returns the time after which the suggestion reply is deleted.
+func (m *Manager) suggestionLifetime() time.Duration {
+	if m.suggestionTTL > 0 {
+		return m.suggestionTTL
+	}
+
+	return defaultSuggestionTTL
+}
+
+// getMessageText returns message texts of the request.
+// The reply target is mentioned at the beginning unless the response mentions it itself.
+func (m *Manager) getMessageText(req *request) ([]string, error) {
+	switch req.cmd.Handler {
+	case catalog.HandlerBan:
+		return m.ban(req)
+	case catalog.HandlerUnban:
+		return m.unban(req)
+	case catalog.HandlerMute:
+		return m.mute(req)
+	case catalog.HandlerUnmute:
+		return m.unmute(req)
+	case catalog.HandlerWarn:
+		return m.warn(req)
+	case catalog.HandlerUnwarn:
+		return m.unwarn(req)
+	case catalog.HandlerWarns:
+		return m.warns(req)
+	case catalog.HandlerDel:
+		return m.del(req)
+	case catalog.HandlerFilters:
+		return m.filtersCmd(req)
+	case catalog.HandlerReport:
+		return m.report(req)
+	case catalog.HandlerReports:
+		return m.reportsCmd(req)
+	case catalog.HandlerFban:
+		return m.fban(req)
+	case catalog.HandlerFunban:
+		return m.funban(req)
+	}
+
+	var msgTexts []string
+	var targetUsed bool
+
+	if req.cmd.Handler == catalog.HandlerHelp {
+		msgTexts = m.commands.Help(chatRef(req.message.Chat), req.role)
+	} else {
+		data := m.templateData(req)
+
+		text, used, err := req.cmd.Render(data)
+		if err != nil {
+			return nil, fmt.Errorf("req.cmd.Render: %v", err)
+		}
+
+		msgTexts, targetUsed = []string{text}, used
+	}
+
+	if target := replyTarget(req.message); target != "" && !targetUsed && len(msgTexts) > 0 {
+		msgTexts[0] = target + " " + msgTexts[0]
+	}
+
+	return msgTexts, nil
+}
+
+// templateData returns the response template data of the request.
+func (m *Manager) templateData(req *request) catalog.Data {
+	data := catalog.Data{
+		Target: replyTarget(req.message),
+		Args:   html.EscapeString(req.args),
+		Date:   m.timeNow().Format("02.01.2006"),
+	}
+
+	if req.message.From != nil {
+		data.Caller = html.EscapeString(fullName(req.message.From))
+	}
+
+	if req.message.Chat != nil {
+		data.Chat = html.EscapeString(req.message.Chat.Title)
+	}
+
+	return data
+}
+
+// timeNow returns the current time.
+func (m *Manager) timeNow() time.Time {
+	if m.now != nil {
+		return m.now()
+	}
+
+	return time.Now()
+}
+
+// chatRef returns the reference of the chat for the commands lookup.
+func chatRef(chat *tgbotapi.Chat) catalog.ChatRef {
+	if chat == nil {
+		return catalog.ChatRef{}
+	}
+
+	return catalog.ChatRef{
+		ID:       chat.ID,
+		Username: chat.UserName,
+	}
+}
+
+// replyTarget returns the mention of the author of the replied message, empty if the message is not a reply.
+func replyTarget(message *tgbotapi.Message) string {
+	reply := repliedMessage(message)
+	if reply == nil || reply.From == nil {
+		return ""
+	}
+
+	return mention(reply.From)
+}
+
+// mention returns the HTML mention of the user.
+func mention(user *tgbotapi.User) string {
+	if user.UserName != "" {
+		return "@" + user.UserName
+	}
+
+	return fmt.Sprintf(`<a href="tg://user?id=%d">%s</a>`,
+		user.ID,
+		html.EscapeString(fullName(user)),
+	)
+}
+
+// fullName returns the first and the last name of the user.
+func fullName(user *tgbotapi.User) string {
+	name := user.FirstName
+	if user.LastName != "" {
+		name += " " + user.LastName
+	}
+
+	return name
 }
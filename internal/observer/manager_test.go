@@ -1,12 +1,16 @@
 package observer
 
 import (
+	"errors"
 	"testing"
+	"time"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"go.uber.org/zap"
 
+	"geeksonator/internal/catalog"
 	"geeksonator/internal/observer/mocks"
 )
 
@@ -15,6 +19,75 @@ const (
 	laraTxt = "@laravel_pro - Официальный чат для всех Laravel программистов."
 )
 
+// laraCommands returns commands mock resolving the /lara command.
+func laraCommands(t *testing.T) *mocks.CommandsMock {
+	t.Helper()
+
+	commands := mocks.NewCommandsMock(t)
+
+	commands.EXPECT().
+		Lookup(mock.Anything, "lara").
+		Return(&catalog.Command{
+			Name:     "lara",
+			Aliases:  []string{"лара"},
+			Role:     catalog.RoleAdmin,
+			Response: laraTxt,
+		}, true)
+
+	// the chat configuration is read for the cleanup only if the command is answered
+	commands.EXPECT().
+		Chat(mock.Anything).
+		Return(nil, false).
+		Maybe()
+
+	return commands
+}
+
+// unknownCommands returns commands mock without the /unknown command.
+func unknownCommands(t *testing.T) *mocks.CommandsMock {
+	t.Helper()
+
+	commands := mocks.NewCommandsMock(t)
+
+	commands.EXPECT().
+		Lookup(mock.Anything, "unknown").
+		Return(nil, false)
+
+	commands.EXPECT().
+		Chat(mock.Anything).
+		Return(nil, false)
+
+	return commands
+}
+
+// messageCommands returns commands mock for the ordinary chat messages of the chats without the job board,
+// the messages kept by the moderation checks reach the job board check.
+func messageCommands(t *testing.T) *mocks.CommandsMock {
+	t.Helper()
+
+	commands := mocks.NewCommandsMock(t)
+
+	commands.EXPECT().
+		Jobs(mock.Anything).
+		Return(nil, false).
+		Maybe()
+
+	return commands
+}
+
+// adminsCache returns cache mock with the admin of the chat 300600.
+func adminsCache(t *testing.T, adminID int64) *mocks.CacheMock {
+	t.Helper()
+
+	cache := mocks.NewCacheMock(t)
+
+	cache.EXPECT().
+		Get(int64(300600)).
+		Return([]tgbotapi.ChatMember{{User: &tgbotapi.User{ID: adminID}}}, true)
+
+	return cache
+}
+
 func TestNewManager(t *testing.T) {
 	t.Parallel()
 
@@ -22,6 +95,7 @@ func TestNewManager(t *testing.T) {
 		bot         BotProvider
 		chanUpdates tgbotapi.UpdatesChannel
 		cache       Cache
+		commands    Commands
 	}
 	tests := []struct {
 		name string
@@ -34,11 +108,13 @@ func TestNewManager(t *testing.T) {
 				bot:         nil,
 				chanUpdates: nil,
 				cache:       nil,
+				commands:    nil,
 			},
 			want: &Manager{
 				bot:         nil,
 				chanUpdates: nil,
 				cache:       nil,
+				commands:    nil,
 			},
 		},
 	}
@@ -46,7 +122,7 @@ func TestNewManager(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 
-			got := NewManager(tt.args.bot, tt.args.chanUpdates, tt.args.cache)
+			got := NewManager(tt.args.bot, tt.args.chanUpdates, tt.args.cache, tt.args.commands)
 			assert.Equal(t, tt.want, got)
 		})
 	}
@@ -55,14 +131,21 @@ func TestNewManager(t *testing.T) {
 func TestNewManagerWithDebug(t *testing.T) {
 	t.Parallel()
 
-	m := NewManager(nil, nil, nil, WithDebug(zap.NewNop()))
+	m := NewManager(nil, nil, nil, nil, WithDebug(zap.NewNop()))
 	assert.NotNil(t, m.logger)
 }
 
+func TestNewManagerWithBotUsername(t *testing.T) {
+	t.Parallel()
+
+	m := NewManager(nil, nil, nil, nil, WithBotUsername("geeksonator_bot"))
+	assert.Equal(t, "geeksonator_bot", m.botUsername)
+}
+
 func TestNewManagerWithSkipAdminCheck(t *testing.T) {
 	t.Parallel()
 
-	m := NewManager(nil, nil, nil, WithSkipAdminCheck())
+	m := NewManager(nil, nil, nil, nil, WithSkipAdminCheck())
 	assert.True(t, m.skipAdminCheck)
 }
 
@@ -120,9 +203,23 @@ func TestManager_processingUpdate(t *testing.T) {
 						true,
 					)
 
+				commands := mocks.NewCommandsMock(t)
+
+				commands.EXPECT().
+					Lookup(catalog.ChatRef{ID: 300600}, "nometa").
+					Return(&catalog.Command{
+						Name:     "nometa",
+						Response: "nometa.xyz",
+					}, true)
+
+				commands.EXPECT().
+					Chat(catalog.ChatRef{ID: 300600}).
+					Return(nil, false)
+
 				return &Manager{
-					bot:   botProvider,
-					cache: cache,
+					bot:      botProvider,
+					cache:    cache,
+					commands: commands,
 				}
 			},
 			args: args{
@@ -140,6 +237,96 @@ func TestManager_processingUpdate(t *testing.T) {
 			},
 			want: nil,
 		},
+		{
+			name: "Split response replies only once",
+			man: func() *Manager {
+				botProvider := mocks.NewBotProviderMock(t)
+
+				for _, text := range []string{"@username part 1", "part 2"} {
+					botProvider.EXPECT().
+						NewMessage(int64(300600), text).
+						Return(tgbotapi.MessageConfig{
+							BaseChat: tgbotapi.BaseChat{
+								ChatID: 300600,
+							},
+							Text: text,
+						})
+				}
+
+				botProvider.EXPECT().
+					Send(
+						tgbotapi.MessageConfig{
+							BaseChat: tgbotapi.BaseChat{
+								ChatID:                   300600,
+								ReplyToMessageID:         100,
+								AllowSendingWithoutReply: true,
+							},
+							Text:                  "@username part 1",
+							ParseMode:             "html",
+							DisableWebPagePreview: true,
+						},
+					).
+					Return(tgbotapi.Message{}, nil)
+
+				botProvider.EXPECT().
+					Send(
+						tgbotapi.MessageConfig{
+							BaseChat: tgbotapi.BaseChat{
+								ChatID: 300600,
+							},
+							Text:                  "part 2",
+							ParseMode:             "html",
+							DisableWebPagePreview: true,
+						},
+					).
+					Return(tgbotapi.Message{}, nil)
+
+				commands := mocks.NewCommandsMock(t)
+
+				commands.EXPECT().
+					Lookup(catalog.ChatRef{ID: 300600}, "help").
+					Return(&catalog.Command{
+						Name:    "help",
+						Role:    catalog.RoleAdmin,
+						Handler: catalog.HandlerHelp,
+					}, true)
+
+				commands.EXPECT().
+					Help(catalog.ChatRef{ID: 300600}, catalog.RoleAdmin).
+					Return([]string{"part 1", "part 2"})
+
+				commands.EXPECT().
+					Chat(catalog.ChatRef{ID: 300600}).
+					Return(nil, false)
+
+				return &Manager{
+					bot:            botProvider,
+					commands:       commands,
+					skipAdminCheck: true,
+				}
+			},
+			args: args{
+				update: tgbotapi.Update{
+					Message: &tgbotapi.Message{
+						Chat: &tgbotapi.Chat{
+							ID: 300600,
+						},
+						From: &tgbotapi.User{
+							ID: 100500,
+						},
+						ReplyToMessage: &tgbotapi.Message{
+							Sticker:   &tgbotapi.Sticker{FileID: "spam"},
+							MessageID: 100,
+							From: &tgbotapi.User{
+								UserName: "username",
+							},
+						},
+						Text: "/help",
+					},
+				},
+			},
+			want: nil,
+		},
 		{
 			name: "Message is nil",
 			man: func() *Manager {
@@ -169,7 +356,9 @@ func TestManager_processingUpdate(t *testing.T) {
 		{
 			name: "Message is unknown command",
 			man: func() *Manager {
-				return &Manager{}
+				return &Manager{
+					commands: unknownCommands(t),
+				}
 			},
 			args: args{
 				update: tgbotapi.Update{
@@ -201,7 +390,7 @@ func TestManager_processingMessage(t *testing.T) {
 		name    string
 		man     func() *Manager
 		args    args
-		wantMsg string
+		wantMsg []string
 		wantErr error
 	}{
 		{
@@ -212,7 +401,7 @@ func TestManager_processingMessage(t *testing.T) {
 			args: args{
 				message: nil,
 			},
-			wantMsg: "",
+			wantMsg: nil,
 			wantErr: nil,
 		},
 		{
@@ -225,26 +414,29 @@ func TestManager_processingMessage(t *testing.T) {
 					Text: "",
 				},
 			},
-			wantMsg: "",
+			wantMsg: nil,
 			wantErr: nil,
 		},
 		{
 			name: "Message is unknown command",
 			man: func() *Manager {
-				return &Manager{}
+				return &Manager{
+					commands: unknownCommands(t),
+				}
 			},
 			args: args{
 				message: &tgbotapi.Message{
 					Text: "/unknown",
 				},
 			},
-			wantMsg: "",
+			wantMsg: nil,
 			wantErr: nil,
 		},
 		{
 			name: "Skip admin check",
 			man: func() *Manager {
 				return &Manager{
+					commands:       laraCommands(t),
 					skipAdminCheck: true,
 				}
 			},
@@ -253,7 +445,7 @@ func TestManager_processingMessage(t *testing.T) {
 					Text: laraCmd,
 				},
 			},
-			wantMsg: laraTxt,
+			wantMsg: []string{laraTxt},
 			wantErr: nil,
 		},
 		{
@@ -272,7 +464,8 @@ func TestManager_processingMessage(t *testing.T) {
 					}, true)
 
 				return &Manager{
-					cache: cache,
+					cache:    cache,
+					commands: laraCommands(t),
 				}
 			},
 			args: args{
@@ -286,7 +479,7 @@ func TestManager_processingMessage(t *testing.T) {
 					Text: laraCmd,
 				},
 			},
-			wantMsg: laraTxt,
+			wantMsg: []string{laraTxt},
 			wantErr: nil,
 		},
 		{
@@ -308,7 +501,8 @@ func TestManager_processingMessage(t *testing.T) {
 					)
 
 				return &Manager{
-					cache: cache,
+					cache:    cache,
+					commands: laraCommands(t),
 				}
 			},
 			args: args{
@@ -322,7 +516,7 @@ func TestManager_processingMessage(t *testing.T) {
 					Text: laraCmd,
 				},
 			},
-			wantMsg: laraTxt,
+			wantMsg: []string{laraTxt},
 			wantErr: nil,
 		},
 		{
@@ -344,7 +538,8 @@ func TestManager_processingMessage(t *testing.T) {
 					)
 
 				return &Manager{
-					cache: cache,
+					cache:    cache,
+					commands: laraCommands(t),
 				}
 			},
 			args: args{
@@ -358,7 +553,62 @@ func TestManager_processingMessage(t *testing.T) {
 					Text: laraCmd,
 				},
 			},
-			wantMsg: "",
+			wantMsg: nil,
+			wantErr: nil,
+		},
+		{
+			name: "Member command for not admin",
+			man: func() *Manager {
+				cache := mocks.NewCacheMock(t)
+
+				cache.EXPECT().
+					Get(int64(300600)).
+					Return(
+						[]tgbotapi.ChatMember{
+							{
+								User: &tgbotapi.User{
+									ID: 100500,
+								},
+							},
+						},
+						true,
+					)
+
+				commands := mocks.NewCommandsMock(t)
+
+				commands.EXPECT().
+					Lookup(catalog.ChatRef{ID: 300600}, "help").
+					Return(&catalog.Command{
+						Name:    "help",
+						Role:    catalog.RoleMember,
+						Handler: catalog.HandlerHelp,
+					}, true)
+
+				commands.EXPECT().
+					Help(catalog.ChatRef{ID: 300600}, catalog.RoleMember).
+					Return([]string{"help text"})
+
+				commands.EXPECT().
+					Chat(catalog.ChatRef{ID: 300600}).
+					Return(nil, false)
+
+				return &Manager{
+					cache:    cache,
+					commands: commands,
+				}
+			},
+			args: args{
+				message: &tgbotapi.Message{
+					Chat: &tgbotapi.Chat{
+						ID: 300600,
+					},
+					From: &tgbotapi.User{
+						ID: 100501,
+					},
+					Text: "/help",
+				},
+			},
+			wantMsg: []string{"help text"},
 			wantErr: nil,
 		},
 	}
@@ -367,7 +617,7 @@ func TestManager_processingMessage(t *testing.T) {
 			t.Parallel()
 
 			got, err := tt.man().processingMessage(tt.args.message)
-			assert.Equal(t, tt.wantMsg, got)
+			assert.Equal(t, tt.wantMsg, got.texts)
 			assert.Equal(t, tt.wantErr, err)
 		})
 	}
@@ -446,10 +696,11 @@ func TestManager_sendMessage(t *testing.T) {
 					Send(
 						tgbotapi.MessageConfig{
 							BaseChat: tgbotapi.BaseChat{
-								ChatID:           100500,
-								ReplyToMessageID: 100,
+								ChatID:                   100500,
+								ReplyToMessageID:         100,
+								AllowSendingWithoutReply: true,
 							},
-							Text:                  "@username message text",
+							Text:                  "message text",
 							ParseMode:             "html",
 							DisableWebPagePreview: true,
 						},
@@ -467,6 +718,7 @@ func TestManager_sendMessage(t *testing.T) {
 						ID: 100500,
 					},
 					ReplyToMessage: &tgbotapi.Message{
+						Sticker:   &tgbotapi.Sticker{FileID: "spam"},
 						MessageID: 100,
 						From: &tgbotapi.User{
 							UserName: "username",
@@ -496,10 +748,11 @@ func TestManager_sendMessage(t *testing.T) {
 					Send(
 						tgbotapi.MessageConfig{
 							BaseChat: tgbotapi.BaseChat{
-								ChatID:           100500,
-								ReplyToMessageID: 100,
+								ChatID:                   100500,
+								ReplyToMessageID:         100,
+								AllowSendingWithoutReply: true,
 							},
-							Text:                  "<a href=\"tg://user?id=300600\">first last</a> message text",
+							Text:                  "message text",
 							ParseMode:             "html",
 							DisableWebPagePreview: true,
 						},
@@ -517,6 +770,7 @@ func TestManager_sendMessage(t *testing.T) {
 						ID: 100500,
 					},
 					ReplyToMessage: &tgbotapi.Message{
+						Sticker:   &tgbotapi.Sticker{FileID: "spam"},
 						MessageID: 100,
 						From: &tgbotapi.User{
 							ID:        300600,
@@ -535,7 +789,7 @@ func TestManager_sendMessage(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 
-			err := tt.man().sendMessage(tt.args.updateMsg, tt.args.message)
+			_, err := tt.man().sendMessage(tt.args.updateMsg, tt.args.message)
 			assert.Equal(t, tt.wantErr, err)
 		})
 	}
@@ -705,30 +959,602 @@ func Test_authorIsAdmin(t *testing.T) {
 	}
 }
 
-func Test_getMessageText(t *testing.T) {
+func TestManager_getCommand(t *testing.T) {
 	t.Parallel()
 
 	type args struct {
-		text string
+		message *tgbotapi.Message
 	}
 	tests := []struct {
-		name string
-		args args
-		want string
+		name     string
+		man      func() *Manager
+		args     args
+		wantName string
+		wantArgs string
+		wantOk   bool
 	}{
 		{
 			name: "Success",
+			man: func() *Manager {
+				return &Manager{
+					commands: laraCommands(t),
+				}
+			},
+			args: args{
+				message: &tgbotapi.Message{
+					Text: laraCmd,
+				},
+			},
+			wantName: "lara",
+			wantOk:   true,
+		},
+		{
+			name: "With bot username and arguments",
+			man: func() *Manager {
+				return &Manager{
+					commands:    laraCommands(t),
+					botUsername: "geeksonator_bot",
+				}
+			},
+			args: args{
+				message: &tgbotapi.Message{
+					Text: "/LARA@geeksonator_bot some words",
+					Entities: []tgbotapi.MessageEntity{
+						{Type: "bot_command", Offset: 0, Length: 21},
+					},
+				},
+			},
+			wantName: "lara",
+			wantArgs: "some words",
+			wantOk:   true,
+		},
+		{
+			name: "Unknown command",
+			man: func() *Manager {
+				return &Manager{
+					commands: unknownCommands(t),
+				}
+			},
 			args: args{
-				text: laraCmd,
+				message: &tgbotapi.Message{
+					Text: "/unknown",
+				},
 			},
-			want: laraTxt,
+			wantOk: false,
+		},
+		{
+			name: "Not a command",
+			man: func() *Manager {
+				return &Manager{}
+			},
+			args: args{
+				message: &tgbotapi.Message{
+					Text: "lara",
+				},
+			},
+			wantOk: false,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 
-			got := getMessageText(tt.args.text)
+			got, gotParsed, ok := tt.man().getCommand(tt.args.message)
+			assert.Equal(t, tt.wantOk, ok)
+			assert.Equal(t, tt.wantArgs, gotParsed.args)
+			if tt.wantOk {
+				assert.Equal(t, tt.wantName, got.Name)
+			}
+		})
+	}
+}
+
+func TestManager_suggestCommand(t *testing.T) {
+	t.Parallel()
+
+	const suggestionTxt = "Команда <code>/lar</code> не найдена. Возможно, вы имели в виду <code>/lara</code>?"
+
+	message := &tgbotapi.Message{
+		MessageID: 42,
+		Chat: &tgbotapi.Chat{
+			ID:    300600,
+			Type:  "supergroup",
+			Title: "PHP Geeks",
+		},
+		From: &tgbotapi.User{
+			ID: 100500,
+		},
+		Text: "/lar",
+	}
+
+	// suggestCommands returns commands mock suggesting /lara for /lar.
+	suggestCommands := func() *mocks.CommandsMock {
+		commands := mocks.NewCommandsMock(t)
+
+		commands.EXPECT().
+			Suggest(catalog.ChatRef{ID: 300600}, "lar", catalog.RoleAdmin).
+			Return("lara", true)
+
+		return commands
+	}
+
+	privateMsg := tgbotapi.MessageConfig{
+		BaseChat:  tgbotapi.BaseChat{ChatID: 100500},
+		Text:      suggestionTxt + "\nЧат: PHP Geeks",
+		ParseMode: "html",
+	}
+
+	tests := []struct {
+		name    string
+		man     func(deleted chan<- struct{}) *Manager
+		message *tgbotapi.Message
+		wantDel bool
+		wantErr bool
+	}{
+		{
+			name: "Sent privately",
+			man: func(chan<- struct{}) *Manager {
+				botProvider := mocks.NewBotProviderMock(t)
+
+				botProvider.EXPECT().
+					NewMessage(int64(100500), privateMsg.Text).
+					Return(tgbotapi.NewMessage(100500, privateMsg.Text))
+
+				botProvider.EXPECT().
+					Send(privateMsg).
+					Return(tgbotapi.Message{}, nil)
+
+				return &Manager{
+					bot:      botProvider,
+					cache:    adminsCache(t, 100500),
+					commands: suggestCommands(),
+				}
+			},
+			message: message,
+			wantDel: false,
+			wantErr: false,
+		},
+		{
+			name: "Self-deleting reply if the bot can't write privately",
+			man: func(deleted chan<- struct{}) *Manager {
+				botProvider := mocks.NewBotProviderMock(t)
+
+				botProvider.EXPECT().
+					NewMessage(int64(100500), privateMsg.Text).
+					Return(tgbotapi.NewMessage(100500, privateMsg.Text))
+
+				botProvider.EXPECT().
+					Send(privateMsg).
+					Return(tgbotapi.Message{}, errors.New("Forbidden: bot can't initiate conversation with a user"))
+
+				botProvider.EXPECT().
+					NewMessage(int64(300600), suggestionTxt).
+					Return(tgbotapi.NewMessage(300600, suggestionTxt))
+
+				botProvider.EXPECT().
+					Send(tgbotapi.MessageConfig{
+						BaseChat: tgbotapi.BaseChat{
+							ChatID:           300600,
+							ReplyToMessageID: 42,
+						},
+						Text:      suggestionTxt,
+						ParseMode: "html",
+					}).
+					Return(tgbotapi.Message{MessageID: 43}, nil)
+
+				botProvider.EXPECT().
+					DeleteMessage(int64(300600), 43).
+					Run(func(int64, int) {
+						close(deleted)
+					}).
+					Return(nil)
+
+				return &Manager{
+					bot:           botProvider,
+					cache:         adminsCache(t, 100500),
+					commands:      suggestCommands(),
+					suggestionTTL: time.Millisecond,
+				}
+			},
+			message: message,
+			wantDel: true,
+			wantErr: false,
+		},
+		{
+			name: "Reply deletion is scheduled",
+			man: func(chan<- struct{}) *Manager {
+				botProvider := mocks.NewBotProviderMock(t)
+				scheduler := mocks.NewSchedulerMock(t)
+
+				botProvider.EXPECT().
+					NewMessage(int64(100500), privateMsg.Text).
+					Return(tgbotapi.NewMessage(100500, privateMsg.Text))
+
+				botProvider.EXPECT().
+					Send(privateMsg).
+					Return(tgbotapi.Message{}, errors.New("Forbidden: bot can't initiate conversation with a user"))
+
+				botProvider.EXPECT().
+					NewMessage(int64(300600), suggestionTxt).
+					Return(tgbotapi.NewMessage(300600, suggestionTxt))
+
+				botProvider.EXPECT().
+					Send(tgbotapi.MessageConfig{
+						BaseChat: tgbotapi.BaseChat{
+							ChatID:           300600,
+							ReplyToMessageID: 42,
+						},
+						Text:      suggestionTxt,
+						ParseMode: "html",
+					}).
+					Return(tgbotapi.Message{MessageID: 43}, nil)
+
+				scheduler.EXPECT().
+					Schedule(int64(300600), 43, moderationNow.Add(defaultSuggestionTTL)).
+					Return(nil)
+
+				return &Manager{
+					bot:       botProvider,
+					cache:     adminsCache(t, 100500),
+					commands:  suggestCommands(),
+					scheduler: scheduler,
+					now:       func() time.Time { return moderationNow },
+				}
+			},
+			message: message,
+			wantDel: false,
+			wantErr: false,
+		},
+		{
+			name: "Chat reply failure is only logged",
+			man: func(chan<- struct{}) *Manager {
+				botProvider := mocks.NewBotProviderMock(t)
+
+				botProvider.EXPECT().
+					NewMessage(int64(100500), privateMsg.Text).
+					Return(tgbotapi.NewMessage(100500, privateMsg.Text))
+
+				botProvider.EXPECT().
+					Send(privateMsg).
+					Return(tgbotapi.Message{}, errors.New("Forbidden: bot can't initiate conversation with a user"))
+
+				botProvider.EXPECT().
+					NewMessage(int64(300600), suggestionTxt).
+					Return(tgbotapi.NewMessage(300600, suggestionTxt))
+
+				botProvider.EXPECT().
+					Send(tgbotapi.MessageConfig{
+						BaseChat: tgbotapi.BaseChat{
+							ChatID:           300600,
+							ReplyToMessageID: 42,
+						},
+						Text:      suggestionTxt,
+						ParseMode: "html",
+					}).
+					Return(tgbotapi.Message{}, errors.New("Bad Request: not enough rights to send text messages"))
+
+				return &Manager{
+					bot:      botProvider,
+					cache:    adminsCache(t, 100500),
+					commands: suggestCommands(),
+				}
+			},
+			message: message,
+			wantDel: false,
+			wantErr: false,
+		},
+		{
+			name: "Not an admin",
+			man: func(chan<- struct{}) *Manager {
+				return &Manager{
+					cache:    adminsCache(t, 100501),
+					commands: suggestCommands(),
+				}
+			},
+			message: message,
+			wantDel: false,
+			wantErr: false,
+		},
+		{
+			name: "No suggestion",
+			man: func(chan<- struct{}) *Manager {
+				commands := mocks.NewCommandsMock(t)
+
+				commands.EXPECT().
+					Suggest(catalog.ChatRef{ID: 300600}, "lar", catalog.RoleAdmin).
+					Return("", false)
+
+				return &Manager{
+					commands: commands,
+				}
+			},
+			message: message,
+			wantDel: false,
+			wantErr: false,
+		},
+		{
+			name: "Private chat",
+			man: func(chan<- struct{}) *Manager {
+				return &Manager{}
+			},
+			message: &tgbotapi.Message{
+				Chat: &tgbotapi.Chat{ID: 100500, Type: "private"},
+				From: &tgbotapi.User{ID: 100500},
+				Text: "/lar",
+			},
+			wantDel: false,
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			deleted := make(chan struct{})
+
+			err := tt.man(deleted).suggestCommand(tt.message)
+			assert.Equal(t, tt.wantErr, err != nil)
+
+			if tt.wantDel {
+				select {
+				case <-deleted:
+				case <-time.After(time.Second):
+					t.Error("suggestion is not deleted")
+				}
+			}
+		})
+	}
+}
+
+// templateCommands returns the catalog with templated responses.
+func templateCommands(t *testing.T) *catalog.Catalog {
+	t.Helper()
+
+	c, err := catalog.Parse([]byte(`version: 1
+commands:
+  - name: go
+    link: "@golangGeeks"
+    response: "{{.Target}}, ваш вопрос в {{chat \"go\"}}. {{.Caller}}, {{.Chat}}, {{.Args}}, {{.Date}}"
+  - name: php
+    response: "@phpGeeks"
+`), catalog.FormatYAML)
+	assert.NoError(t, err)
+
+	return c
+}
+
+func TestManager_getMessageText(t *testing.T) {
+	t.Parallel()
+
+	commands := templateCommands(t)
+	goCmd, _ := commands.Lookup("go")
+	phpCmd, _ := commands.Lookup("php")
+
+	type args struct {
+		req *request
+	}
+	tests := []struct {
+		name    string
+		man     func() *Manager
+		args    args
+		want    []string
+		wantErr error
+	}{
+		{
+			name: "Response",
+			man: func() *Manager {
+				return &Manager{}
+			},
+			args: args{
+				req: &request{
+					message: &tgbotapi.Message{},
+					cmd: &catalog.Command{
+						Name:     "lara",
+						Response: laraTxt,
+					},
+					role: catalog.RoleAdmin,
+				},
+			},
+			want: []string{laraTxt},
+		},
+		{
+			name: "Reply target with user name",
+			man: func() *Manager {
+				return &Manager{}
+			},
+			args: args{
+				req: &request{
+					message: &tgbotapi.Message{
+						ReplyToMessage: &tgbotapi.Message{
+							Sticker: &tgbotapi.Sticker{FileID: "spam"},
+							From: &tgbotapi.User{
+								UserName: "username",
+							},
+						},
+					},
+					cmd:  phpCmd,
+					role: catalog.RoleAdmin,
+				},
+			},
+			want: []string{"@username @phpGeeks"},
+		},
+		{
+			name: "Reply target with user id",
+			man: func() *Manager {
+				return &Manager{}
+			},
+			args: args{
+				req: &request{
+					message: &tgbotapi.Message{
+						ReplyToMessage: &tgbotapi.Message{
+							Sticker: &tgbotapi.Sticker{FileID: "spam"},
+							From: &tgbotapi.User{
+								ID:        300600,
+								FirstName: "first",
+								LastName:  "<last>",
+							},
+						},
+					},
+					cmd:  phpCmd,
+					role: catalog.RoleAdmin,
+				},
+			},
+			want: []string{`<a href="tg://user?id=300600">first &lt;last&gt;</a> @phpGeeks`},
+		},
+		{
+			name: "Template mentions the target itself",
+			man: func() *Manager {
+				return &Manager{
+					now: func() time.Time {
+						return time.Date(2024, 3, 8, 12, 0, 0, 0, time.UTC)
+					},
+				}
+			},
+			args: args{
+				req: &request{
+					message: &tgbotapi.Message{
+						From: &tgbotapi.User{
+							FirstName: "Admin",
+						},
+						Chat: &tgbotapi.Chat{
+							Title: "PHP & Geeks",
+						},
+						ReplyToMessage: &tgbotapi.Message{
+							Sticker: &tgbotapi.Sticker{FileID: "spam"},
+							From: &tgbotapi.User{
+								UserName: "username",
+							},
+						},
+					},
+					cmd:  goCmd,
+					args: "<b>goroutines</b>",
+					role: catalog.RoleAdmin,
+				},
+			},
+			want: []string{"@username, ваш вопрос в @golangGeeks. Admin, PHP &amp; Geeks, &lt;b&gt;goroutines&lt;/b&gt;, 08.03.2024"},
+		},
+		{
+			name: "Help",
+			man: func() *Manager {
+				commands := mocks.NewCommandsMock(t)
+
+				commands.EXPECT().
+					Help(catalog.ChatRef{}, catalog.RoleMember).
+					Return([]string{"help text"})
+
+				return &Manager{
+					commands: commands,
+				}
+			},
+			args: args{
+				req: &request{
+					message: &tgbotapi.Message{},
+					cmd: &catalog.Command{
+						Name:    "help",
+						Handler: catalog.HandlerHelp,
+					},
+					role: catalog.RoleMember,
+				},
+			},
+			want: []string{"help text"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := tt.man().getMessageText(tt.args.req)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
+
+func TestManager_getMessageText_LayoutFallback(t *testing.T) {
+	t.Parallel()
+
+	c, err := catalog.Parse([]byte(`version: 1
+commands:
+  - name: php
+    response: "@phpGeeks"
+  - name: lara
+    aliases: [лара]
+    response: "@laravel_pro"
+chats:
+  - id: 300600
+    layoutFallback: true
+`), catalog.FormatYAML)
+	assert.NoError(t, err)
+
+	m := &Manager{
+		commands: catalog.NewStore(c),
+	}
+
+	tests := []struct {
+		name   string
+		chatID int64
+		text   string
+		want   []string
+		wantOk bool
+	}{
+		{
+			name:   "Exact match",
+			chatID: 300600,
+			text:   "/php",
+			want:   []string{"@phpGeeks"},
+			wantOk: true,
+		},
+		{
+			name:   "ЙЦУКЕН to QWERTY",
+			chatID: 300600,
+			text:   "/зрз",
+			want:   []string{"@phpGeeks"},
+			wantOk: true,
+		},
+		{
+			name:   "QWERTY to ЙЦУКЕН",
+			chatID: 300600,
+			text:   "/kfhf",
+			want:   []string{"@laravel_pro"},
+			wantOk: true,
+		},
+		{
+			name:   "Unknown in both layouts",
+			chatID: 300600,
+			text:   "/зрзз",
+			wantOk: false,
+		},
+		{
+			name:   "Fallback is disabled in the chat",
+			chatID: 300601,
+			text:   "/зрз",
+			wantOk: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			message := &tgbotapi.Message{
+				Chat: &tgbotapi.Chat{
+					ID: tt.chatID,
+				},
+				Text: tt.text,
+			}
+
+			cmd, _, ok := m.getCommand(message)
+			assert.Equal(t, tt.wantOk, ok)
+
+			if !ok {
+				return
+			}
+
+			got, err := m.getMessageText(&request{
+				message: message,
+				cmd:     cmd,
+				role:    catalog.RoleAdmin,
+			})
+			assert.NoError(t, err)
 			assert.Equal(t, tt.want, got)
 		})
 	}
@@ -0,0 +1,78 @@
+// Code generated by mockery v2.36.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	audit "geeksonator/internal/audit"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// AuditorMock is an autogenerated mock type for the Auditor type
+type AuditorMock struct {
+	mock.Mock
+}
+
+type AuditorMock_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *AuditorMock) EXPECT() *AuditorMock_Expecter {
+	return &AuditorMock_Expecter{mock: &_m.Mock}
+}
+
+// Write provides a mock function with given fields: e
+func (_m *AuditorMock) Write(e audit.Event) error {
+	ret := _m.Called(e)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(audit.Event) error); ok {
+		r0 = rf(e)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AuditorMock_Write_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Write'
+type AuditorMock_Write_Call struct {
+	*mock.Call
+}
+
+// Write is a helper method to define mock.On call
+//   - e audit.Event
+func (_e *AuditorMock_Expecter) Write(e interface{}) *AuditorMock_Write_Call {
+	return &AuditorMock_Write_Call{Call: _e.mock.On("Write", e)}
+}
+
+func (_c *AuditorMock_Write_Call) Run(run func(e audit.Event)) *AuditorMock_Write_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(audit.Event))
+	})
+	return _c
+}
+
+func (_c *AuditorMock_Write_Call) Return(_a0 error) *AuditorMock_Write_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *AuditorMock_Write_Call) RunAndReturn(run func(audit.Event) error) *AuditorMock_Write_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewAuditorMock creates a new instance of AuditorMock. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAuditorMock(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AuditorMock {
+	mock := &AuditorMock{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
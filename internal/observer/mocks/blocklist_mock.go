@@ -0,0 +1,88 @@
+// Code generated by mockery v2.36.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	blocklist "geeksonator/internal/blocklist"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// BlocklistMock is an autogenerated mock type for the Blocklist type
+type BlocklistMock struct {
+	mock.Mock
+}
+
+type BlocklistMock_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *BlocklistMock) EXPECT() *BlocklistMock_Expecter {
+	return &BlocklistMock_Expecter{mock: &_m.Mock}
+}
+
+// Get provides a mock function with given fields: userID
+func (_m *BlocklistMock) Get(userID int64) (blocklist.Entry, bool) {
+	ret := _m.Called(userID)
+
+	var r0 blocklist.Entry
+	var r1 bool
+	if rf, ok := ret.Get(0).(func(int64) (blocklist.Entry, bool)); ok {
+		return rf(userID)
+	}
+	if rf, ok := ret.Get(0).(func(int64) blocklist.Entry); ok {
+		r0 = rf(userID)
+	} else {
+		r0 = ret.Get(0).(blocklist.Entry)
+	}
+
+	if rf, ok := ret.Get(1).(func(int64) bool); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	return r0, r1
+}
+
+// BlocklistMock_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type BlocklistMock_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - userID int64
+func (_e *BlocklistMock_Expecter) Get(userID interface{}) *BlocklistMock_Get_Call {
+	return &BlocklistMock_Get_Call{Call: _e.mock.On("Get", userID)}
+}
+
+func (_c *BlocklistMock_Get_Call) Run(run func(userID int64)) *BlocklistMock_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int64))
+	})
+	return _c
+}
+
+func (_c *BlocklistMock_Get_Call) Return(_a0 blocklist.Entry, _a1 bool) *BlocklistMock_Get_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *BlocklistMock_Get_Call) RunAndReturn(run func(int64) (blocklist.Entry, bool)) *BlocklistMock_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewBlocklistMock creates a new instance of BlocklistMock. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewBlocklistMock(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *BlocklistMock {
+	mock := &BlocklistMock{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -6,6 +6,8 @@ import (
 	mock "github.com/stretchr/testify/mock"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	time "time"
 )
 
 // BotProviderMock is an autogenerated mock type for the BotProvider type
@@ -21,6 +23,224 @@ func (_m *BotProviderMock) EXPECT() *BotProviderMock_Expecter {
 	return &BotProviderMock_Expecter{mock: &_m.Mock}
 }
 
+// AnswerCallbackQuery provides a mock function with given fields: callbackID, text
+func (_m *BotProviderMock) AnswerCallbackQuery(callbackID string, text string) error {
+	ret := _m.Called(callbackID, text)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(callbackID, text)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// BotProviderMock_AnswerCallbackQuery_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AnswerCallbackQuery'
+type BotProviderMock_AnswerCallbackQuery_Call struct {
+	*mock.Call
+}
+
+// AnswerCallbackQuery is a helper method to define mock.On call
+//   - callbackID string
+//   - text string
+func (_e *BotProviderMock_Expecter) AnswerCallbackQuery(callbackID interface{}, text interface{}) *BotProviderMock_AnswerCallbackQuery_Call {
+	return &BotProviderMock_AnswerCallbackQuery_Call{Call: _e.mock.On("AnswerCallbackQuery", callbackID, text)}
+}
+
+func (_c *BotProviderMock_AnswerCallbackQuery_Call) Run(run func(callbackID string, text string)) *BotProviderMock_AnswerCallbackQuery_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *BotProviderMock_AnswerCallbackQuery_Call) Return(_a0 error) *BotProviderMock_AnswerCallbackQuery_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *BotProviderMock_AnswerCallbackQuery_Call) RunAndReturn(run func(string, string) error) *BotProviderMock_AnswerCallbackQuery_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// BanChatMember provides a mock function with given fields: chatID, userID, untilDate, revokeMessages
+func (_m *BotProviderMock) BanChatMember(chatID int64, userID int64, untilDate time.Time, revokeMessages bool) error {
+	ret := _m.Called(chatID, userID, untilDate, revokeMessages)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int64, int64, time.Time, bool) error); ok {
+		r0 = rf(chatID, userID, untilDate, revokeMessages)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// BotProviderMock_BanChatMember_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BanChatMember'
+type BotProviderMock_BanChatMember_Call struct {
+	*mock.Call
+}
+
+// BanChatMember is a helper method to define mock.On call
+//   - chatID int64
+//   - userID int64
+//   - untilDate time.Time
+//   - revokeMessages bool
+func (_e *BotProviderMock_Expecter) BanChatMember(chatID interface{}, userID interface{}, untilDate interface{}, revokeMessages interface{}) *BotProviderMock_BanChatMember_Call {
+	return &BotProviderMock_BanChatMember_Call{Call: _e.mock.On("BanChatMember", chatID, userID, untilDate, revokeMessages)}
+}
+
+func (_c *BotProviderMock_BanChatMember_Call) Run(run func(chatID int64, userID int64, untilDate time.Time, revokeMessages bool)) *BotProviderMock_BanChatMember_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int64), args[1].(int64), args[2].(time.Time), args[3].(bool))
+	})
+	return _c
+}
+
+func (_c *BotProviderMock_BanChatMember_Call) Return(_a0 error) *BotProviderMock_BanChatMember_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *BotProviderMock_BanChatMember_Call) RunAndReturn(run func(int64, int64, time.Time, bool) error) *BotProviderMock_BanChatMember_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeclineChatJoinRequest provides a mock function with given fields: chatID, userID
+func (_m *BotProviderMock) DeclineChatJoinRequest(chatID int64, userID int64) error {
+	ret := _m.Called(chatID, userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int64, int64) error); ok {
+		r0 = rf(chatID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// BotProviderMock_DeclineChatJoinRequest_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeclineChatJoinRequest'
+type BotProviderMock_DeclineChatJoinRequest_Call struct {
+	*mock.Call
+}
+
+// DeclineChatJoinRequest is a helper method to define mock.On call
+//   - chatID int64
+//   - userID int64
+func (_e *BotProviderMock_Expecter) DeclineChatJoinRequest(chatID interface{}, userID interface{}) *BotProviderMock_DeclineChatJoinRequest_Call {
+	return &BotProviderMock_DeclineChatJoinRequest_Call{Call: _e.mock.On("DeclineChatJoinRequest", chatID, userID)}
+}
+
+func (_c *BotProviderMock_DeclineChatJoinRequest_Call) Run(run func(chatID int64, userID int64)) *BotProviderMock_DeclineChatJoinRequest_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int64), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *BotProviderMock_DeclineChatJoinRequest_Call) Return(_a0 error) *BotProviderMock_DeclineChatJoinRequest_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *BotProviderMock_DeclineChatJoinRequest_Call) RunAndReturn(run func(int64, int64) error) *BotProviderMock_DeclineChatJoinRequest_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteMessage provides a mock function with given fields: chatID, messageID
+func (_m *BotProviderMock) DeleteMessage(chatID int64, messageID int) error {
+	ret := _m.Called(chatID, messageID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int64, int) error); ok {
+		r0 = rf(chatID, messageID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// BotProviderMock_DeleteMessage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteMessage'
+type BotProviderMock_DeleteMessage_Call struct {
+	*mock.Call
+}
+
+// DeleteMessage is a helper method to define mock.On call
+//   - chatID int64
+//   - messageID int
+func (_e *BotProviderMock_Expecter) DeleteMessage(chatID interface{}, messageID interface{}) *BotProviderMock_DeleteMessage_Call {
+	return &BotProviderMock_DeleteMessage_Call{Call: _e.mock.On("DeleteMessage", chatID, messageID)}
+}
+
+func (_c *BotProviderMock_DeleteMessage_Call) Run(run func(chatID int64, messageID int)) *BotProviderMock_DeleteMessage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int64), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *BotProviderMock_DeleteMessage_Call) Return(_a0 error) *BotProviderMock_DeleteMessage_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *BotProviderMock_DeleteMessage_Call) RunAndReturn(run func(int64, int) error) *BotProviderMock_DeleteMessage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EditMessageText provides a mock function with given fields: chatID, messageID, text
+func (_m *BotProviderMock) EditMessageText(chatID int64, messageID int, text string) error {
+	ret := _m.Called(chatID, messageID, text)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int64, int, string) error); ok {
+		r0 = rf(chatID, messageID, text)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// BotProviderMock_EditMessageText_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EditMessageText'
+type BotProviderMock_EditMessageText_Call struct {
+	*mock.Call
+}
+
+// EditMessageText is a helper method to define mock.On call
+//   - chatID int64
+//   - messageID int
+//   - text string
+func (_e *BotProviderMock_Expecter) EditMessageText(chatID interface{}, messageID interface{}, text interface{}) *BotProviderMock_EditMessageText_Call {
+	return &BotProviderMock_EditMessageText_Call{Call: _e.mock.On("EditMessageText", chatID, messageID, text)}
+}
+
+func (_c *BotProviderMock_EditMessageText_Call) Run(run func(chatID int64, messageID int, text string)) *BotProviderMock_EditMessageText_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int64), args[1].(int), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *BotProviderMock_EditMessageText_Call) Return(_a0 error) *BotProviderMock_EditMessageText_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *BotProviderMock_EditMessageText_Call) RunAndReturn(run func(int64, int, string) error) *BotProviderMock_EditMessageText_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetChatAdministrators provides a mock function with given fields: chatConfig
 func (_m *BotProviderMock) GetChatAdministrators(chatConfig tgbotapi.ChatConfig) ([]tgbotapi.ChatMember, error) {
 	ret := _m.Called(chatConfig)
@@ -118,6 +338,51 @@ func (_c *BotProviderMock_NewMessage_Call) RunAndReturn(run func(int64, string)
 	return _c
 }
 
+// RestrictChatMember provides a mock function with given fields: chatID, userID, permissions, untilDate
+func (_m *BotProviderMock) RestrictChatMember(chatID int64, userID int64, permissions tgbotapi.ChatPermissions, untilDate time.Time) error {
+	ret := _m.Called(chatID, userID, permissions, untilDate)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int64, int64, tgbotapi.ChatPermissions, time.Time) error); ok {
+		r0 = rf(chatID, userID, permissions, untilDate)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// BotProviderMock_RestrictChatMember_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RestrictChatMember'
+type BotProviderMock_RestrictChatMember_Call struct {
+	*mock.Call
+}
+
+// RestrictChatMember is a helper method to define mock.On call
+//   - chatID int64
+//   - userID int64
+//   - permissions tgbotapi.ChatPermissions
+//   - untilDate time.Time
+func (_e *BotProviderMock_Expecter) RestrictChatMember(chatID interface{}, userID interface{}, permissions interface{}, untilDate interface{}) *BotProviderMock_RestrictChatMember_Call {
+	return &BotProviderMock_RestrictChatMember_Call{Call: _e.mock.On("RestrictChatMember", chatID, userID, permissions, untilDate)}
+}
+
+func (_c *BotProviderMock_RestrictChatMember_Call) Run(run func(chatID int64, userID int64, permissions tgbotapi.ChatPermissions, untilDate time.Time)) *BotProviderMock_RestrictChatMember_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int64), args[1].(int64), args[2].(tgbotapi.ChatPermissions), args[3].(time.Time))
+	})
+	return _c
+}
+
+func (_c *BotProviderMock_RestrictChatMember_Call) Return(_a0 error) *BotProviderMock_RestrictChatMember_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *BotProviderMock_RestrictChatMember_Call) RunAndReturn(run func(int64, int64, tgbotapi.ChatPermissions, time.Time) error) *BotProviderMock_RestrictChatMember_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Send provides a mock function with given fields: c
 func (_m *BotProviderMock) Send(c tgbotapi.Chattable) (tgbotapi.Message, error) {
 	ret := _m.Called(c)
@@ -170,6 +435,49 @@ func (_c *BotProviderMock_Send_Call) RunAndReturn(run func(tgbotapi.Chattable) (
 	return _c
 }
 
+// UnbanChatMember provides a mock function with given fields: chatID, userID
+func (_m *BotProviderMock) UnbanChatMember(chatID int64, userID int64) error {
+	ret := _m.Called(chatID, userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int64, int64) error); ok {
+		r0 = rf(chatID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// BotProviderMock_UnbanChatMember_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UnbanChatMember'
+type BotProviderMock_UnbanChatMember_Call struct {
+	*mock.Call
+}
+
+// UnbanChatMember is a helper method to define mock.On call
+//   - chatID int64
+//   - userID int64
+func (_e *BotProviderMock_Expecter) UnbanChatMember(chatID interface{}, userID interface{}) *BotProviderMock_UnbanChatMember_Call {
+	return &BotProviderMock_UnbanChatMember_Call{Call: _e.mock.On("UnbanChatMember", chatID, userID)}
+}
+
+func (_c *BotProviderMock_UnbanChatMember_Call) Run(run func(chatID int64, userID int64)) *BotProviderMock_UnbanChatMember_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int64), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *BotProviderMock_UnbanChatMember_Call) Return(_a0 error) *BotProviderMock_UnbanChatMember_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *BotProviderMock_UnbanChatMember_Call) RunAndReturn(run func(int64, int64) error) *BotProviderMock_UnbanChatMember_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewBotProviderMock creates a new instance of BotProviderMock. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewBotProviderMock(t interface {
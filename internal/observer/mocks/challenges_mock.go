@@ -0,0 +1,164 @@
+// Code generated by mockery v2.36.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	captcha "geeksonator/internal/captcha"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ChallengesMock is an autogenerated mock type for the Challenges type
+type ChallengesMock struct {
+	mock.Mock
+}
+
+type ChallengesMock_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *ChallengesMock) EXPECT() *ChallengesMock_Expecter {
+	return &ChallengesMock_Expecter{mock: &_m.Mock}
+}
+
+// Add provides a mock function with given fields: p
+func (_m *ChallengesMock) Add(p captcha.Pending) error {
+	ret := _m.Called(p)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(captcha.Pending) error); ok {
+		r0 = rf(p)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ChallengesMock_Add_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Add'
+type ChallengesMock_Add_Call struct {
+	*mock.Call
+}
+
+// Add is a helper method to define mock.On call
+//   - p captcha.Pending
+func (_e *ChallengesMock_Expecter) Add(p interface{}) *ChallengesMock_Add_Call {
+	return &ChallengesMock_Add_Call{Call: _e.mock.On("Add", p)}
+}
+
+func (_c *ChallengesMock_Add_Call) Run(run func(p captcha.Pending)) *ChallengesMock_Add_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(captcha.Pending))
+	})
+	return _c
+}
+
+func (_c *ChallengesMock_Add_Call) Return(_a0 error) *ChallengesMock_Add_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ChallengesMock_Add_Call) RunAndReturn(run func(captcha.Pending) error) *ChallengesMock_Add_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// List provides a mock function with given fields:
+func (_m *ChallengesMock) List() []captcha.Pending {
+	ret := _m.Called()
+
+	var r0 []captcha.Pending
+	if rf, ok := ret.Get(0).(func() []captcha.Pending); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]captcha.Pending)
+		}
+	}
+
+	return r0
+}
+
+// ChallengesMock_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type ChallengesMock_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+func (_e *ChallengesMock_Expecter) List() *ChallengesMock_List_Call {
+	return &ChallengesMock_List_Call{Call: _e.mock.On("List")}
+}
+
+func (_c *ChallengesMock_List_Call) Run(run func()) *ChallengesMock_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *ChallengesMock_List_Call) Return(_a0 []captcha.Pending) *ChallengesMock_List_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ChallengesMock_List_Call) RunAndReturn(run func() []captcha.Pending) *ChallengesMock_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Remove provides a mock function with given fields: chatID, userID
+func (_m *ChallengesMock) Remove(chatID int64, userID int64) error {
+	ret := _m.Called(chatID, userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int64, int64) error); ok {
+		r0 = rf(chatID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ChallengesMock_Remove_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Remove'
+type ChallengesMock_Remove_Call struct {
+	*mock.Call
+}
+
+// Remove is a helper method to define mock.On call
+//   - chatID int64
+//   - userID int64
+func (_e *ChallengesMock_Expecter) Remove(chatID interface{}, userID interface{}) *ChallengesMock_Remove_Call {
+	return &ChallengesMock_Remove_Call{Call: _e.mock.On("Remove", chatID, userID)}
+}
+
+func (_c *ChallengesMock_Remove_Call) Run(run func(chatID int64, userID int64)) *ChallengesMock_Remove_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int64), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *ChallengesMock_Remove_Call) Return(_a0 error) *ChallengesMock_Remove_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ChallengesMock_Remove_Call) RunAndReturn(run func(int64, int64) error) *ChallengesMock_Remove_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewChallengesMock creates a new instance of ChallengesMock. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewChallengesMock(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ChallengesMock {
+	mock := &ChallengesMock{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
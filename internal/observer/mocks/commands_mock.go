@@ -0,0 +1,700 @@
+// Code generated by mockery v2.36.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	catalog "geeksonator/internal/catalog"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// CommandsMock is an autogenerated mock type for the Commands type
+type CommandsMock struct {
+	mock.Mock
+}
+
+type CommandsMock_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *CommandsMock) EXPECT() *CommandsMock_Expecter {
+	return &CommandsMock_Expecter{mock: &_m.Mock}
+}
+
+// Audit provides a mock function with given fields: chat
+func (_m *CommandsMock) Audit(chat catalog.ChatRef) *catalog.Audit {
+	ret := _m.Called(chat)
+
+	var r0 *catalog.Audit
+	if rf, ok := ret.Get(0).(func(catalog.ChatRef) *catalog.Audit); ok {
+		r0 = rf(chat)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*catalog.Audit)
+		}
+	}
+
+	return r0
+}
+
+// CommandsMock_Audit_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Audit'
+type CommandsMock_Audit_Call struct {
+	*mock.Call
+}
+
+// Audit is a helper method to define mock.On call
+//   - chat catalog.ChatRef
+func (_e *CommandsMock_Expecter) Audit(chat interface{}) *CommandsMock_Audit_Call {
+	return &CommandsMock_Audit_Call{Call: _e.mock.On("Audit", chat)}
+}
+
+func (_c *CommandsMock_Audit_Call) Run(run func(chat catalog.ChatRef)) *CommandsMock_Audit_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(catalog.ChatRef))
+	})
+	return _c
+}
+
+func (_c *CommandsMock_Audit_Call) Return(_a0 *catalog.Audit) *CommandsMock_Audit_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *CommandsMock_Audit_Call) RunAndReturn(run func(catalog.ChatRef) *catalog.Audit) *CommandsMock_Audit_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Captcha provides a mock function with given fields: chat
+func (_m *CommandsMock) Captcha(chat catalog.ChatRef) (*catalog.Captcha, bool) {
+	ret := _m.Called(chat)
+
+	var r0 *catalog.Captcha
+	var r1 bool
+	if rf, ok := ret.Get(0).(func(catalog.ChatRef) (*catalog.Captcha, bool)); ok {
+		return rf(chat)
+	}
+	if rf, ok := ret.Get(0).(func(catalog.ChatRef) *catalog.Captcha); ok {
+		r0 = rf(chat)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*catalog.Captcha)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(catalog.ChatRef) bool); ok {
+		r1 = rf(chat)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	return r0, r1
+}
+
+// CommandsMock_Captcha_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Captcha'
+type CommandsMock_Captcha_Call struct {
+	*mock.Call
+}
+
+// Captcha is a helper method to define mock.On call
+//   - chat catalog.ChatRef
+func (_e *CommandsMock_Expecter) Captcha(chat interface{}) *CommandsMock_Captcha_Call {
+	return &CommandsMock_Captcha_Call{Call: _e.mock.On("Captcha", chat)}
+}
+
+func (_c *CommandsMock_Captcha_Call) Run(run func(chat catalog.ChatRef)) *CommandsMock_Captcha_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(catalog.ChatRef))
+	})
+	return _c
+}
+
+func (_c *CommandsMock_Captcha_Call) Return(_a0 *catalog.Captcha, _a1 bool) *CommandsMock_Captcha_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *CommandsMock_Captcha_Call) RunAndReturn(run func(catalog.ChatRef) (*catalog.Captcha, bool)) *CommandsMock_Captcha_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Chat provides a mock function with given fields: chat
+func (_m *CommandsMock) Chat(chat catalog.ChatRef) (*catalog.Chat, bool) {
+	ret := _m.Called(chat)
+
+	var r0 *catalog.Chat
+	var r1 bool
+	if rf, ok := ret.Get(0).(func(catalog.ChatRef) (*catalog.Chat, bool)); ok {
+		return rf(chat)
+	}
+	if rf, ok := ret.Get(0).(func(catalog.ChatRef) *catalog.Chat); ok {
+		r0 = rf(chat)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*catalog.Chat)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(catalog.ChatRef) bool); ok {
+		r1 = rf(chat)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	return r0, r1
+}
+
+// CommandsMock_Chat_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Chat'
+type CommandsMock_Chat_Call struct {
+	*mock.Call
+}
+
+// Chat is a helper method to define mock.On call
+//   - chat catalog.ChatRef
+func (_e *CommandsMock_Expecter) Chat(chat interface{}) *CommandsMock_Chat_Call {
+	return &CommandsMock_Chat_Call{Call: _e.mock.On("Chat", chat)}
+}
+
+func (_c *CommandsMock_Chat_Call) Run(run func(chat catalog.ChatRef)) *CommandsMock_Chat_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(catalog.ChatRef))
+	})
+	return _c
+}
+
+func (_c *CommandsMock_Chat_Call) Return(_a0 *catalog.Chat, _a1 bool) *CommandsMock_Chat_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *CommandsMock_Chat_Call) RunAndReturn(run func(catalog.ChatRef) (*catalog.Chat, bool)) *CommandsMock_Chat_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Federation provides a mock function with given fields: chat
+func (_m *CommandsMock) Federation(chat catalog.ChatRef) (*catalog.Federation, bool) {
+	ret := _m.Called(chat)
+
+	var r0 *catalog.Federation
+	var r1 bool
+	if rf, ok := ret.Get(0).(func(catalog.ChatRef) (*catalog.Federation, bool)); ok {
+		return rf(chat)
+	}
+	if rf, ok := ret.Get(0).(func(catalog.ChatRef) *catalog.Federation); ok {
+		r0 = rf(chat)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*catalog.Federation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(catalog.ChatRef) bool); ok {
+		r1 = rf(chat)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	return r0, r1
+}
+
+// CommandsMock_Federation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Federation'
+type CommandsMock_Federation_Call struct {
+	*mock.Call
+}
+
+// Federation is a helper method to define mock.On call
+//   - chat catalog.ChatRef
+func (_e *CommandsMock_Expecter) Federation(chat interface{}) *CommandsMock_Federation_Call {
+	return &CommandsMock_Federation_Call{Call: _e.mock.On("Federation", chat)}
+}
+
+func (_c *CommandsMock_Federation_Call) Run(run func(chat catalog.ChatRef)) *CommandsMock_Federation_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(catalog.ChatRef))
+	})
+	return _c
+}
+
+func (_c *CommandsMock_Federation_Call) Return(_a0 *catalog.Federation, _a1 bool) *CommandsMock_Federation_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *CommandsMock_Federation_Call) RunAndReturn(run func(catalog.ChatRef) (*catalog.Federation, bool)) *CommandsMock_Federation_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Flood provides a mock function with given fields: chat
+func (_m *CommandsMock) Flood(chat catalog.ChatRef) (*catalog.Flood, bool) {
+	ret := _m.Called(chat)
+
+	var r0 *catalog.Flood
+	var r1 bool
+	if rf, ok := ret.Get(0).(func(catalog.ChatRef) (*catalog.Flood, bool)); ok {
+		return rf(chat)
+	}
+	if rf, ok := ret.Get(0).(func(catalog.ChatRef) *catalog.Flood); ok {
+		r0 = rf(chat)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*catalog.Flood)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(catalog.ChatRef) bool); ok {
+		r1 = rf(chat)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	return r0, r1
+}
+
+// CommandsMock_Flood_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Flood'
+type CommandsMock_Flood_Call struct {
+	*mock.Call
+}
+
+// Flood is a helper method to define mock.On call
+//   - chat catalog.ChatRef
+func (_e *CommandsMock_Expecter) Flood(chat interface{}) *CommandsMock_Flood_Call {
+	return &CommandsMock_Flood_Call{Call: _e.mock.On("Flood", chat)}
+}
+
+func (_c *CommandsMock_Flood_Call) Run(run func(chat catalog.ChatRef)) *CommandsMock_Flood_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(catalog.ChatRef))
+	})
+	return _c
+}
+
+func (_c *CommandsMock_Flood_Call) Return(_a0 *catalog.Flood, _a1 bool) *CommandsMock_Flood_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *CommandsMock_Flood_Call) RunAndReturn(run func(catalog.ChatRef) (*catalog.Flood, bool)) *CommandsMock_Flood_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Help provides a mock function with given fields: chat, role
+func (_m *CommandsMock) Help(chat catalog.ChatRef, role catalog.Role) []string {
+	ret := _m.Called(chat, role)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(catalog.ChatRef, catalog.Role) []string); ok {
+		r0 = rf(chat, role)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	return r0
+}
+
+// CommandsMock_Help_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Help'
+type CommandsMock_Help_Call struct {
+	*mock.Call
+}
+
+// Help is a helper method to define mock.On call
+//   - chat catalog.ChatRef
+//   - role catalog.Role
+func (_e *CommandsMock_Expecter) Help(chat interface{}, role interface{}) *CommandsMock_Help_Call {
+	return &CommandsMock_Help_Call{Call: _e.mock.On("Help", chat, role)}
+}
+
+func (_c *CommandsMock_Help_Call) Run(run func(chat catalog.ChatRef, role catalog.Role)) *CommandsMock_Help_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(catalog.ChatRef), args[1].(catalog.Role))
+	})
+	return _c
+}
+
+func (_c *CommandsMock_Help_Call) Return(_a0 []string) *CommandsMock_Help_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *CommandsMock_Help_Call) RunAndReturn(run func(catalog.ChatRef, catalog.Role) []string) *CommandsMock_Help_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Jobs provides a mock function with given fields: chat
+func (_m *CommandsMock) Jobs(chat catalog.ChatRef) (*catalog.Jobs, bool) {
+	ret := _m.Called(chat)
+
+	var r0 *catalog.Jobs
+	var r1 bool
+	if rf, ok := ret.Get(0).(func(catalog.ChatRef) (*catalog.Jobs, bool)); ok {
+		return rf(chat)
+	}
+	if rf, ok := ret.Get(0).(func(catalog.ChatRef) *catalog.Jobs); ok {
+		r0 = rf(chat)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*catalog.Jobs)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(catalog.ChatRef) bool); ok {
+		r1 = rf(chat)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	return r0, r1
+}
+
+// CommandsMock_Jobs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Jobs'
+type CommandsMock_Jobs_Call struct {
+	*mock.Call
+}
+
+// Jobs is a helper method to define mock.On call
+//   - chat catalog.ChatRef
+func (_e *CommandsMock_Expecter) Jobs(chat interface{}) *CommandsMock_Jobs_Call {
+	return &CommandsMock_Jobs_Call{Call: _e.mock.On("Jobs", chat)}
+}
+
+func (_c *CommandsMock_Jobs_Call) Run(run func(chat catalog.ChatRef)) *CommandsMock_Jobs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(catalog.ChatRef))
+	})
+	return _c
+}
+
+func (_c *CommandsMock_Jobs_Call) Return(_a0 *catalog.Jobs, _a1 bool) *CommandsMock_Jobs_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *CommandsMock_Jobs_Call) RunAndReturn(run func(catalog.ChatRef) (*catalog.Jobs, bool)) *CommandsMock_Jobs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Lookup provides a mock function with given fields: chat, name
+func (_m *CommandsMock) Lookup(chat catalog.ChatRef, name string) (*catalog.Command, bool) {
+	ret := _m.Called(chat, name)
+
+	var r0 *catalog.Command
+	var r1 bool
+	if rf, ok := ret.Get(0).(func(catalog.ChatRef, string) (*catalog.Command, bool)); ok {
+		return rf(chat, name)
+	}
+	if rf, ok := ret.Get(0).(func(catalog.ChatRef, string) *catalog.Command); ok {
+		r0 = rf(chat, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*catalog.Command)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(catalog.ChatRef, string) bool); ok {
+		r1 = rf(chat, name)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	return r0, r1
+}
+
+// CommandsMock_Lookup_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Lookup'
+type CommandsMock_Lookup_Call struct {
+	*mock.Call
+}
+
+// Lookup is a helper method to define mock.On call
+//   - chat catalog.ChatRef
+//   - name string
+func (_e *CommandsMock_Expecter) Lookup(chat interface{}, name interface{}) *CommandsMock_Lookup_Call {
+	return &CommandsMock_Lookup_Call{Call: _e.mock.On("Lookup", chat, name)}
+}
+
+func (_c *CommandsMock_Lookup_Call) Run(run func(chat catalog.ChatRef, name string)) *CommandsMock_Lookup_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(catalog.ChatRef), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *CommandsMock_Lookup_Call) Return(_a0 *catalog.Command, _a1 bool) *CommandsMock_Lookup_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *CommandsMock_Lookup_Call) RunAndReturn(run func(catalog.ChatRef, string) (*catalog.Command, bool)) *CommandsMock_Lookup_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Newcomers provides a mock function with given fields: chat
+func (_m *CommandsMock) Newcomers(chat catalog.ChatRef) (*catalog.Newcomers, bool) {
+	ret := _m.Called(chat)
+
+	var r0 *catalog.Newcomers
+	var r1 bool
+	if rf, ok := ret.Get(0).(func(catalog.ChatRef) (*catalog.Newcomers, bool)); ok {
+		return rf(chat)
+	}
+	if rf, ok := ret.Get(0).(func(catalog.ChatRef) *catalog.Newcomers); ok {
+		r0 = rf(chat)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*catalog.Newcomers)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(catalog.ChatRef) bool); ok {
+		r1 = rf(chat)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	return r0, r1
+}
+
+// CommandsMock_Newcomers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Newcomers'
+type CommandsMock_Newcomers_Call struct {
+	*mock.Call
+}
+
+// Newcomers is a helper method to define mock.On call
+//   - chat catalog.ChatRef
+func (_e *CommandsMock_Expecter) Newcomers(chat interface{}) *CommandsMock_Newcomers_Call {
+	return &CommandsMock_Newcomers_Call{Call: _e.mock.On("Newcomers", chat)}
+}
+
+func (_c *CommandsMock_Newcomers_Call) Run(run func(chat catalog.ChatRef)) *CommandsMock_Newcomers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(catalog.ChatRef))
+	})
+	return _c
+}
+
+func (_c *CommandsMock_Newcomers_Call) Return(_a0 *catalog.Newcomers, _a1 bool) *CommandsMock_Newcomers_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *CommandsMock_Newcomers_Call) RunAndReturn(run func(catalog.ChatRef) (*catalog.Newcomers, bool)) *CommandsMock_Newcomers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Posts provides a mock function with given fields: chat
+func (_m *CommandsMock) Posts(chat catalog.ChatRef) (*catalog.Posts, bool) {
+	ret := _m.Called(chat)
+
+	var r0 *catalog.Posts
+	var r1 bool
+	if rf, ok := ret.Get(0).(func(catalog.ChatRef) (*catalog.Posts, bool)); ok {
+		return rf(chat)
+	}
+	if rf, ok := ret.Get(0).(func(catalog.ChatRef) *catalog.Posts); ok {
+		r0 = rf(chat)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*catalog.Posts)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(catalog.ChatRef) bool); ok {
+		r1 = rf(chat)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	return r0, r1
+}
+
+// CommandsMock_Posts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Posts'
+type CommandsMock_Posts_Call struct {
+	*mock.Call
+}
+
+// Posts is a helper method to define mock.On call
+//   - chat catalog.ChatRef
+func (_e *CommandsMock_Expecter) Posts(chat interface{}) *CommandsMock_Posts_Call {
+	return &CommandsMock_Posts_Call{Call: _e.mock.On("Posts", chat)}
+}
+
+func (_c *CommandsMock_Posts_Call) Run(run func(chat catalog.ChatRef)) *CommandsMock_Posts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(catalog.ChatRef))
+	})
+	return _c
+}
+
+func (_c *CommandsMock_Posts_Call) Return(_a0 *catalog.Posts, _a1 bool) *CommandsMock_Posts_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *CommandsMock_Posts_Call) RunAndReturn(run func(catalog.ChatRef) (*catalog.Posts, bool)) *CommandsMock_Posts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Reports provides a mock function with given fields: chat
+func (_m *CommandsMock) Reports(chat catalog.ChatRef) *catalog.Reports {
+	ret := _m.Called(chat)
+
+	var r0 *catalog.Reports
+	if rf, ok := ret.Get(0).(func(catalog.ChatRef) *catalog.Reports); ok {
+		r0 = rf(chat)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*catalog.Reports)
+		}
+	}
+
+	return r0
+}
+
+// CommandsMock_Reports_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Reports'
+type CommandsMock_Reports_Call struct {
+	*mock.Call
+}
+
+// Reports is a helper method to define mock.On call
+//   - chat catalog.ChatRef
+func (_e *CommandsMock_Expecter) Reports(chat interface{}) *CommandsMock_Reports_Call {
+	return &CommandsMock_Reports_Call{Call: _e.mock.On("Reports", chat)}
+}
+
+func (_c *CommandsMock_Reports_Call) Run(run func(chat catalog.ChatRef)) *CommandsMock_Reports_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(catalog.ChatRef))
+	})
+	return _c
+}
+
+func (_c *CommandsMock_Reports_Call) Return(_a0 *catalog.Reports) *CommandsMock_Reports_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *CommandsMock_Reports_Call) RunAndReturn(run func(catalog.ChatRef) *catalog.Reports) *CommandsMock_Reports_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Suggest provides a mock function with given fields: chat, name, role
+func (_m *CommandsMock) Suggest(chat catalog.ChatRef, name string, role catalog.Role) (string, bool) {
+	ret := _m.Called(chat, name, role)
+
+	var r0 string
+	var r1 bool
+	if rf, ok := ret.Get(0).(func(catalog.ChatRef, string, catalog.Role) (string, bool)); ok {
+		return rf(chat, name, role)
+	}
+	if rf, ok := ret.Get(0).(func(catalog.ChatRef, string, catalog.Role) string); ok {
+		r0 = rf(chat, name, role)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(catalog.ChatRef, string, catalog.Role) bool); ok {
+		r1 = rf(chat, name, role)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	return r0, r1
+}
+
+// CommandsMock_Suggest_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Suggest'
+type CommandsMock_Suggest_Call struct {
+	*mock.Call
+}
+
+// Suggest is a helper method to define mock.On call
+//   - chat catalog.ChatRef
+//   - name string
+//   - role catalog.Role
+func (_e *CommandsMock_Expecter) Suggest(chat interface{}, name interface{}, role interface{}) *CommandsMock_Suggest_Call {
+	return &CommandsMock_Suggest_Call{Call: _e.mock.On("Suggest", chat, name, role)}
+}
+
+func (_c *CommandsMock_Suggest_Call) Run(run func(chat catalog.ChatRef, name string, role catalog.Role)) *CommandsMock_Suggest_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(catalog.ChatRef), args[1].(string), args[2].(catalog.Role))
+	})
+	return _c
+}
+
+func (_c *CommandsMock_Suggest_Call) Return(_a0 string, _a1 bool) *CommandsMock_Suggest_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *CommandsMock_Suggest_Call) RunAndReturn(run func(catalog.ChatRef, string, catalog.Role) (string, bool)) *CommandsMock_Suggest_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Warnings provides a mock function with given fields: chat
+func (_m *CommandsMock) Warnings(chat catalog.ChatRef) *catalog.Warnings {
+	ret := _m.Called(chat)
+
+	var r0 *catalog.Warnings
+	if rf, ok := ret.Get(0).(func(catalog.ChatRef) *catalog.Warnings); ok {
+		r0 = rf(chat)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*catalog.Warnings)
+		}
+	}
+
+	return r0
+}
+
+// CommandsMock_Warnings_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Warnings'
+type CommandsMock_Warnings_Call struct {
+	*mock.Call
+}
+
+// Warnings is a helper method to define mock.On call
+//   - chat catalog.ChatRef
+func (_e *CommandsMock_Expecter) Warnings(chat interface{}) *CommandsMock_Warnings_Call {
+	return &CommandsMock_Warnings_Call{Call: _e.mock.On("Warnings", chat)}
+}
+
+func (_c *CommandsMock_Warnings_Call) Run(run func(chat catalog.ChatRef)) *CommandsMock_Warnings_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(catalog.ChatRef))
+	})
+	return _c
+}
+
+func (_c *CommandsMock_Warnings_Call) Return(_a0 *catalog.Warnings) *CommandsMock_Warnings_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *CommandsMock_Warnings_Call) RunAndReturn(run func(catalog.ChatRef) *catalog.Warnings) *CommandsMock_Warnings_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewCommandsMock creates a new instance of CommandsMock. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewCommandsMock(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *CommandsMock {
+	mock := &CommandsMock{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
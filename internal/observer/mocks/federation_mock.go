@@ -0,0 +1,183 @@
+// Code generated by mockery v2.36.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	federation "geeksonator/internal/federation"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// FederationMock is an autogenerated mock type for the Federation type
+type FederationMock struct {
+	mock.Mock
+}
+
+type FederationMock_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *FederationMock) EXPECT() *FederationMock_Expecter {
+	return &FederationMock_Expecter{mock: &_m.Mock}
+}
+
+// Ban provides a mock function with given fields: userID, ban
+func (_m *FederationMock) Ban(userID int64, ban federation.Ban) error {
+	ret := _m.Called(userID, ban)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int64, federation.Ban) error); ok {
+		r0 = rf(userID, ban)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FederationMock_Ban_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Ban'
+type FederationMock_Ban_Call struct {
+	*mock.Call
+}
+
+// Ban is a helper method to define mock.On call
+//   - userID int64
+//   - ban federation.Ban
+func (_e *FederationMock_Expecter) Ban(userID interface{}, ban interface{}) *FederationMock_Ban_Call {
+	return &FederationMock_Ban_Call{Call: _e.mock.On("Ban", userID, ban)}
+}
+
+func (_c *FederationMock_Ban_Call) Run(run func(userID int64, ban federation.Ban)) *FederationMock_Ban_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int64), args[1].(federation.Ban))
+	})
+	return _c
+}
+
+func (_c *FederationMock_Ban_Call) Return(_a0 error) *FederationMock_Ban_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *FederationMock_Ban_Call) RunAndReturn(run func(int64, federation.Ban) error) *FederationMock_Ban_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Get provides a mock function with given fields: userID
+func (_m *FederationMock) Get(userID int64) (federation.Ban, bool) {
+	ret := _m.Called(userID)
+
+	var r0 federation.Ban
+	var r1 bool
+	if rf, ok := ret.Get(0).(func(int64) (federation.Ban, bool)); ok {
+		return rf(userID)
+	}
+	if rf, ok := ret.Get(0).(func(int64) federation.Ban); ok {
+		r0 = rf(userID)
+	} else {
+		r0 = ret.Get(0).(federation.Ban)
+	}
+
+	if rf, ok := ret.Get(1).(func(int64) bool); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	return r0, r1
+}
+
+// FederationMock_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type FederationMock_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - userID int64
+func (_e *FederationMock_Expecter) Get(userID interface{}) *FederationMock_Get_Call {
+	return &FederationMock_Get_Call{Call: _e.mock.On("Get", userID)}
+}
+
+func (_c *FederationMock_Get_Call) Run(run func(userID int64)) *FederationMock_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int64))
+	})
+	return _c
+}
+
+func (_c *FederationMock_Get_Call) Return(_a0 federation.Ban, _a1 bool) *FederationMock_Get_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *FederationMock_Get_Call) RunAndReturn(run func(int64) (federation.Ban, bool)) *FederationMock_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Unban provides a mock function with given fields: userID
+func (_m *FederationMock) Unban(userID int64) (bool, error) {
+	ret := _m.Called(userID)
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64) (bool, error)); ok {
+		return rf(userID)
+	}
+	if rf, ok := ret.Get(0).(func(int64) bool); ok {
+		r0 = rf(userID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FederationMock_Unban_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Unban'
+type FederationMock_Unban_Call struct {
+	*mock.Call
+}
+
+// Unban is a helper method to define mock.On call
+//   - userID int64
+func (_e *FederationMock_Expecter) Unban(userID interface{}) *FederationMock_Unban_Call {
+	return &FederationMock_Unban_Call{Call: _e.mock.On("Unban", userID)}
+}
+
+func (_c *FederationMock_Unban_Call) Run(run func(userID int64)) *FederationMock_Unban_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int64))
+	})
+	return _c
+}
+
+func (_c *FederationMock_Unban_Call) Return(_a0 bool, _a1 error) *FederationMock_Unban_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *FederationMock_Unban_Call) RunAndReturn(run func(int64) (bool, error)) *FederationMock_Unban_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewFederationMock creates a new instance of FederationMock. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewFederationMock(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *FederationMock {
+	mock := &FederationMock{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
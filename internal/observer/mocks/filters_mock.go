@@ -0,0 +1,239 @@
+// Code generated by mockery v2.36.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	filters "geeksonator/internal/filters"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// FiltersMock is an autogenerated mock type for the Filters type
+type FiltersMock struct {
+	mock.Mock
+}
+
+type FiltersMock_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *FiltersMock) EXPECT() *FiltersMock_Expecter {
+	return &FiltersMock_Expecter{mock: &_m.Mock}
+}
+
+// Add provides a mock function with given fields: chatID, rule
+func (_m *FiltersMock) Add(chatID int64, rule filters.Rule) (filters.Rule, error) {
+	ret := _m.Called(chatID, rule)
+
+	var r0 filters.Rule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64, filters.Rule) (filters.Rule, error)); ok {
+		return rf(chatID, rule)
+	}
+	if rf, ok := ret.Get(0).(func(int64, filters.Rule) filters.Rule); ok {
+		r0 = rf(chatID, rule)
+	} else {
+		r0 = ret.Get(0).(filters.Rule)
+	}
+
+	if rf, ok := ret.Get(1).(func(int64, filters.Rule) error); ok {
+		r1 = rf(chatID, rule)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FiltersMock_Add_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Add'
+type FiltersMock_Add_Call struct {
+	*mock.Call
+}
+
+// Add is a helper method to define mock.On call
+//   - chatID int64
+//   - rule filters.Rule
+func (_e *FiltersMock_Expecter) Add(chatID interface{}, rule interface{}) *FiltersMock_Add_Call {
+	return &FiltersMock_Add_Call{Call: _e.mock.On("Add", chatID, rule)}
+}
+
+func (_c *FiltersMock_Add_Call) Run(run func(chatID int64, rule filters.Rule)) *FiltersMock_Add_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int64), args[1].(filters.Rule))
+	})
+	return _c
+}
+
+func (_c *FiltersMock_Add_Call) Return(_a0 filters.Rule, _a1 error) *FiltersMock_Add_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *FiltersMock_Add_Call) RunAndReturn(run func(int64, filters.Rule) (filters.Rule, error)) *FiltersMock_Add_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Match provides a mock function with given fields: chatID, text
+func (_m *FiltersMock) Match(chatID int64, text string) (filters.Rule, bool) {
+	ret := _m.Called(chatID, text)
+
+	var r0 filters.Rule
+	var r1 bool
+	if rf, ok := ret.Get(0).(func(int64, string) (filters.Rule, bool)); ok {
+		return rf(chatID, text)
+	}
+	if rf, ok := ret.Get(0).(func(int64, string) filters.Rule); ok {
+		r0 = rf(chatID, text)
+	} else {
+		r0 = ret.Get(0).(filters.Rule)
+	}
+
+	if rf, ok := ret.Get(1).(func(int64, string) bool); ok {
+		r1 = rf(chatID, text)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	return r0, r1
+}
+
+// FiltersMock_Match_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Match'
+type FiltersMock_Match_Call struct {
+	*mock.Call
+}
+
+// Match is a helper method to define mock.On call
+//   - chatID int64
+//   - text string
+func (_e *FiltersMock_Expecter) Match(chatID interface{}, text interface{}) *FiltersMock_Match_Call {
+	return &FiltersMock_Match_Call{Call: _e.mock.On("Match", chatID, text)}
+}
+
+func (_c *FiltersMock_Match_Call) Run(run func(chatID int64, text string)) *FiltersMock_Match_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int64), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *FiltersMock_Match_Call) Return(_a0 filters.Rule, _a1 bool) *FiltersMock_Match_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *FiltersMock_Match_Call) RunAndReturn(run func(int64, string) (filters.Rule, bool)) *FiltersMock_Match_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Remove provides a mock function with given fields: chatID, id
+func (_m *FiltersMock) Remove(chatID int64, id int) (bool, error) {
+	ret := _m.Called(chatID, id)
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64, int) (bool, error)); ok {
+		return rf(chatID, id)
+	}
+	if rf, ok := ret.Get(0).(func(int64, int) bool); ok {
+		r0 = rf(chatID, id)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(int64, int) error); ok {
+		r1 = rf(chatID, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FiltersMock_Remove_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Remove'
+type FiltersMock_Remove_Call struct {
+	*mock.Call
+}
+
+// Remove is a helper method to define mock.On call
+//   - chatID int64
+//   - id int
+func (_e *FiltersMock_Expecter) Remove(chatID interface{}, id interface{}) *FiltersMock_Remove_Call {
+	return &FiltersMock_Remove_Call{Call: _e.mock.On("Remove", chatID, id)}
+}
+
+func (_c *FiltersMock_Remove_Call) Run(run func(chatID int64, id int)) *FiltersMock_Remove_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int64), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *FiltersMock_Remove_Call) Return(_a0 bool, _a1 error) *FiltersMock_Remove_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *FiltersMock_Remove_Call) RunAndReturn(run func(int64, int) (bool, error)) *FiltersMock_Remove_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Rules provides a mock function with given fields: chatID
+func (_m *FiltersMock) Rules(chatID int64) []filters.Rule {
+	ret := _m.Called(chatID)
+
+	var r0 []filters.Rule
+	if rf, ok := ret.Get(0).(func(int64) []filters.Rule); ok {
+		r0 = rf(chatID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]filters.Rule)
+		}
+	}
+
+	return r0
+}
+
+// FiltersMock_Rules_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Rules'
+type FiltersMock_Rules_Call struct {
+	*mock.Call
+}
+
+// Rules is a helper method to define mock.On call
+//   - chatID int64
+func (_e *FiltersMock_Expecter) Rules(chatID interface{}) *FiltersMock_Rules_Call {
+	return &FiltersMock_Rules_Call{Call: _e.mock.On("Rules", chatID)}
+}
+
+func (_c *FiltersMock_Rules_Call) Run(run func(chatID int64)) *FiltersMock_Rules_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int64))
+	})
+	return _c
+}
+
+func (_c *FiltersMock_Rules_Call) Return(_a0 []filters.Rule) *FiltersMock_Rules_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *FiltersMock_Rules_Call) RunAndReturn(run func(int64) []filters.Rule) *FiltersMock_Rules_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewFiltersMock creates a new instance of FiltersMock. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewFiltersMock(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *FiltersMock {
+	mock := &FiltersMock{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,82 @@
+// Code generated by mockery v2.36.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// FloodLimiterMock is an autogenerated mock type for the FloodLimiter type
+type FloodLimiterMock struct {
+	mock.Mock
+}
+
+type FloodLimiterMock_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *FloodLimiterMock) EXPECT() *FloodLimiterMock_Expecter {
+	return &FloodLimiterMock_Expecter{mock: &_m.Mock}
+}
+
+// Allow provides a mock function with given fields: chatID, userID, at, limit, window
+func (_m *FloodLimiterMock) Allow(chatID int64, userID int64, at time.Time, limit int, window time.Duration) bool {
+	ret := _m.Called(chatID, userID, at, limit, window)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(int64, int64, time.Time, int, time.Duration) bool); ok {
+		r0 = rf(chatID, userID, at, limit, window)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// FloodLimiterMock_Allow_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Allow'
+type FloodLimiterMock_Allow_Call struct {
+	*mock.Call
+}
+
+// Allow is a helper method to define mock.On call
+//   - chatID int64
+//   - userID int64
+//   - at time.Time
+//   - limit int
+//   - window time.Duration
+func (_e *FloodLimiterMock_Expecter) Allow(chatID interface{}, userID interface{}, at interface{}, limit interface{}, window interface{}) *FloodLimiterMock_Allow_Call {
+	return &FloodLimiterMock_Allow_Call{Call: _e.mock.On("Allow", chatID, userID, at, limit, window)}
+}
+
+func (_c *FloodLimiterMock_Allow_Call) Run(run func(chatID int64, userID int64, at time.Time, limit int, window time.Duration)) *FloodLimiterMock_Allow_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int64), args[1].(int64), args[2].(time.Time), args[3].(int), args[4].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *FloodLimiterMock_Allow_Call) Return(_a0 bool) *FloodLimiterMock_Allow_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *FloodLimiterMock_Allow_Call) RunAndReturn(run func(int64, int64, time.Time, int, time.Duration) bool) *FloodLimiterMock_Allow_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewFloodLimiterMock creates a new instance of FloodLimiterMock. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewFloodLimiterMock(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *FloodLimiterMock {
+	mock := &FloodLimiterMock{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,264 @@
+// Code generated by mockery v2.36.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	members "geeksonator/internal/members"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// MembersMock is an autogenerated mock type for the Members type
+type MembersMock struct {
+	mock.Mock
+}
+
+type MembersMock_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MembersMock) EXPECT() *MembersMock_Expecter {
+	return &MembersMock_Expecter{mock: &_m.Mock}
+}
+
+// CountMessage provides a mock function with given fields: chatID, userID
+func (_m *MembersMock) CountMessage(chatID int64, userID int64) error {
+	ret := _m.Called(chatID, userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int64, int64) error); ok {
+		r0 = rf(chatID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MembersMock_CountMessage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountMessage'
+type MembersMock_CountMessage_Call struct {
+	*mock.Call
+}
+
+// CountMessage is a helper method to define mock.On call
+//   - chatID int64
+//   - userID int64
+func (_e *MembersMock_Expecter) CountMessage(chatID interface{}, userID interface{}) *MembersMock_CountMessage_Call {
+	return &MembersMock_CountMessage_Call{Call: _e.mock.On("CountMessage", chatID, userID)}
+}
+
+func (_c *MembersMock_CountMessage_Call) Run(run func(chatID int64, userID int64)) *MembersMock_CountMessage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int64), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *MembersMock_CountMessage_Call) Return(_a0 error) *MembersMock_CountMessage_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MembersMock_CountMessage_Call) RunAndReturn(run func(int64, int64) error) *MembersMock_CountMessage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Get provides a mock function with given fields: chatID, userID
+func (_m *MembersMock) Get(chatID int64, userID int64) (members.Member, bool) {
+	ret := _m.Called(chatID, userID)
+
+	var r0 members.Member
+	var r1 bool
+	if rf, ok := ret.Get(0).(func(int64, int64) (members.Member, bool)); ok {
+		return rf(chatID, userID)
+	}
+	if rf, ok := ret.Get(0).(func(int64, int64) members.Member); ok {
+		r0 = rf(chatID, userID)
+	} else {
+		r0 = ret.Get(0).(members.Member)
+	}
+
+	if rf, ok := ret.Get(1).(func(int64, int64) bool); ok {
+		r1 = rf(chatID, userID)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	return r0, r1
+}
+
+// MembersMock_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type MembersMock_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - chatID int64
+//   - userID int64
+func (_e *MembersMock_Expecter) Get(chatID interface{}, userID interface{}) *MembersMock_Get_Call {
+	return &MembersMock_Get_Call{Call: _e.mock.On("Get", chatID, userID)}
+}
+
+func (_c *MembersMock_Get_Call) Run(run func(chatID int64, userID int64)) *MembersMock_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int64), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *MembersMock_Get_Call) Return(_a0 members.Member, _a1 bool) *MembersMock_Get_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MembersMock_Get_Call) RunAndReturn(run func(int64, int64) (members.Member, bool)) *MembersMock_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Join provides a mock function with given fields: chatID, userID, at
+func (_m *MembersMock) Join(chatID int64, userID int64, at time.Time) error {
+	ret := _m.Called(chatID, userID, at)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int64, int64, time.Time) error); ok {
+		r0 = rf(chatID, userID, at)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MembersMock_Join_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Join'
+type MembersMock_Join_Call struct {
+	*mock.Call
+}
+
+// Join is a helper method to define mock.On call
+//   - chatID int64
+//   - userID int64
+//   - at time.Time
+func (_e *MembersMock_Expecter) Join(chatID interface{}, userID interface{}, at interface{}) *MembersMock_Join_Call {
+	return &MembersMock_Join_Call{Call: _e.mock.On("Join", chatID, userID, at)}
+}
+
+func (_c *MembersMock_Join_Call) Run(run func(chatID int64, userID int64, at time.Time)) *MembersMock_Join_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int64), args[1].(int64), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MembersMock_Join_Call) Return(_a0 error) *MembersMock_Join_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MembersMock_Join_Call) RunAndReturn(run func(int64, int64, time.Time) error) *MembersMock_Join_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MarkWarned provides a mock function with given fields: chatID, userID
+func (_m *MembersMock) MarkWarned(chatID int64, userID int64) error {
+	ret := _m.Called(chatID, userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int64, int64) error); ok {
+		r0 = rf(chatID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MembersMock_MarkWarned_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkWarned'
+type MembersMock_MarkWarned_Call struct {
+	*mock.Call
+}
+
+// MarkWarned is a helper method to define mock.On call
+//   - chatID int64
+//   - userID int64
+func (_e *MembersMock_Expecter) MarkWarned(chatID interface{}, userID interface{}) *MembersMock_MarkWarned_Call {
+	return &MembersMock_MarkWarned_Call{Call: _e.mock.On("MarkWarned", chatID, userID)}
+}
+
+func (_c *MembersMock_MarkWarned_Call) Run(run func(chatID int64, userID int64)) *MembersMock_MarkWarned_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int64), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *MembersMock_MarkWarned_Call) Return(_a0 error) *MembersMock_MarkWarned_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MembersMock_MarkWarned_Call) RunAndReturn(run func(int64, int64) error) *MembersMock_MarkWarned_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Remove provides a mock function with given fields: chatID, userID
+func (_m *MembersMock) Remove(chatID int64, userID int64) error {
+	ret := _m.Called(chatID, userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int64, int64) error); ok {
+		r0 = rf(chatID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MembersMock_Remove_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Remove'
+type MembersMock_Remove_Call struct {
+	*mock.Call
+}
+
+// Remove is a helper method to define mock.On call
+//   - chatID int64
+//   - userID int64
+func (_e *MembersMock_Expecter) Remove(chatID interface{}, userID interface{}) *MembersMock_Remove_Call {
+	return &MembersMock_Remove_Call{Call: _e.mock.On("Remove", chatID, userID)}
+}
+
+func (_c *MembersMock_Remove_Call) Run(run func(chatID int64, userID int64)) *MembersMock_Remove_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int64), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *MembersMock_Remove_Call) Return(_a0 error) *MembersMock_Remove_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MembersMock_Remove_Call) RunAndReturn(run func(int64, int64) error) *MembersMock_Remove_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMembersMock creates a new instance of MembersMock. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMembersMock(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MembersMock {
+	mock := &MembersMock{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,127 @@
+// Code generated by mockery v2.36.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	mock "github.com/stretchr/testify/mock"
+
+	posts "geeksonator/internal/posts"
+
+	time "time"
+)
+
+// PostsMock is an autogenerated mock type for the Posts type
+type PostsMock struct {
+	mock.Mock
+}
+
+type PostsMock_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *PostsMock) EXPECT() *PostsMock_Expecter {
+	return &PostsMock_Expecter{mock: &_m.Mock}
+}
+
+// Add provides a mock function with given fields: chatID, post, since
+func (_m *PostsMock) Add(chatID int64, post posts.Post, since time.Time) error {
+	ret := _m.Called(chatID, post, since)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int64, posts.Post, time.Time) error); ok {
+		r0 = rf(chatID, post, since)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// PostsMock_Add_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Add'
+type PostsMock_Add_Call struct {
+	*mock.Call
+}
+
+// Add is a helper method to define mock.On call
+//   - chatID int64
+//   - post posts.Post
+//   - since time.Time
+func (_e *PostsMock_Expecter) Add(chatID interface{}, post interface{}, since interface{}) *PostsMock_Add_Call {
+	return &PostsMock_Add_Call{Call: _e.mock.On("Add", chatID, post, since)}
+}
+
+func (_c *PostsMock_Add_Call) Run(run func(chatID int64, post posts.Post, since time.Time)) *PostsMock_Add_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int64), args[1].(posts.Post), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *PostsMock_Add_Call) Return(_a0 error) *PostsMock_Add_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *PostsMock_Add_Call) RunAndReturn(run func(int64, posts.Post, time.Time) error) *PostsMock_Add_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Recent provides a mock function with given fields: chatID, since
+func (_m *PostsMock) Recent(chatID int64, since time.Time) []posts.Post {
+	ret := _m.Called(chatID, since)
+
+	var r0 []posts.Post
+	if rf, ok := ret.Get(0).(func(int64, time.Time) []posts.Post); ok {
+		r0 = rf(chatID, since)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]posts.Post)
+		}
+	}
+
+	return r0
+}
+
+// PostsMock_Recent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Recent'
+type PostsMock_Recent_Call struct {
+	*mock.Call
+}
+
+// Recent is a helper method to define mock.On call
+//   - chatID int64
+//   - since time.Time
+func (_e *PostsMock_Expecter) Recent(chatID interface{}, since interface{}) *PostsMock_Recent_Call {
+	return &PostsMock_Recent_Call{Call: _e.mock.On("Recent", chatID, since)}
+}
+
+func (_c *PostsMock_Recent_Call) Run(run func(chatID int64, since time.Time)) *PostsMock_Recent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int64), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *PostsMock_Recent_Call) Return(_a0 []posts.Post) *PostsMock_Recent_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *PostsMock_Recent_Call) RunAndReturn(run func(int64, time.Time) []posts.Post) *PostsMock_Recent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewPostsMock creates a new instance of PostsMock. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewPostsMock(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *PostsMock {
+	mock := &PostsMock{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
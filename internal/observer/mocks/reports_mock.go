@@ -0,0 +1,162 @@
+// Code generated by mockery v2.36.0. DO NOT EDIT.
+
+package mocks
+
+import mock "github.com/stretchr/testify/mock"
+
+// ReportsMock is an autogenerated mock type for the Reports type
+type ReportsMock struct {
+	mock.Mock
+}
+
+type ReportsMock_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *ReportsMock) EXPECT() *ReportsMock_Expecter {
+	return &ReportsMock_Expecter{mock: &_m.Mock}
+}
+
+// Subscribe provides a mock function with given fields: chatID, userID
+func (_m *ReportsMock) Subscribe(chatID int64, userID int64) error {
+	ret := _m.Called(chatID, userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int64, int64) error); ok {
+		r0 = rf(chatID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ReportsMock_Subscribe_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Subscribe'
+type ReportsMock_Subscribe_Call struct {
+	*mock.Call
+}
+
+// Subscribe is a helper method to define mock.On call
+//   - chatID int64
+//   - userID int64
+func (_e *ReportsMock_Expecter) Subscribe(chatID interface{}, userID interface{}) *ReportsMock_Subscribe_Call {
+	return &ReportsMock_Subscribe_Call{Call: _e.mock.On("Subscribe", chatID, userID)}
+}
+
+func (_c *ReportsMock_Subscribe_Call) Run(run func(chatID int64, userID int64)) *ReportsMock_Subscribe_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int64), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *ReportsMock_Subscribe_Call) Return(_a0 error) *ReportsMock_Subscribe_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ReportsMock_Subscribe_Call) RunAndReturn(run func(int64, int64) error) *ReportsMock_Subscribe_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Subscribers provides a mock function with given fields: chatID
+func (_m *ReportsMock) Subscribers(chatID int64) []int64 {
+	ret := _m.Called(chatID)
+
+	var r0 []int64
+	if rf, ok := ret.Get(0).(func(int64) []int64); ok {
+		r0 = rf(chatID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]int64)
+		}
+	}
+
+	return r0
+}
+
+// ReportsMock_Subscribers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Subscribers'
+type ReportsMock_Subscribers_Call struct {
+	*mock.Call
+}
+
+// Subscribers is a helper method to define mock.On call
+//   - chatID int64
+func (_e *ReportsMock_Expecter) Subscribers(chatID interface{}) *ReportsMock_Subscribers_Call {
+	return &ReportsMock_Subscribers_Call{Call: _e.mock.On("Subscribers", chatID)}
+}
+
+func (_c *ReportsMock_Subscribers_Call) Run(run func(chatID int64)) *ReportsMock_Subscribers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int64))
+	})
+	return _c
+}
+
+func (_c *ReportsMock_Subscribers_Call) Return(_a0 []int64) *ReportsMock_Subscribers_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ReportsMock_Subscribers_Call) RunAndReturn(run func(int64) []int64) *ReportsMock_Subscribers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Unsubscribe provides a mock function with given fields: chatID, userID
+func (_m *ReportsMock) Unsubscribe(chatID int64, userID int64) error {
+	ret := _m.Called(chatID, userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int64, int64) error); ok {
+		r0 = rf(chatID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ReportsMock_Unsubscribe_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Unsubscribe'
+type ReportsMock_Unsubscribe_Call struct {
+	*mock.Call
+}
+
+// Unsubscribe is a helper method to define mock.On call
+//   - chatID int64
+//   - userID int64
+func (_e *ReportsMock_Expecter) Unsubscribe(chatID interface{}, userID interface{}) *ReportsMock_Unsubscribe_Call {
+	return &ReportsMock_Unsubscribe_Call{Call: _e.mock.On("Unsubscribe", chatID, userID)}
+}
+
+func (_c *ReportsMock_Unsubscribe_Call) Run(run func(chatID int64, userID int64)) *ReportsMock_Unsubscribe_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int64), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *ReportsMock_Unsubscribe_Call) Return(_a0 error) *ReportsMock_Unsubscribe_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ReportsMock_Unsubscribe_Call) RunAndReturn(run func(int64, int64) error) *ReportsMock_Unsubscribe_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewReportsMock creates a new instance of ReportsMock. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewReportsMock(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ReportsMock {
+	mock := &ReportsMock{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
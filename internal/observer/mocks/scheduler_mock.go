@@ -0,0 +1,123 @@
+// Code generated by mockery v2.36.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// SchedulerMock is an autogenerated mock type for the Scheduler type
+type SchedulerMock struct {
+	mock.Mock
+}
+
+type SchedulerMock_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *SchedulerMock) EXPECT() *SchedulerMock_Expecter {
+	return &SchedulerMock_Expecter{mock: &_m.Mock}
+}
+
+// Cancel provides a mock function with given fields: chatID, messageID
+func (_m *SchedulerMock) Cancel(chatID int64, messageID int) error {
+	ret := _m.Called(chatID, messageID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int64, int) error); ok {
+		r0 = rf(chatID, messageID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SchedulerMock_Cancel_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Cancel'
+type SchedulerMock_Cancel_Call struct {
+	*mock.Call
+}
+
+// Cancel is a helper method to define mock.On call
+//   - chatID int64
+//   - messageID int
+func (_e *SchedulerMock_Expecter) Cancel(chatID interface{}, messageID interface{}) *SchedulerMock_Cancel_Call {
+	return &SchedulerMock_Cancel_Call{Call: _e.mock.On("Cancel", chatID, messageID)}
+}
+
+func (_c *SchedulerMock_Cancel_Call) Run(run func(chatID int64, messageID int)) *SchedulerMock_Cancel_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int64), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *SchedulerMock_Cancel_Call) Return(_a0 error) *SchedulerMock_Cancel_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *SchedulerMock_Cancel_Call) RunAndReturn(run func(int64, int) error) *SchedulerMock_Cancel_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Schedule provides a mock function with given fields: chatID, messageID, at
+func (_m *SchedulerMock) Schedule(chatID int64, messageID int, at time.Time) error {
+	ret := _m.Called(chatID, messageID, at)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int64, int, time.Time) error); ok {
+		r0 = rf(chatID, messageID, at)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SchedulerMock_Schedule_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Schedule'
+type SchedulerMock_Schedule_Call struct {
+	*mock.Call
+}
+
+// Schedule is a helper method to define mock.On call
+//   - chatID int64
+//   - messageID int
+//   - at time.Time
+func (_e *SchedulerMock_Expecter) Schedule(chatID interface{}, messageID interface{}, at interface{}) *SchedulerMock_Schedule_Call {
+	return &SchedulerMock_Schedule_Call{Call: _e.mock.On("Schedule", chatID, messageID, at)}
+}
+
+func (_c *SchedulerMock_Schedule_Call) Run(run func(chatID int64, messageID int, at time.Time)) *SchedulerMock_Schedule_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int64), args[1].(int), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *SchedulerMock_Schedule_Call) Return(_a0 error) *SchedulerMock_Schedule_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *SchedulerMock_Schedule_Call) RunAndReturn(run func(int64, int, time.Time) error) *SchedulerMock_Schedule_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewSchedulerMock creates a new instance of SchedulerMock. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewSchedulerMock(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *SchedulerMock {
+	mock := &SchedulerMock{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,247 @@
+// Code generated by mockery v2.36.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+
+	warnings "geeksonator/internal/warnings"
+)
+
+// WarningsMock is an autogenerated mock type for the Warnings type
+type WarningsMock struct {
+	mock.Mock
+}
+
+type WarningsMock_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *WarningsMock) EXPECT() *WarningsMock_Expecter {
+	return &WarningsMock_Expecter{mock: &_m.Mock}
+}
+
+// Add provides a mock function with given fields: chatID, userID, w, since
+func (_m *WarningsMock) Add(chatID int64, userID int64, w warnings.Warning, since time.Time) ([]warnings.Warning, error) {
+	ret := _m.Called(chatID, userID, w, since)
+
+	var r0 []warnings.Warning
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64, int64, warnings.Warning, time.Time) ([]warnings.Warning, error)); ok {
+		return rf(chatID, userID, w, since)
+	}
+	if rf, ok := ret.Get(0).(func(int64, int64, warnings.Warning, time.Time) []warnings.Warning); ok {
+		r0 = rf(chatID, userID, w, since)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]warnings.Warning)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int64, int64, warnings.Warning, time.Time) error); ok {
+		r1 = rf(chatID, userID, w, since)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WarningsMock_Add_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Add'
+type WarningsMock_Add_Call struct {
+	*mock.Call
+}
+
+// Add is a helper method to define mock.On call
+//   - chatID int64
+//   - userID int64
+//   - w warnings.Warning
+//   - since time.Time
+func (_e *WarningsMock_Expecter) Add(chatID interface{}, userID interface{}, w interface{}, since interface{}) *WarningsMock_Add_Call {
+	return &WarningsMock_Add_Call{Call: _e.mock.On("Add", chatID, userID, w, since)}
+}
+
+func (_c *WarningsMock_Add_Call) Run(run func(chatID int64, userID int64, w warnings.Warning, since time.Time)) *WarningsMock_Add_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int64), args[1].(int64), args[2].(warnings.Warning), args[3].(time.Time))
+	})
+	return _c
+}
+
+func (_c *WarningsMock_Add_Call) Return(_a0 []warnings.Warning, _a1 error) *WarningsMock_Add_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *WarningsMock_Add_Call) RunAndReturn(run func(int64, int64, warnings.Warning, time.Time) ([]warnings.Warning, error)) *WarningsMock_Add_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// List provides a mock function with given fields: chatID, userID, since
+func (_m *WarningsMock) List(chatID int64, userID int64, since time.Time) []warnings.Warning {
+	ret := _m.Called(chatID, userID, since)
+
+	var r0 []warnings.Warning
+	if rf, ok := ret.Get(0).(func(int64, int64, time.Time) []warnings.Warning); ok {
+		r0 = rf(chatID, userID, since)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]warnings.Warning)
+		}
+	}
+
+	return r0
+}
+
+// WarningsMock_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type WarningsMock_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - chatID int64
+//   - userID int64
+//   - since time.Time
+func (_e *WarningsMock_Expecter) List(chatID interface{}, userID interface{}, since interface{}) *WarningsMock_List_Call {
+	return &WarningsMock_List_Call{Call: _e.mock.On("List", chatID, userID, since)}
+}
+
+func (_c *WarningsMock_List_Call) Run(run func(chatID int64, userID int64, since time.Time)) *WarningsMock_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int64), args[1].(int64), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *WarningsMock_List_Call) Return(_a0 []warnings.Warning) *WarningsMock_List_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *WarningsMock_List_Call) RunAndReturn(run func(int64, int64, time.Time) []warnings.Warning) *WarningsMock_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RemoveLast provides a mock function with given fields: chatID, userID, since
+func (_m *WarningsMock) RemoveLast(chatID int64, userID int64, since time.Time) ([]warnings.Warning, bool, error) {
+	ret := _m.Called(chatID, userID, since)
+
+	var r0 []warnings.Warning
+	var r1 bool
+	var r2 error
+	if rf, ok := ret.Get(0).(func(int64, int64, time.Time) ([]warnings.Warning, bool, error)); ok {
+		return rf(chatID, userID, since)
+	}
+	if rf, ok := ret.Get(0).(func(int64, int64, time.Time) []warnings.Warning); ok {
+		r0 = rf(chatID, userID, since)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]warnings.Warning)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int64, int64, time.Time) bool); ok {
+		r1 = rf(chatID, userID, since)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	if rf, ok := ret.Get(2).(func(int64, int64, time.Time) error); ok {
+		r2 = rf(chatID, userID, since)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// WarningsMock_RemoveLast_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveLast'
+type WarningsMock_RemoveLast_Call struct {
+	*mock.Call
+}
+
+// RemoveLast is a helper method to define mock.On call
+//   - chatID int64
+//   - userID int64
+//   - since time.Time
+func (_e *WarningsMock_Expecter) RemoveLast(chatID interface{}, userID interface{}, since interface{}) *WarningsMock_RemoveLast_Call {
+	return &WarningsMock_RemoveLast_Call{Call: _e.mock.On("RemoveLast", chatID, userID, since)}
+}
+
+func (_c *WarningsMock_RemoveLast_Call) Run(run func(chatID int64, userID int64, since time.Time)) *WarningsMock_RemoveLast_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int64), args[1].(int64), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *WarningsMock_RemoveLast_Call) Return(_a0 []warnings.Warning, _a1 bool, _a2 error) *WarningsMock_RemoveLast_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *WarningsMock_RemoveLast_Call) RunAndReturn(run func(int64, int64, time.Time) ([]warnings.Warning, bool, error)) *WarningsMock_RemoveLast_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Reset provides a mock function with given fields: chatID, userID
+func (_m *WarningsMock) Reset(chatID int64, userID int64) error {
+	ret := _m.Called(chatID, userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int64, int64) error); ok {
+		r0 = rf(chatID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// WarningsMock_Reset_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Reset'
+type WarningsMock_Reset_Call struct {
+	*mock.Call
+}
+
+// Reset is a helper method to define mock.On call
+//   - chatID int64
+//   - userID int64
+func (_e *WarningsMock_Expecter) Reset(chatID interface{}, userID interface{}) *WarningsMock_Reset_Call {
+	return &WarningsMock_Reset_Call{Call: _e.mock.On("Reset", chatID, userID)}
+}
+
+func (_c *WarningsMock_Reset_Call) Run(run func(chatID int64, userID int64)) *WarningsMock_Reset_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int64), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *WarningsMock_Reset_Call) Return(_a0 error) *WarningsMock_Reset_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *WarningsMock_Reset_Call) RunAndReturn(run func(int64, int64) error) *WarningsMock_Reset_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewWarningsMock creates a new instance of WarningsMock. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewWarningsMock(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *WarningsMock {
+	mock := &WarningsMock{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
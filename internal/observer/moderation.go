@@ -0,0 +1,401 @@
+package observer
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"go.uber.org/zap"
+
+	"geeksonator/internal/audit"
+	"geeksonator/pkg/duration"
+)
+
+const (
+	// minRestrictDuration and maxRestrictDuration are the bounds of a ban or mute,
+	// Telegram treats shorter and longer ones as permanent.
+	minRestrictDuration = 30 * time.Second
+	maxRestrictDuration = 366 * 24 * time.Hour
+	// revokeFlag is the argument of /ban deleting the recent messages of the banned user.
+	revokeFlag = "-d"
+)
+
+var errDurationOutOfRange = errors.New("duration is out of range")
+
+const (
+	// durationRangeTxt is the answer to the moderation command with the duration out of the bounds.
+	durationRangeTxt = "Срок должен быть от 30 секунд до 366 дней."
+	// notReplyTxt is the answer to the moderation command sent not as a reply.
+	notReplyTxt = "Команда работает только ответом на сообщение."
+)
+
+// moderationArgs are the arguments of the moderation command: [duration] [-d] [reason].
+type moderationArgs struct {
+	// duration is the restriction duration, zero for the permanent one.
+	duration time.Duration
+	// revoke deletes the recent messages of the user.
+	revoke bool
+	// reason is the free text reason.
+	reason string
+}
+
+// parseModerationArgs parses the moderation command arguments, the first word is the duration
+// if it's parsed as one, otherwise it's the beginning of the reason.
+func parseModerationArgs(args string) (moderationArgs, error) {
+	var parsed moderationArgs
+
+	fields := strings.Fields(args)
+	if len(fields) > 0 {
+		if d, err := duration.Parse(fields[0]); err == nil {
+			if d < minRestrictDuration || d > maxRestrictDuration {
+				return moderationArgs{}, fmt.Errorf("%w: %s", errDurationOutOfRange, fields[0])
+			}
+
+			parsed.duration = d
+			fields = fields[1:]
+		}
+	}
+
+	if len(fields) > 0 && fields[0] == revokeFlag {
+		parsed.revoke = true
+		fields = fields[1:]
+	}
+
+	parsed.reason = strings.Join(fields, " ")
+
+	return parsed, nil
+}
+
+// moderationTarget returns the author of the replied message.
+// If the command can't be applied, the user is nil and the text explains why.
+func (m *Manager) moderationTarget(req *request) (*tgbotapi.User, string, error) {
+	reply := repliedMessage(req.message)
+	if reply == nil || reply.From == nil {
+		return nil, notReplyTxt, nil
+	}
+
+	if reply.From.IsBot && strings.EqualFold(reply.From.UserName, m.botUsername) {
+		return nil, "Бот не может применить команду к себе.", nil
+	}
+
+	admins, err := m.getAdmins(req.message.Chat.ChatConfig())
+	if err != nil {
+		return nil, "", fmt.Errorf("m.getAdmins: %v", err)
+	}
+
+	if authorIsAdmin(admins, reply.From.ID) {
+		return nil, "Команда не применяется к администраторам.", nil
+	}
+
+	return reply.From, "", nil
+}
+
+// repliedMessage returns the message replied by the message, nil if the message isn't a reply
+// or is sent to the forum topic without a reply, i.e. it replies to the topic root.
+func repliedMessage(message *tgbotapi.Message) *tgbotapi.Message {
+	if message.ReplyToMessage == nil || isTopicRoot(message.ReplyToMessage) {
+		return nil
+	}
+
+	return message.ReplyToMessage
+}
+
+// isTopicRoot returns true if the replied message is the creation of the forum topic: the messages sent
+// to the topic without a reply are the replies to it. The library doesn't decode this service message,
+// so it has neither text nor any content.
+func isTopicRoot(reply *tgbotapi.Message) bool {
+	return reply.Text == "" && reply.Caption == "" && reply.Animation == nil && reply.Audio == nil &&
+		reply.Document == nil && len(reply.Photo) == 0 && reply.Sticker == nil && reply.Video == nil &&
+		reply.VideoNote == nil && reply.Voice == nil && reply.Contact == nil && reply.Dice == nil &&
+		reply.Game == nil && reply.Poll == nil && reply.Venue == nil && reply.Location == nil &&
+		reply.Invoice == nil && len(reply.NewChatMembers) == 0 && reply.LeftChatMember == nil
+}
+
+// ban bans the author of the replied message for the duration from the arguments.
+func (m *Manager) ban(req *request) ([]string, error) {
+	target, refusal, err := m.moderationTarget(req)
+	if err != nil {
+		return nil, fmt.Errorf("m.moderationTarget: %v", err)
+	}
+
+	if target == nil {
+		return []string{refusal}, nil
+	}
+
+	args, err := parseModerationArgs(req.args)
+	if err != nil {
+		return []string{durationRangeTxt}, nil
+	}
+
+	text, ok := m.banMember(req.message.Chat.ID, target, args.duration, args.revoke)
+	if !ok {
+		return []string{text}, nil
+	}
+
+	e := m.auditEvent(audit.ActionBan, req.message.Chat, req.message.ReplyToMessage, req.message.From)
+	e.Duration = auditDuration(args.duration)
+	e.Reason = args.reason
+
+	if args.revoke {
+		text += "\nСообщения пользователя удалены."
+		e.Content = content(req.message.ReplyToMessage)
+	}
+
+	m.audit(req.message.Chat, e)
+
+	return []string{text + reasonLine(args.reason)}, nil
+}
+
+// banMember bans the user for the duration, zero bans forever.
+// It returns the confirmation text, or the failure text and false if the user isn't banned.
+func (m *Manager) banMember(chatID int64, target *tgbotapi.User, d time.Duration, revoke bool) (string, bool) {
+	var until time.Time
+	if d > 0 {
+		until = m.timeNow().Add(d)
+	}
+
+	err := m.bot.BanChatMember(chatID, target.ID, until, revoke)
+	if err != nil {
+		m.log("Ban chat member",
+			zap.Int64("userID", target.ID),
+			zap.Error(err),
+		)
+
+		return "Не удалось забанить " + mention(target) + ": " + html.EscapeString(err.Error()), false
+	}
+
+	m.forgetMember(chatID, target.ID)
+
+	return "Пользователь " + mention(target) + " забанен " + period(d, until) + ".", true
+}
+
+// unban unbans the author of the replied message.
+func (m *Manager) unban(req *request) ([]string, error) {
+	target, refusal, err := m.moderationTarget(req)
+	if err != nil {
+		return nil, fmt.Errorf("m.moderationTarget: %v", err)
+	}
+
+	if target == nil {
+		return []string{refusal}, nil
+	}
+
+	err = m.bot.UnbanChatMember(req.message.Chat.ID, target.ID)
+	if err != nil {
+		m.log("Unban chat member",
+			zap.Int64("userID", target.ID),
+			zap.Error(err),
+		)
+
+		return []string{"Не удалось разбанить " + mention(target) + ": " + html.EscapeString(err.Error())}, nil
+	}
+
+	m.audit(req.message.Chat, m.auditEvent(audit.ActionUnban, req.message.Chat, req.message.ReplyToMessage, req.message.From))
+
+	return []string{"Пользователь " + mention(target) + " разбанен."}, nil
+}
+
+// mute forbids the author of the replied message to send messages for the duration from the arguments.
+func (m *Manager) mute(req *request) ([]string, error) {
+	target, refusal, err := m.moderationTarget(req)
+	if err != nil {
+		return nil, fmt.Errorf("m.moderationTarget: %v", err)
+	}
+
+	if target == nil {
+		return []string{refusal}, nil
+	}
+
+	args, err := parseModerationArgs(req.args)
+	if err != nil {
+		return []string{durationRangeTxt}, nil
+	}
+
+	text, ok := m.muteMember(req.message.Chat.ID, target, args.duration)
+	if !ok {
+		return []string{text}, nil
+	}
+
+	e := m.auditEvent(audit.ActionMute, req.message.Chat, req.message.ReplyToMessage, req.message.From)
+	e.Duration = auditDuration(args.duration)
+	e.Reason = args.reason
+	m.audit(req.message.Chat, e)
+
+	return []string{text + reasonLine(args.reason)}, nil
+}
+
+// muteMember forbids the user to send messages for the duration, zero mutes forever.
+// It returns the confirmation text, or the failure text and false if the user isn't muted.
+func (m *Manager) muteMember(chatID int64, target *tgbotapi.User, d time.Duration) (string, bool) {
+	var until time.Time
+	if d > 0 {
+		until = m.timeNow().Add(d)
+	}
+
+	err := m.bot.RestrictChatMember(chatID, target.ID, tgbotapi.ChatPermissions{}, until)
+	if err != nil {
+		m.log("Restrict chat member",
+			zap.Int64("userID", target.ID),
+			zap.Error(err),
+		)
+
+		return "Не удалось замьютить " + mention(target) + ": " + html.EscapeString(err.Error()), false
+	}
+
+	return "Пользователь " + mention(target) + " не может писать в чат " + period(d, until) + ".", true
+}
+
+// unmute lifts the restrictions from the author of the replied message.
+func (m *Manager) unmute(req *request) ([]string, error) {
+	target, refusal, err := m.moderationTarget(req)
+	if err != nil {
+		return nil, fmt.Errorf("m.moderationTarget: %v", err)
+	}
+
+	if target == nil {
+		return []string{refusal}, nil
+	}
+
+	err = m.bot.RestrictChatMember(req.message.Chat.ID, target.ID, memberPermissions(), time.Time{})
+	if err != nil {
+		m.log("Restrict chat member",
+			zap.Int64("userID", target.ID),
+			zap.Error(err),
+		)
+
+		return []string{"Не удалось снять ограничения с " + mention(target) + ": " + html.EscapeString(err.Error())}, nil
+	}
+
+	m.audit(req.message.Chat, m.auditEvent(audit.ActionUnmute, req.message.Chat, req.message.ReplyToMessage, req.message.From))
+
+	return []string{"С пользователя " + mention(target) + " сняты ограничения."}, nil
+}
+
+// del deletes the replied message and the command message.
+func (m *Manager) del(req *request) ([]string, error) {
+	reply := repliedMessage(req.message)
+	if reply == nil {
+		return []string{notReplyTxt}, nil
+	}
+
+	err := m.bot.DeleteMessage(req.message.Chat.ID, reply.MessageID)
+	if err != nil {
+		m.log("Delete replied message",
+			zap.Int("messageID", reply.MessageID),
+			zap.Error(err),
+		)
+
+		return []string{"Не удалось удалить сообщение: " + html.EscapeString(err.Error())}, nil
+	}
+
+	m.auditDelete(req.message)
+	m.deleteCommand(req.message)
+
+	return nil, nil
+}
+
+// deleteReplied deletes the message replied by the command with the "!" suffix.
+// The response is still sent, so the failure is only logged.
+func (m *Manager) deleteReplied(message *tgbotapi.Message) {
+	reply := repliedMessage(message)
+	if reply == nil {
+		return
+	}
+
+	err := m.bot.DeleteMessage(message.Chat.ID, reply.MessageID)
+	if err != nil {
+		m.log("Delete replied message",
+			zap.Int("messageID", reply.MessageID),
+			zap.Error(err),
+		)
+
+		return
+	}
+
+	m.auditDelete(message)
+}
+
+// auditDelete logs the deletion of the message replied by the admin command with a copy of its content.
+func (m *Manager) auditDelete(message *tgbotapi.Message) {
+	e := m.auditEvent(audit.ActionDelete, message.Chat, message.ReplyToMessage, message.From)
+	e.Content = content(message.ReplyToMessage)
+	m.audit(message.Chat, e)
+}
+
+// postNotice posts the notice of the automatic moderation action to the chat.
+// The action is already applied, so the failure is only logged.
+func (m *Manager) postNotice(chatID int64, text string) {
+	msg := m.bot.NewMessage(chatID, text)
+	msg.ParseMode = "html"
+
+	_, err := m.bot.Send(msg)
+	if err != nil {
+		m.log("Send notice",
+			zap.Error(err),
+		)
+	}
+}
+
+// notifyAuthor sends the notice to the author of the deleted message privately with the copy of the message.
+// If the bot can't write to the author it posts the notice to the chat and deletes it after a while.
+func (m *Manager) notifyAuthor(message *tgbotapi.Message, text string) {
+	private := m.bot.NewMessage(message.From.ID, "Чат: "+html.EscapeString(message.Chat.Title)+"\n"+text+
+		"\n\nВаш текст:\n<blockquote>"+html.EscapeString(content(message))+"</blockquote>")
+	private.ParseMode = "html"
+
+	// the bot can't write to the author who hasn't started it, the notice falls back to the chat
+	_, err := m.bot.Send(private)
+	if err == nil {
+		return
+	}
+	m.log("Notify author privately",
+		zap.Error(err),
+	)
+
+	notice := m.bot.NewMessage(message.Chat.ID, mention(message.From)+", "+text)
+	notice.ParseMode = "html"
+
+	sent, err := m.bot.Send(notice)
+	if err != nil {
+		m.log("Notify author",
+			zap.Error(err),
+		)
+
+		return
+	}
+
+	m.deleteLater(message.Chat.ID, sent.MessageID)
+}
+
+// memberPermissions returns the permissions of a member without restrictions.
+func memberPermissions() tgbotapi.ChatPermissions {
+	return tgbotapi.ChatPermissions{
+		CanSendMessages:       true,
+		CanSendMediaMessages:  true,
+		CanSendPolls:          true,
+		CanSendOtherMessages:  true,
+		CanAddWebPagePreviews: true,
+		CanInviteUsers:        true,
+	}
+}
+
+// period returns the restriction period, e.g. "на 1 день, до 09.03.2024 15:04" or "навсегда".
+func period(d time.Duration, until time.Time) string {
+	if d == 0 {
+		return "навсегда"
+	}
+
+	return "на " + duration.Format(d) + ", до " + until.Format("02.01.2006 15:04")
+}
+
+// reasonLine returns the line with the escaped reason, empty if there's no reason.
+func reasonLine(reason string) string {
+	if reason == "" {
+		return ""
+	}
+
+	return "\nПричина: " + html.EscapeString(reason)
+}
@@ -0,0 +1,590 @@
+package observer
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/stretchr/testify/assert"
+
+	"geeksonator/internal/catalog"
+	"geeksonator/internal/observer/mocks"
+)
+
+// moderationNow is the current time of the moderation tests.
+var moderationNow = time.Date(2024, 3, 8, 12, 0, 0, 0, time.UTC) //nolint:gochecknoglobals // it's a test constant
+
+// moderationRequest returns the request of the moderation command replied to the message of the user.
+func moderationRequest(handler catalog.Handler, args string, target *tgbotapi.User) *request {
+	message := &tgbotapi.Message{
+		MessageID: 42,
+		Chat: &tgbotapi.Chat{
+			ID: 300600,
+		},
+		From: &tgbotapi.User{
+			ID: 100500,
+		},
+	}
+
+	if target != nil {
+		message.ReplyToMessage = &tgbotapi.Message{
+			MessageID: 41,
+			From:      target,
+			Sticker:   &tgbotapi.Sticker{FileID: "spam"},
+		}
+	}
+
+	return &request{
+		message: message,
+		cmd: &catalog.Command{
+			Name:    string(handler),
+			Handler: handler,
+		},
+		args: args,
+		role: catalog.RoleAdmin,
+	}
+}
+
+func Test_parseModerationArgs(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		args    string
+		want    moderationArgs
+		wantErr error
+	}{
+		{
+			name:    "Empty",
+			args:    "",
+			want:    moderationArgs{},
+			wantErr: nil,
+		},
+		{
+			name: "Duration",
+			args: "1d",
+			want: moderationArgs{
+				duration: 24 * time.Hour,
+			},
+			wantErr: nil,
+		},
+		{
+			name: "Duration, revoke and reason",
+			args: "30m -d spam  links",
+			want: moderationArgs{
+				duration: 30 * time.Minute,
+				revoke:   true,
+				reason:   "spam links",
+			},
+			wantErr: nil,
+		},
+		{
+			name: "Reason only",
+			args: "spam",
+			want: moderationArgs{
+				reason: "spam",
+			},
+			wantErr: nil,
+		},
+		{
+			name:    "Too long",
+			args:    "367d",
+			want:    moderationArgs{},
+			wantErr: errDurationOutOfRange,
+		},
+		{
+			name:    "Too short",
+			args:    "10s",
+			want:    moderationArgs{},
+			wantErr: errDurationOutOfRange,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := parseModerationArgs(tt.args)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestManager_ban(t *testing.T) {
+	t.Parallel()
+
+	spammer := &tgbotapi.User{ID: 100501, UserName: "spammer"}
+
+	tests := []struct {
+		name    string
+		man     func() *Manager
+		req     *request
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "Temporary ban with reason",
+			man: func() *Manager {
+				botProvider := mocks.NewBotProviderMock(t)
+
+				botProvider.EXPECT().
+					BanChatMember(int64(300600), int64(100501), moderationNow.Add(24*time.Hour), false).
+					Return(nil)
+
+				return &Manager{
+					bot:   botProvider,
+					cache: adminsCache(t, 100500),
+					now:   func() time.Time { return moderationNow },
+				}
+			},
+			req:     moderationRequest(catalog.HandlerBan, "1d <spam>", spammer),
+			want:    []string{"Пользователь @spammer забанен на 1 день, до 09.03.2024 12:00.\nПричина: &lt;spam&gt;"},
+			wantErr: false,
+		},
+		{
+			name: "Permanent ban with revoke",
+			man: func() *Manager {
+				botProvider := mocks.NewBotProviderMock(t)
+
+				botProvider.EXPECT().
+					BanChatMember(int64(300600), int64(100501), time.Time{}, true).
+					Return(nil)
+
+				return &Manager{
+					bot:   botProvider,
+					cache: adminsCache(t, 100500),
+				}
+			},
+			req:     moderationRequest(catalog.HandlerBan, "-d", spammer),
+			want:    []string{"Пользователь @spammer забанен навсегда.\nСообщения пользователя удалены."},
+			wantErr: false,
+		},
+		{
+			name: "Not a reply",
+			man: func() *Manager {
+				return &Manager{}
+			},
+			req:     moderationRequest(catalog.HandlerBan, "1d", nil),
+			want:    []string{"Команда работает только ответом на сообщение."},
+			wantErr: false,
+		},
+		{
+			name: "Admin",
+			man: func() *Manager {
+				return &Manager{
+					cache: adminsCache(t, 100501),
+				}
+			},
+			req:     moderationRequest(catalog.HandlerBan, "1d", spammer),
+			want:    []string{"Команда не применяется к администраторам."},
+			wantErr: false,
+		},
+		{
+			name: "Too long",
+			man: func() *Manager {
+				return &Manager{
+					cache: adminsCache(t, 100500),
+				}
+			},
+			req:     moderationRequest(catalog.HandlerBan, "400d", spammer),
+			want:    []string{durationRangeTxt},
+			wantErr: false,
+		},
+		{
+			name: "API error",
+			man: func() *Manager {
+				botProvider := mocks.NewBotProviderMock(t)
+
+				botProvider.EXPECT().
+					BanChatMember(int64(300600), int64(100501), time.Time{}, false).
+					Return(errors.New("not enough rights"))
+
+				return &Manager{
+					bot:   botProvider,
+					cache: adminsCache(t, 100500),
+				}
+			},
+			req:     moderationRequest(catalog.HandlerBan, "", spammer),
+			want:    []string{"Не удалось забанить @spammer: not enough rights"},
+			wantErr: false,
+		},
+		{
+			name: "Admins error",
+			man: func() *Manager {
+				botProvider := mocks.NewBotProviderMock(t)
+
+				botProvider.EXPECT().
+					GetChatAdministrators(tgbotapi.ChatConfig{ChatID: 300600}).
+					Return(nil, errors.New("error"))
+
+				cache := mocks.NewCacheMock(t)
+
+				cache.EXPECT().
+					Get(int64(300600)).
+					Return(nil, false)
+
+				return &Manager{
+					bot:   botProvider,
+					cache: cache,
+				}
+			},
+			req:     moderationRequest(catalog.HandlerBan, "", spammer),
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := tt.man().getMessageText(tt.req)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestManager_moderationTarget_TopicRoot(t *testing.T) {
+	t.Parallel()
+
+	// the command sent to the forum topic without a reply replies to the topic creation
+	req := moderationRequest(catalog.HandlerBan, "", &tgbotapi.User{ID: 100501, UserName: "creator"})
+	req.message.ReplyToMessage.Sticker = nil
+
+	got, err := (&Manager{}).ban(req)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{notReplyTxt}, got)
+
+	req.message.ReplyToMessage.Text = "spam"
+
+	target, _, err := (&Manager{cache: adminsCache(t, 100500)}).moderationTarget(req)
+	assert.NoError(t, err)
+	assert.Equal(t, &tgbotapi.User{ID: 100501, UserName: "creator"}, target, "explicit reply in the topic")
+}
+
+func TestManager_del_TopicRoot(t *testing.T) {
+	t.Parallel()
+
+	// the topic creation isn't deleted by /del and the "!" suffix
+	req := moderationRequest(catalog.HandlerDel, "", &tgbotapi.User{ID: 100501, UserName: "creator"})
+	req.message.ReplyToMessage.Sticker = nil
+
+	m := &Manager{bot: mocks.NewBotProviderMock(t)}
+
+	got, err := m.del(req)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{notReplyTxt}, got)
+
+	m.deleteReplied(req.message)
+
+	assert.Empty(t, replyTarget(req.message))
+}
+
+func TestManager_unban(t *testing.T) {
+	t.Parallel()
+
+	botProvider := mocks.NewBotProviderMock(t)
+
+	botProvider.EXPECT().
+		UnbanChatMember(int64(300600), int64(100501)).
+		Return(nil)
+
+	m := &Manager{
+		bot:   botProvider,
+		cache: adminsCache(t, 100500),
+	}
+
+	got, err := m.getMessageText(moderationRequest(catalog.HandlerUnban, "", &tgbotapi.User{ID: 100501, UserName: "spammer"}))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Пользователь @spammer разбанен."}, got)
+}
+
+func TestManager_mute(t *testing.T) {
+	t.Parallel()
+
+	spammer := &tgbotapi.User{ID: 100501, UserName: "spammer"}
+
+	tests := []struct {
+		name string
+		man  func() *Manager
+		req  *request
+		want []string
+	}{
+		{
+			name: "Russian duration",
+			man: func() *Manager {
+				botProvider := mocks.NewBotProviderMock(t)
+
+				botProvider.EXPECT().
+					RestrictChatMember(int64(300600), int64(100501), tgbotapi.ChatPermissions{}, moderationNow.Add(30*time.Minute)).
+					Return(nil)
+
+				return &Manager{
+					bot:   botProvider,
+					cache: adminsCache(t, 100500),
+					now:   func() time.Time { return moderationNow },
+				}
+			},
+			req:  moderationRequest(catalog.HandlerMute, "30м флуд", spammer),
+			want: []string{"Пользователь @spammer не может писать в чат на 30 минут, до 08.03.2024 12:30.\nПричина: флуд"},
+		},
+		{
+			name: "Permanent",
+			man: func() *Manager {
+				botProvider := mocks.NewBotProviderMock(t)
+
+				botProvider.EXPECT().
+					RestrictChatMember(int64(300600), int64(100501), tgbotapi.ChatPermissions{}, time.Time{}).
+					Return(nil)
+
+				return &Manager{
+					bot:   botProvider,
+					cache: adminsCache(t, 100500),
+				}
+			},
+			req:  moderationRequest(catalog.HandlerMute, "", spammer),
+			want: []string{"Пользователь @spammer не может писать в чат навсегда."},
+		},
+		{
+			name: "API error",
+			man: func() *Manager {
+				botProvider := mocks.NewBotProviderMock(t)
+
+				botProvider.EXPECT().
+					RestrictChatMember(int64(300600), int64(100501), tgbotapi.ChatPermissions{}, time.Time{}).
+					Return(errors.New("not enough rights"))
+
+				return &Manager{
+					bot:   botProvider,
+					cache: adminsCache(t, 100500),
+				}
+			},
+			req:  moderationRequest(catalog.HandlerMute, "", spammer),
+			want: []string{"Не удалось замьютить @spammer: not enough rights"},
+		},
+		{
+			name: "Not a reply",
+			man: func() *Manager {
+				return &Manager{}
+			},
+			req:  moderationRequest(catalog.HandlerMute, "1д", nil),
+			want: []string{"Команда работает только ответом на сообщение."},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := tt.man().getMessageText(tt.req)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestManager_unmute(t *testing.T) {
+	t.Parallel()
+
+	botProvider := mocks.NewBotProviderMock(t)
+
+	botProvider.EXPECT().
+		RestrictChatMember(int64(300600), int64(100501), memberPermissions(), time.Time{}).
+		Return(nil)
+
+	m := &Manager{
+		bot:   botProvider,
+		cache: adminsCache(t, 100500),
+	}
+
+	got, err := m.getMessageText(moderationRequest(catalog.HandlerUnmute, "", &tgbotapi.User{ID: 100501, UserName: "spammer"}))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"С пользователя @spammer сняты ограничения."}, got)
+}
+
+func TestManager_del(t *testing.T) {
+	t.Parallel()
+
+	spammer := &tgbotapi.User{ID: 100501, UserName: "spammer"}
+
+	tests := []struct {
+		name string
+		man  func() *Manager
+		req  *request
+		want []string
+	}{
+		{
+			name: "Success",
+			man: func() *Manager {
+				botProvider := mocks.NewBotProviderMock(t)
+
+				botProvider.EXPECT().
+					DeleteMessage(int64(300600), 41).
+					Return(nil)
+
+				botProvider.EXPECT().
+					DeleteMessage(int64(300600), 42).
+					Return(nil)
+
+				return &Manager{
+					bot: botProvider,
+				}
+			},
+			req:  moderationRequest(catalog.HandlerDel, "", spammer),
+			want: nil,
+		},
+		{
+			name: "Command message isn't deleted",
+			man: func() *Manager {
+				botProvider := mocks.NewBotProviderMock(t)
+
+				botProvider.EXPECT().
+					DeleteMessage(int64(300600), 41).
+					Return(nil)
+
+				botProvider.EXPECT().
+					DeleteMessage(int64(300600), 42).
+					Return(errors.New("message can't be deleted"))
+
+				return &Manager{
+					bot: botProvider,
+				}
+			},
+			req:  moderationRequest(catalog.HandlerDel, "", spammer),
+			want: nil,
+		},
+		{
+			name: "Replied message isn't deleted",
+			man: func() *Manager {
+				botProvider := mocks.NewBotProviderMock(t)
+
+				botProvider.EXPECT().
+					DeleteMessage(int64(300600), 41).
+					Return(errors.New("message to delete not found"))
+
+				return &Manager{
+					bot: botProvider,
+				}
+			},
+			req:  moderationRequest(catalog.HandlerDel, "", spammer),
+			want: []string{"Не удалось удалить сообщение: message to delete not found"},
+		},
+		{
+			name: "Not a reply",
+			man: func() *Manager {
+				return &Manager{}
+			},
+			req:  moderationRequest(catalog.HandlerDel, "", nil),
+			want: []string{notReplyTxt},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := tt.man().getMessageText(tt.req)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestManager_processingMessage_DeleteFlag(t *testing.T) {
+	t.Parallel()
+
+	message := func(from int64) *tgbotapi.Message {
+		return &tgbotapi.Message{
+			MessageID: 42,
+			Chat: &tgbotapi.Chat{
+				ID: 300600,
+			},
+			From: &tgbotapi.User{
+				ID: from,
+			},
+			Text: laraCmd + "!",
+			ReplyToMessage: &tgbotapi.Message{
+				Sticker:   &tgbotapi.Sticker{FileID: "spam"},
+				MessageID: 41,
+				From:      &tgbotapi.User{ID: 100501, UserName: "spammer"},
+			},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		man     func() *Manager
+		message *tgbotapi.Message
+		want    []string
+	}{
+		{
+			name: "Admin",
+			man: func() *Manager {
+				botProvider := mocks.NewBotProviderMock(t)
+
+				botProvider.EXPECT().
+					DeleteMessage(int64(300600), 41).
+					Return(nil)
+
+				return &Manager{
+					bot:      botProvider,
+					cache:    adminsCache(t, 100500),
+					commands: laraCommands(t),
+				}
+			},
+			message: message(100500),
+			want:    []string{"@spammer " + laraTxt},
+		},
+		{
+			name: "Delete error is ignored",
+			man: func() *Manager {
+				botProvider := mocks.NewBotProviderMock(t)
+
+				botProvider.EXPECT().
+					DeleteMessage(int64(300600), 41).
+					Return(errors.New("message to delete not found"))
+
+				return &Manager{
+					bot:      botProvider,
+					cache:    adminsCache(t, 100500),
+					commands: laraCommands(t),
+				}
+			},
+			message: message(100500),
+			want:    []string{"@spammer " + laraTxt},
+		},
+		{
+			name: "Member",
+			man: func() *Manager {
+				commands := mocks.NewCommandsMock(t)
+
+				commands.EXPECT().
+					Lookup(catalog.ChatRef{ID: 300600}, "lara").
+					Return(&catalog.Command{
+						Name:     "lara",
+						Role:     catalog.RoleMember,
+						Response: laraTxt,
+					}, true)
+
+				commands.EXPECT().
+					Chat(catalog.ChatRef{ID: 300600}).
+					Return(nil, false)
+
+				return &Manager{
+					cache:    adminsCache(t, 100500),
+					commands: commands,
+				}
+			},
+			message: message(100502),
+			want:    []string{"@spammer " + laraTxt},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := tt.man().processingMessage(tt.message)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got.texts)
+		})
+	}
+}
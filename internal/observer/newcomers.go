@@ -0,0 +1,175 @@
+package observer
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"go.uber.org/zap"
+
+	"geeksonator/internal/audit"
+	"geeksonator/internal/catalog"
+	"geeksonator/pkg/duration"
+)
+
+// trackNewMembers starts tracking the new chat members if the chat restricts the newcomers.
+func (m *Manager) trackNewMembers(message *tgbotapi.Message) {
+	if m.members == nil {
+		return
+	}
+
+	if _, ok := m.commands.Newcomers(chatRef(message.Chat)); !ok {
+		return
+	}
+
+	for _, member := range message.NewChatMembers {
+		if member.IsBot {
+			continue
+		}
+
+		if err := m.members.Join(message.Chat.ID, member.ID, m.timeNow()); err != nil {
+			m.log("Track new member",
+				zap.Int64("userID", member.ID),
+				zap.Error(err),
+			)
+		}
+	}
+}
+
+// checkNewcomer deletes the link, forward or media message of the newcomer and warns the newcomer once,
+// other messages are counted. The member is no longer tracked when the restrictions don't apply.
+// The message is kept if the admins can't be fetched. It returns true if the message is deleted.
+func (m *Manager) checkNewcomer(message *tgbotapi.Message) bool {
+	if m.members == nil || message.From == nil || message.Chat == nil || message.Chat.IsPrivate() {
+		return false
+	}
+
+	chatID, userID := message.Chat.ID, message.From.ID
+
+	member, ok := m.members.Get(chatID, userID)
+	if !ok {
+		return false
+	}
+
+	cfg, ok := m.commands.Newcomers(chatRef(message.Chat))
+	if !ok {
+		return false
+	}
+
+	if !cfg.Restricts(member.Joined, member.Messages, m.timeNow()) {
+		m.forgetMember(chatID, userID)
+
+		return false
+	}
+
+	if !restrictedContent(message) {
+		if err := m.members.CountMessage(chatID, userID); err != nil {
+			m.log("Count newcomer message",
+				zap.Int64("userID", userID),
+				zap.Error(err),
+			)
+		}
+
+		return false
+	}
+
+	admins, err := m.getAdmins(message.Chat.ChatConfig())
+	if err != nil {
+		m.log("Get chat admins",
+			zap.Int64("chatID", chatID),
+			zap.Error(err),
+		)
+
+		return false
+	}
+
+	// the newcomer is promoted
+	if authorIsAdmin(admins, userID) {
+		m.forgetMember(chatID, userID)
+
+		return false
+	}
+
+	err = m.bot.DeleteMessage(chatID, message.MessageID)
+	if err != nil {
+		m.log("Delete newcomer message",
+			zap.Int("messageID", message.MessageID),
+			zap.Error(err),
+		)
+	} else {
+		e := m.auditEvent(audit.ActionNewcomer, message.Chat, message, nil)
+		e.Reason = "ссылка, пересылка или медиа от новичка"
+		e.Content = content(message)
+		m.audit(message.Chat, e)
+	}
+
+	if member.Warned {
+		return true
+	}
+
+	m.postNotice(chatID, newcomerNotice(message.From, cfg))
+
+	if err := m.members.MarkWarned(chatID, userID); err != nil {
+		m.log("Mark newcomer warned",
+			zap.Int64("userID", userID),
+			zap.Error(err),
+		)
+	}
+
+	return true
+}
+
+// forgetMember stops tracking the member, the failure is only logged.
+func (m *Manager) forgetMember(chatID, userID int64) {
+	if m.members == nil {
+		return
+	}
+
+	if err := m.members.Remove(chatID, userID); err != nil {
+		m.log("Forget newcomer",
+			zap.Int64("userID", userID),
+			zap.Error(err),
+		)
+	}
+}
+
+// newcomerNotice returns the warning of the newcomer about the restrictions.
+func newcomerNotice(user *tgbotapi.User, cfg *catalog.Newcomers) string {
+	text := mention(user) + ", сообщение удалено: новым участникам нельзя отправлять ссылки, пересылки и медиа."
+
+	if cfg.Period > 0 {
+		text += "\nОграничение действует с момента входа в чат: " + duration.Format(time.Duration(cfg.Period)) + "."
+	}
+
+	if cfg.Messages > 0 {
+		text += "\nОграничение снимается после сообщений без ссылок и медиа: " + strconv.Itoa(cfg.Messages) + "."
+	}
+
+	return text
+}
+
+// restrictedContent returns true if the message is forwarded, has a link or media, stickers are allowed.
+func restrictedContent(message *tgbotapi.Message) bool {
+	if message.ForwardDate != 0 || message.ForwardFrom != nil || message.ForwardFromChat != nil || message.ForwardSenderName != "" {
+		return true
+	}
+
+	if len(message.Photo) > 0 || message.Video != nil || message.Animation != nil || message.Document != nil ||
+		message.Audio != nil || message.Voice != nil || message.VideoNote != nil {
+		return true
+	}
+
+	for _, entities := range [][]tgbotapi.MessageEntity{message.Entities, message.CaptionEntities} {
+		for _, entity := range entities {
+			if entity.IsURL() || entity.IsTextLink() {
+				return true
+			}
+		}
+	}
+
+	// the links are looked for in the text as well, e.g. the invite links t.me/+AbCdEf
+	text := strings.ToLower(message.Text + " " + message.Caption)
+
+	return strings.Contains(text, "://") || strings.Contains(text, "t.me/") || strings.Contains(text, "telegram.me/")
+}
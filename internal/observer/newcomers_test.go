@@ -0,0 +1,320 @@
+package observer
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/stretchr/testify/assert"
+
+	"geeksonator/internal/catalog"
+	"geeksonator/internal/members"
+	"geeksonator/internal/observer/mocks"
+)
+
+func TestManager_trackNewMembers(t *testing.T) {
+	t.Parallel()
+
+	commands := mocks.NewCommandsMock(t)
+
+	commands.EXPECT().
+		Newcomers(catalog.ChatRef{ID: 300600}).
+		Return(&catalog.Newcomers{Messages: 5}, true)
+
+	commands.EXPECT().
+		Captcha(catalog.ChatRef{ID: 300600}).
+		Return(nil, false)
+
+	store := mocks.NewMembersMock(t)
+
+	store.EXPECT().
+		Join(int64(300600), int64(100501), moderationNow).
+		Return(nil)
+
+	store.EXPECT().
+		Join(int64(300600), int64(100502), moderationNow).
+		Return(errors.New("disk is full"))
+
+	m := &Manager{
+		commands: commands,
+		members:  store,
+		now:      func() time.Time { return moderationNow },
+	}
+
+	got, err := m.processingMessage(&tgbotapi.Message{
+		Chat: &tgbotapi.Chat{ID: 300600},
+		NewChatMembers: []tgbotapi.User{
+			{ID: 100501},
+			{ID: 100502},
+			{ID: 100503, IsBot: true},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Empty(t, got.texts)
+}
+
+func TestManager_processingMessage_LeftMember(t *testing.T) {
+	t.Parallel()
+
+	store := mocks.NewMembersMock(t)
+
+	store.EXPECT().
+		Remove(int64(300600), int64(100501)).
+		Return(nil)
+
+	m := &Manager{
+		members: store,
+	}
+
+	got, err := m.processingMessage(&tgbotapi.Message{
+		Chat:           &tgbotapi.Chat{ID: 300600},
+		From:           &tgbotapi.User{ID: 100501},
+		LeftChatMember: &tgbotapi.User{ID: 100501},
+	})
+	assert.NoError(t, err)
+	assert.Empty(t, got.texts)
+}
+
+func TestManager_banMember_ForgetsNewcomer(t *testing.T) {
+	t.Parallel()
+
+	botProvider := mocks.NewBotProviderMock(t)
+	store := mocks.NewMembersMock(t)
+
+	botProvider.EXPECT().
+		BanChatMember(int64(300600), int64(100501), time.Time{}, false).
+		Return(nil)
+
+	store.EXPECT().
+		Remove(int64(300600), int64(100501)).
+		Return(nil)
+
+	m := &Manager{
+		bot:     botProvider,
+		members: store,
+	}
+
+	_, ok := m.banMember(300600, &tgbotapi.User{ID: 100501, UserName: "spammer"}, 0, false)
+	assert.True(t, ok)
+}
+
+func TestManager_processingMessage_Newcomer(t *testing.T) {
+	t.Parallel()
+
+	cfg := &catalog.Newcomers{Period: catalog.Duration(24 * time.Hour), Messages: 5}
+	newcomer := members.Member{Joined: moderationNow.Add(-time.Hour), Messages: 1}
+	notice := "@newbie, сообщение удалено: новым участникам нельзя отправлять ссылки, пересылки и медиа." +
+		"\nОграничение действует с момента входа в чат: 1 день." +
+		"\nОграничение снимается после сообщений без ссылок и медиа: 5."
+
+	tests := []struct {
+		name        string
+		member      *members.Member
+		text        string
+		checkAdmins bool
+		adminID     int64
+		adminsErr   error
+		expect      func(botProvider *mocks.BotProviderMock, store *mocks.MembersMock)
+	}{
+		{
+			name: "Not tracked",
+			text: "https://spam.example",
+		},
+		{
+			name:   "Restrictions passed",
+			member: &members.Member{Joined: moderationNow.Add(-25 * time.Hour), Messages: 5},
+			text:   "https://php.net",
+			expect: func(_ *mocks.BotProviderMock, store *mocks.MembersMock) {
+				store.EXPECT().
+					Remove(int64(300600), int64(100501)).
+					Return(nil)
+			},
+		},
+		{
+			name:   "Plain message is counted",
+			member: &newcomer,
+			text:   "Всем привет!",
+			expect: func(_ *mocks.BotProviderMock, store *mocks.MembersMock) {
+				store.EXPECT().
+					CountMessage(int64(300600), int64(100501)).
+					Return(nil)
+			},
+		},
+		{
+			name:        "Promoted newcomer",
+			member:      &newcomer,
+			text:        "https://php.net",
+			checkAdmins: true,
+			adminID:     100501,
+			expect: func(_ *mocks.BotProviderMock, store *mocks.MembersMock) {
+				store.EXPECT().
+					Remove(int64(300600), int64(100501)).
+					Return(nil)
+			},
+		},
+		{
+			name:        "Kept if the admins can't be fetched",
+			member:      &newcomer,
+			text:        "https://spam.example",
+			checkAdmins: true,
+			adminsErr:   errors.New("Too Many Requests: retry after 5"),
+		},
+		{
+			name:        "Link is deleted with the notice",
+			member:      &newcomer,
+			text:        "Заходите t.me/+AbCdEf",
+			checkAdmins: true,
+			expect: func(botProvider *mocks.BotProviderMock, store *mocks.MembersMock) {
+				botProvider.EXPECT().
+					DeleteMessage(int64(300600), 42).
+					Return(nil)
+
+				botProvider.EXPECT().
+					NewMessage(int64(300600), notice).
+					Return(tgbotapi.MessageConfig{Text: notice})
+
+				botProvider.EXPECT().
+					Send(tgbotapi.MessageConfig{Text: notice, ParseMode: "html"}).
+					Return(tgbotapi.Message{}, nil)
+
+				store.EXPECT().
+					MarkWarned(int64(300600), int64(100501)).
+					Return(nil)
+			},
+		},
+		{
+			name:        "Warned once",
+			member:      &members.Member{Joined: moderationNow.Add(-time.Hour), Warned: true},
+			text:        "https://spam.example",
+			checkAdmins: true,
+			expect: func(botProvider *mocks.BotProviderMock, _ *mocks.MembersMock) {
+				botProvider.EXPECT().
+					DeleteMessage(int64(300600), 42).
+					Return(errors.New("message not found"))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			botProvider := mocks.NewBotProviderMock(t)
+			cache := mocks.NewCacheMock(t)
+			commands := messageCommands(t)
+			store := mocks.NewMembersMock(t)
+
+			if tt.member != nil {
+				store.EXPECT().
+					Get(int64(300600), int64(100501)).
+					Return(*tt.member, true)
+
+				commands.EXPECT().
+					Newcomers(catalog.ChatRef{ID: 300600}).
+					Return(cfg, true)
+			} else {
+				store.EXPECT().
+					Get(int64(300600), int64(100501)).
+					Return(members.Member{}, false)
+			}
+
+			if tt.adminsErr != nil {
+				cache.EXPECT().
+					Get(int64(300600)).
+					Return(nil, false)
+
+				botProvider.EXPECT().
+					GetChatAdministrators(tgbotapi.ChatConfig{ChatID: 300600}).
+					Return(nil, tt.adminsErr)
+			}
+
+			if tt.checkAdmins && tt.adminsErr == nil {
+				admins := []tgbotapi.ChatMember{{User: &tgbotapi.User{ID: 100500}}}
+				if tt.adminID != 0 {
+					admins = append(admins, tgbotapi.ChatMember{User: &tgbotapi.User{ID: tt.adminID}})
+				}
+
+				cache.EXPECT().
+					Get(int64(300600)).
+					Return(admins, true)
+			}
+
+			if tt.expect != nil {
+				tt.expect(botProvider, store)
+			}
+
+			m := &Manager{
+				bot:      botProvider,
+				cache:    cache,
+				commands: commands,
+				members:  store,
+				now:      func() time.Time { return moderationNow },
+			}
+
+			got, err := m.processingMessage(&tgbotapi.Message{
+				MessageID: 42,
+				From:      &tgbotapi.User{ID: 100501, UserName: "newbie"},
+				Chat:      &tgbotapi.Chat{ID: 300600, Type: "supergroup"},
+				Text:      tt.text,
+			})
+			assert.NoError(t, err)
+			assert.Empty(t, got.texts)
+		})
+	}
+}
+
+func TestRestrictedContent(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		message *tgbotapi.Message
+		want    bool
+	}{
+		{
+			name:    "Plain text",
+			message: &tgbotapi.Message{Text: "Всем привет!"},
+		},
+		{
+			name:    "Sticker",
+			message: &tgbotapi.Message{Sticker: &tgbotapi.Sticker{}},
+		},
+		{
+			name:    "URL entity",
+			message: &tgbotapi.Message{Text: "php.net", Entities: []tgbotapi.MessageEntity{{Type: "url", Length: 7}}},
+			want:    true,
+		},
+		{
+			name:    "Text link in caption",
+			message: &tgbotapi.Message{Caption: "тут", CaptionEntities: []tgbotapi.MessageEntity{{Type: "text_link", Length: 3}}},
+			want:    true,
+		},
+		{
+			name:    "Invite link in text",
+			message: &tgbotapi.Message{Text: "Вступайте T.ME/+AbCdEf"},
+			want:    true,
+		},
+		{
+			name:    "Forward",
+			message: &tgbotapi.Message{Text: "Новость", ForwardFromChat: &tgbotapi.Chat{ID: -100500}},
+			want:    true,
+		},
+		{
+			name:    "Hidden forward",
+			message: &tgbotapi.Message{Text: "Новость", ForwardSenderName: "Anonymous", ForwardDate: 1709900000},
+			want:    true,
+		},
+		{
+			name:    "Photo",
+			message: &tgbotapi.Message{Photo: []tgbotapi.PhotoSize{{FileID: "photo"}}},
+			want:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, restrictedContent(tt.message))
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package observer
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf16"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// delSuffix is the command suffix deleting the replied message, e.g. /code!.
+const delSuffix = "!"
+
+// command is a bot command parsed from the message.
+type command struct {
+	// name is the lower-cased command name without the leading slash and the bot username.
+	name string
+	// args is the text after the command.
+	args string
+	// del is set by the "!" suffix, e.g. /code!, the replied message is deleted.
+	del bool
+}
+
+// parseCommand parses the command at the beginning of the message text or the media caption.
+// The bounds of the command are taken from the bot_command entity, commands with non-latin
+// names have no entity and end at the first space. Commands addressed to other bots are ignored.
+// The "!" suffix of the command sets the delete flag.
+func parseCommand(message *tgbotapi.Message, botUsername string) (command, bool) {
+	text, entities := message.Text, message.Entities
+	if text == "" {
+		text, entities = message.Caption, message.CaptionEntities
+	}
+
+	if !strings.HasPrefix(text, "/") {
+		return command{}, false
+	}
+
+	end := strings.IndexFunc(text, unicode.IsSpace)
+	if end == -1 {
+		end = len(text)
+	}
+
+	for _, entity := range entities {
+		if entity.Offset == 0 && entity.IsCommand() {
+			end = utf16Offset(text, entity.Length)
+
+			break
+		}
+	}
+
+	// the entity doesn't include the "!" suffix
+	if strings.HasPrefix(text[end:], delSuffix) {
+		end += len(delSuffix)
+	}
+
+	name, username, _ := strings.Cut(text[1:end], "@")
+
+	name, del := strings.CutSuffix(name, delSuffix)
+	username, delAfterUsername := strings.CutSuffix(username, delSuffix)
+
+	if username != "" && botUsername != "" && !strings.EqualFold(username, botUsername) {
+		return command{}, false
+	}
+
+	if name == "" {
+		return command{}, false
+	}
+
+	return command{
+		name: strings.ToLower(name),
+		args: strings.TrimSpace(text[end:]),
+		del:  del || delAfterUsername,
+	}, true
+}
+
+// utf16Offset converts the offset in UTF-16 code units, used by Telegram entities, into the byte offset.
+func utf16Offset(text string, units int) int {
+	n := 0
+	for i, r := range text {
+		if n >= units {
+			return i
+		}
+
+		n += utf16.RuneLen(r)
+	}
+
+	return len(text)
+}
@@ -0,0 +1,189 @@
+package observer
+
+import (
+	"testing"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_parseCommand(t *testing.T) {
+	t.Parallel()
+
+	const botUsername = "geeksonator_bot"
+
+	tests := []struct {
+		name    string
+		message *tgbotapi.Message
+		want    command
+		wantOk  bool
+	}{
+		{
+			name:    "Plain command",
+			message: &tgbotapi.Message{Text: "/php"},
+			want:    command{name: "php"},
+			wantOk:  true,
+		},
+		{
+			name: "Bot username suffix",
+			message: &tgbotapi.Message{
+				Text:     "/php@Geeksonator_Bot",
+				Entities: []tgbotapi.MessageEntity{{Type: "bot_command", Offset: 0, Length: 20}},
+			},
+			want:   command{name: "php"},
+			wantOk: true,
+		},
+		{
+			name: "Other bot username",
+			message: &tgbotapi.Message{
+				Text:     "/php@other_bot",
+				Entities: []tgbotapi.MessageEntity{{Type: "bot_command", Offset: 0, Length: 14}},
+			},
+			want:   command{},
+			wantOk: false,
+		},
+		{
+			name:    "Upper case",
+			message: &tgbotapi.Message{Text: "/PHP"},
+			want:    command{name: "php"},
+			wantOk:  true,
+		},
+		{
+			name:    "Trailing space",
+			message: &tgbotapi.Message{Text: "/php "},
+			want:    command{name: "php"},
+			wantOk:  true,
+		},
+		{
+			name: "Arguments",
+			message: &tgbotapi.Message{
+				Text:     "/php some words",
+				Entities: []tgbotapi.MessageEntity{{Type: "bot_command", Offset: 0, Length: 4}},
+			},
+			want:   command{name: "php", args: "some words"},
+			wantOk: true,
+		},
+		{
+			name: "Entity ends before punctuation",
+			message: &tgbotapi.Message{
+				Text:     "/php, please",
+				Entities: []tgbotapi.MessageEntity{{Type: "bot_command", Offset: 0, Length: 4}},
+			},
+			want:   command{name: "php", args: ", please"},
+			wantOk: true,
+		},
+		{
+			name:    "Cyrillic command without entity",
+			message: &tgbotapi.Message{Text: "/ПХП\nвопрос"},
+			want:    command{name: "пхп", args: "вопрос"},
+			wantOk:  true,
+		},
+		{
+			name: "Media caption",
+			message: &tgbotapi.Message{
+				Caption:         "/code 😀 look",
+				CaptionEntities: []tgbotapi.MessageEntity{{Type: "bot_command", Offset: 0, Length: 5}},
+			},
+			want:   command{name: "code", args: "😀 look"},
+			wantOk: true,
+		},
+		{
+			name: "Delete suffix after entity",
+			message: &tgbotapi.Message{
+				Text:     "/code! please",
+				Entities: []tgbotapi.MessageEntity{{Type: "bot_command", Offset: 0, Length: 5}},
+			},
+			want:   command{name: "code", args: "please", del: true},
+			wantOk: true,
+		},
+		{
+			name: "Delete suffix after bot username",
+			message: &tgbotapi.Message{
+				Text:     "/code@geeksonator_bot!",
+				Entities: []tgbotapi.MessageEntity{{Type: "bot_command", Offset: 0, Length: 21}},
+			},
+			want:   command{name: "code", del: true},
+			wantOk: true,
+		},
+		{
+			name:    "Cyrillic command with delete suffix",
+			message: &tgbotapi.Message{Text: "/код!"},
+			want:    command{name: "код", del: true},
+			wantOk:  true,
+		},
+		{
+			name:    "Only delete suffix",
+			message: &tgbotapi.Message{Text: "/!"},
+			want:    command{},
+			wantOk:  false,
+		},
+		{
+			name: "Command is not at the beginning",
+			message: &tgbotapi.Message{
+				Text:     "see /php",
+				Entities: []tgbotapi.MessageEntity{{Type: "bot_command", Offset: 4, Length: 4}},
+			},
+			want:   command{},
+			wantOk: false,
+		},
+		{
+			name:    "Only slash",
+			message: &tgbotapi.Message{Text: "/ php"},
+			want:    command{},
+			wantOk:  false,
+		},
+		{
+			name:    "Empty",
+			message: &tgbotapi.Message{},
+			want:    command{},
+			wantOk:  false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, ok := parseCommand(tt.message, botUsername)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantOk, ok)
+		})
+	}
+}
+
+func Test_utf16Offset(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		text  string
+		units int
+		want  int
+	}{
+		{
+			name:  "ASCII",
+			text:  "/php args",
+			units: 4,
+			want:  4,
+		},
+		{
+			name:  "Surrogate pair",
+			text:  "😀/php",
+			units: 2,
+			want:  4,
+		},
+		{
+			name:  "Out of range",
+			text:  "/php",
+			units: 10,
+			want:  4,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := utf16Offset(tt.text, tt.units)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,129 @@
+package observer
+
+import (
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"go.uber.org/zap"
+
+	"geeksonator/internal/audit"
+	"geeksonator/internal/catalog"
+	"geeksonator/internal/posts"
+)
+
+// checkPost applies the posts rules of the chat: the post of the author who already posted within the interval
+// or near-identical to a recent post is deleted and the author is notified, the accepted post is recorded
+// and scheduled for deletion at the max age. Admins, commands, replies and messages without text aren't checked,
+// the admins are fetched only for the post to be deleted. It returns true if the post is deleted.
+func (m *Manager) checkPost(message *tgbotapi.Message) bool {
+	if m.posts == nil || message.From == nil || message.Chat == nil || message.Chat.IsPrivate() || repliedMessage(message) != nil {
+		return false
+	}
+
+	text := content(message)
+	if text == "" || strings.HasPrefix(text, "/") {
+		return false
+	}
+
+	cfg, ok := m.commands.Posts(chatRef(message.Chat))
+	if !ok {
+		return false
+	}
+
+	now := m.timeNow()
+
+	if cfg.Interval > 0 {
+		since := now.Add(-cfg.IntervalValue())
+		post := posts.Post{
+			MessageID:   message.MessageID,
+			UserID:      message.From.ID,
+			Fingerprint: posts.Fingerprint(text),
+			Date:        now,
+		}
+
+		if prev, ok := findRepost(m.posts.Recent(message.Chat.ID, since), post, cfg); ok {
+			if m.exemptAuthor(message) {
+				return false
+			}
+
+			return m.rejectRepost(message, prev, post, cfg)
+		}
+
+		if err := m.posts.Add(message.Chat.ID, post, since); err != nil {
+			m.log("Add post",
+				zap.Int("messageID", message.MessageID),
+				zap.Error(err),
+			)
+		}
+	}
+
+	if cfg.MaxAge > 0 && m.scheduler != nil && !m.exemptAuthor(message) {
+		if err := m.scheduler.Schedule(message.Chat.ID, message.MessageID, now.Add(cfg.MaxAgeValue())); err != nil {
+			m.log("Schedule post expiry",
+				zap.Int("messageID", message.MessageID),
+				zap.Error(err),
+			)
+		}
+	}
+
+	return false
+}
+
+// rejectRepost deletes the repost and notifies the author when the next post is allowed.
+// It returns false if the repost can't be deleted.
+func (m *Manager) rejectRepost(message *tgbotapi.Message, prev, post posts.Post, cfg *catalog.Posts) bool {
+	err := m.bot.DeleteMessage(message.Chat.ID, message.MessageID)
+	if err != nil {
+		m.log("Delete repost",
+			zap.Int("messageID", message.MessageID),
+			zap.Error(err),
+		)
+
+		return false
+	}
+
+	loc := post.Date.Location()
+	date := prev.Date.In(loc).Format("02.01.2006 15:04")
+	next := prev.Date.Add(cfg.IntervalValue()).In(loc).Format("02.01.2006 15:04")
+
+	published := date
+	if link := messageLink(message.Chat, prev.MessageID); link != "" {
+		published = `<a href="` + link + `">` + date + "</a>"
+	}
+
+	e := m.auditEvent(audit.ActionRepost, message.Chat, message, nil)
+	e.Content = content(message)
+
+	var text string
+
+	if cfg.PerAuthor && prev.UserID == post.UserID {
+		e.Reason = "пост автора от " + date
+		text = "Публикация удалена: вы уже публиковали пост " + published + ", следующий можно опубликовать после " + next + "."
+	} else {
+		e.Reason = "похожий пост от " + date
+		text = "Публикация удалена: похожий пост уже опубликован " + published + ", повторить его можно после " + next + "."
+	}
+
+	m.audit(message.Chat, e)
+	m.notifyAuthor(message, text)
+
+	return true
+}
+
+// findRepost returns the latest of the recent posts the post repeats: the post of the same author if the author
+// is limited to one post per interval or the post with the similar text.
+func findRepost(recent []posts.Post, post posts.Post, cfg *catalog.Posts) (posts.Post, bool) {
+	for i := len(recent) - 1; i >= 0; i-- {
+		prev := recent[i]
+
+		if cfg.PerAuthor && prev.UserID == post.UserID {
+			return prev, true
+		}
+
+		if posts.Similarity(prev.Fingerprint, post.Fingerprint) >= cfg.SimilarityValue() {
+			return prev, true
+		}
+	}
+
+	return posts.Post{}, false
+}
@@ -0,0 +1,211 @@
+package observer
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/stretchr/testify/assert"
+
+	"geeksonator/internal/audit"
+	"geeksonator/internal/catalog"
+	"geeksonator/internal/observer/mocks"
+	"geeksonator/internal/posts"
+)
+
+func TestManager_processingMessage_Repost(t *testing.T) {
+	t.Parallel()
+
+	const text = "Ищем Senior PHP разработчика в команду платежей, зарплата от 300k, удалённо. Пишите @hr"
+
+	ref := catalog.ChatRef{ID: 300600, Username: "jobGeeks"}
+	week := catalog.Duration(7 * 24 * time.Hour)
+	published := moderationNow.Add(-48 * time.Hour)
+
+	tests := []struct {
+		name   string
+		cfg    *catalog.Posts
+		recent []posts.Post
+		// admins are the chat admins, the admins aren't fetched if nil
+		admins    []tgbotapi.ChatMember
+		adminsErr error
+		// accepted is set if the post is recorded
+		accepted bool
+		want     string
+		reason   string
+	}{
+		{
+			name: "Accepted post expires",
+			cfg:  &catalog.Posts{Interval: week, MaxAge: catalog.Duration(48 * time.Hour)},
+			recent: []posts.Post{
+				{MessageID: 40, UserID: 100501, Fingerprint: posts.Fingerprint("Нужен Go разработчик в офис в Москве"), Date: published},
+			},
+			admins:   chatAdmins(100500),
+			accepted: true,
+		},
+		{
+			name:     "Accepted post without expiry",
+			cfg:      &catalog.Posts{Interval: week},
+			accepted: true,
+		},
+		{
+			name: "Admin repost is kept",
+			cfg:  &catalog.Posts{Interval: week, PerAuthor: true},
+			recent: []posts.Post{
+				{MessageID: 40, UserID: 100501, Fingerprint: posts.Fingerprint("Нужен Go разработчик в офис в Москве"), Date: published},
+			},
+			admins: chatAdmins(100501),
+		},
+		{
+			name: "Repost is kept if the admins can't be fetched",
+			cfg:  &catalog.Posts{Interval: week, PerAuthor: true},
+			recent: []posts.Post{
+				{MessageID: 40, UserID: 100501, Fingerprint: posts.Fingerprint("Нужен Go разработчик в офис в Москве"), Date: published},
+			},
+			adminsErr: errors.New("Too Many Requests: retry after 5"),
+		},
+		{
+			name: "Author limited to one post",
+			cfg:  &catalog.Posts{Interval: week, PerAuthor: true},
+			recent: []posts.Post{
+				{MessageID: 40, UserID: 100501, Fingerprint: posts.Fingerprint("Нужен Go разработчик в офис в Москве"), Date: published},
+			},
+			admins: chatAdmins(100500),
+			want: `Публикация удалена: вы уже публиковали пост <a href="https://t.me/jobGeeks/40">06.03.2024 12:00</a>, ` +
+				"следующий можно опубликовать после 13.03.2024 12:00.",
+			reason: "пост автора от 06.03.2024 12:00",
+		},
+		{
+			name: "Similar post of another author",
+			cfg:  &catalog.Posts{Interval: week},
+			recent: []posts.Post{
+				{MessageID: 40, UserID: 100502, Fingerprint: posts.Fingerprint(text + "!"), Date: published},
+				{MessageID: 41, UserID: 100501, Fingerprint: posts.Fingerprint("Нужен Go разработчик в офис в Москве"), Date: published},
+			},
+			admins: chatAdmins(100500),
+			want: `Публикация удалена: похожий пост уже опубликован <a href="https://t.me/jobGeeks/40">06.03.2024 12:00</a>, ` +
+				"повторить его можно после 13.03.2024 12:00.",
+			reason: "похожий пост от 06.03.2024 12:00",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			botProvider := mocks.NewBotProviderMock(t)
+			commands := messageCommands(t)
+			store := mocks.NewPostsMock(t)
+			scheduler := mocks.NewSchedulerMock(t)
+			auditor := mocks.NewAuditorMock(t)
+			cache := mocks.NewCacheMock(t)
+
+			switch {
+			case tt.admins != nil:
+				cache.EXPECT().
+					Get(int64(300600)).
+					Return(tt.admins, true)
+			case tt.adminsErr != nil:
+				cache.EXPECT().
+					Get(int64(300600)).
+					Return(nil, false)
+
+				botProvider.EXPECT().
+					GetChatAdministrators(tgbotapi.ChatConfig{ChatID: 300600}).
+					Return(nil, tt.adminsErr)
+			}
+
+			commands.EXPECT().
+				Posts(ref).
+				Return(tt.cfg, true)
+
+			store.EXPECT().
+				Recent(int64(300600), moderationNow.Add(-time.Duration(week))).
+				Return(tt.recent)
+
+			if tt.accepted {
+				store.EXPECT().
+					Add(int64(300600), posts.Post{
+						MessageID:   42,
+						UserID:      100501,
+						Fingerprint: posts.Fingerprint(text),
+						Date:        moderationNow,
+					}, moderationNow.Add(-time.Duration(week))).
+					Return(nil)
+
+				if tt.cfg.MaxAge > 0 {
+					scheduler.EXPECT().
+						Schedule(int64(300600), 42, moderationNow.Add(48*time.Hour)).
+						Return(nil)
+				}
+			}
+
+			if tt.want == "" {
+				// the kept post is processed as a usual message
+				commands.EXPECT().
+					Lookup(ref, "").
+					Return(nil, false).
+					Maybe()
+			} else {
+				botProvider.EXPECT().
+					DeleteMessage(int64(300600), 42).
+					Return(nil)
+
+				auditor.EXPECT().
+					Write(audit.Event{
+						Date:     moderationNow,
+						Action:   audit.ActionRepost,
+						ChatID:   300600,
+						Chat:     "Jobs",
+						TargetID: 100501,
+						Target:   "@author",
+						Reason:   tt.reason,
+						Link:     "https://t.me/jobGeeks/42",
+						Content:  text,
+					}).
+					Return(nil)
+
+				commands.EXPECT().
+					Audit(ref).
+					Return(&catalog.Audit{})
+
+				private := "Чат: Jobs\n" + tt.want + "\n\nВаш текст:\n<blockquote>" + text + "</blockquote>"
+				msg := tgbotapi.NewMessage(100501, private)
+
+				botProvider.EXPECT().
+					NewMessage(int64(100501), private).
+					Return(msg)
+
+				msg.ParseMode = "html"
+
+				botProvider.EXPECT().
+					Send(msg).
+					Return(tgbotapi.Message{}, nil)
+			}
+
+			m := &Manager{
+				bot:       botProvider,
+				cache:     cache,
+				commands:  commands,
+				scheduler: scheduler,
+				auditor:   auditor,
+				posts:     store,
+				now:       func() time.Time { return moderationNow },
+			}
+
+			got, err := m.processingMessage(&tgbotapi.Message{
+				MessageID: 42,
+				Chat:      &tgbotapi.Chat{ID: 300600, Title: "Jobs", UserName: "jobGeeks", Type: "supergroup"},
+				From:      &tgbotapi.User{ID: 100501, UserName: "author"},
+				Text:      text,
+			})
+			assert.NoError(t, err)
+			assert.Empty(t, got.texts)
+		})
+	}
+}
+
+// chatAdmins returns the admins of the chat with the user.
+func chatAdmins(adminID int64) []tgbotapi.ChatMember {
+	return []tgbotapi.ChatMember{{User: &tgbotapi.User{ID: adminID}}}
+}
@@ -0,0 +1,430 @@
+package observer
+
+import (
+	"fmt"
+	"html"
+	"strconv"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"go.uber.org/zap"
+
+	"geeksonator/internal/audit"
+)
+
+const (
+	// reportPrefix is the prefix of the callback data of the report buttons: report:<action>:<chatID>:<messageID>.
+	reportPrefix = "report:"
+	// reportTTL is the time the report is kept, Telegram doesn't allow to delete older messages.
+	reportTTL = 48 * time.Hour
+	// reportMuteDuration is the mute duration of the reported author.
+	reportMuteDuration = 24 * time.Hour
+)
+
+// reportAction is the action of the report button.
+type reportAction string
+
+const (
+	reportDelete  reportAction = "del"
+	reportMute    reportAction = "mute"
+	reportBan     reportAction = "ban"
+	reportDismiss reportAction = "dismiss"
+)
+
+// reportKey identifies the reported message.
+type reportKey struct {
+	chatID    int64
+	messageID int
+}
+
+// notification is the report message sent to the log chat or to the admin.
+type notification struct {
+	chatID    int64
+	messageID int
+}
+
+// pendingReport is the report waiting for the admin decision.
+type pendingReport struct {
+	// target is the author of the reported message.
+	target *tgbotapi.User
+	// text is the text of the notifications.
+	text string
+	// notifications are the sent notifications, they're updated with the decision.
+	notifications []notification
+	// date is the date of the report.
+	date time.Time
+	// chat and message are the reported message, it's logged to the audit log with the decision.
+	chat    *tgbotapi.Chat
+	message *tgbotapi.Message
+	// resolved is set after the decision, the repeated reports of the message are ignored.
+	resolved bool
+}
+
+// report notifies the admins about the replied message in the log chat and privately the subscribed ones.
+// The repeated reports of the message are ignored.
+func (m *Manager) report(req *request) ([]string, error) {
+	target, refusal, err := m.moderationTarget(req)
+	if err != nil {
+		return nil, fmt.Errorf("m.moderationTarget: %v", err)
+	}
+
+	if target == nil {
+		return []string{refusal}, nil
+	}
+
+	title := "Жалоба от " + mention(req.message.From) + "." + reasonLine(strings.Join(strings.Fields(req.args), " "))
+
+	reply, err := m.sendReport(req.message.Chat, req.message.ReplyToMessage, target, title)
+	if err != nil {
+		return nil, fmt.Errorf("m.sendReport: %v", err)
+	}
+
+	return []string{reply}, nil
+}
+
+// sendReport posts the report about the message of the target to the log chat and privately to the subscribed admins
+// with the decision buttons. The repeated reports of the message are ignored. It returns the reply to the reporter.
+func (m *Manager) sendReport(chat *tgbotapi.Chat, reported *tgbotapi.Message, target *tgbotapi.User, title string) (string, error) {
+	key := reportKey{chatID: chat.ID, messageID: reported.MessageID}
+
+	if !m.reserveReport(key, target) {
+		return "Жалоба на это сообщение уже отправлена администраторам.", nil
+	}
+
+	recipients, err := m.reportRecipients(chat)
+	if err != nil {
+		m.dropReport(key)
+
+		return "", fmt.Errorf("m.reportRecipients: %v", err)
+	}
+
+	if len(recipients) == 0 {
+		m.dropReport(key)
+
+		return "Жалобы в этом чате не настроены.", nil
+	}
+
+	text := reportText(chat, reported, title)
+	keyboard := reportKeyboard(key)
+
+	var sent []notification
+	for _, chatID := range recipients {
+		msg := m.bot.NewMessage(chatID, text)
+		msg.ParseMode = "html"
+		msg.DisableWebPagePreview = true
+		msg.ReplyMarkup = keyboard
+
+		notice, err := m.bot.Send(msg)
+		if err != nil {
+			m.log("Send report",
+				zap.Int64("chatID", chatID),
+				zap.Error(err),
+			)
+
+			continue
+		}
+
+		sent = append(sent, notification{chatID: chatID, messageID: notice.MessageID})
+	}
+
+	if len(sent) == 0 {
+		m.dropReport(key)
+
+		return "Не удалось отправить жалобу администраторам.", nil
+	}
+
+	m.reportsMu.Lock()
+	m.pendingReports[key].text = text
+	m.pendingReports[key].notifications = sent
+	m.pendingReports[key].chat = chat
+	m.pendingReports[key].message = reported
+	m.reportsMu.Unlock()
+
+	return "Жалоба отправлена администраторам.", nil
+}
+
+// reportRecipients returns the log chat of the chat and the subscribed admins who are still the chat admins.
+func (m *Manager) reportRecipients(chat *tgbotapi.Chat) ([]int64, error) {
+	var recipients []int64
+
+	if logChat := m.commands.Reports(chatRef(chat)).LogChat; logChat != 0 {
+		recipients = append(recipients, logChat)
+	}
+
+	if m.reports == nil {
+		return recipients, nil
+	}
+
+	subscribers := m.reports.Subscribers(chat.ID)
+	if len(subscribers) == 0 {
+		return recipients, nil
+	}
+
+	admins, err := m.getAdmins(chat.ChatConfig())
+	if err != nil {
+		return nil, fmt.Errorf("m.getAdmins: %v", err)
+	}
+
+	for _, userID := range subscribers {
+		if authorIsAdmin(admins, userID) {
+			recipients = append(recipients, userID)
+		}
+	}
+
+	return recipients, nil
+}
+
+// reserveReport registers the report of the message, false if the message is already reported.
+// The reports older than reportTTL are forgotten.
+func (m *Manager) reserveReport(key reportKey, target *tgbotapi.User) bool {
+	m.reportsMu.Lock()
+	defer m.reportsMu.Unlock()
+
+	if m.pendingReports == nil {
+		m.pendingReports = make(map[reportKey]*pendingReport)
+	}
+
+	now := m.timeNow()
+	for k, pending := range m.pendingReports {
+		if now.Sub(pending.date) > reportTTL {
+			delete(m.pendingReports, k)
+		}
+	}
+
+	if _, ok := m.pendingReports[key]; ok {
+		return false
+	}
+
+	m.pendingReports[key] = &pendingReport{
+		target: target,
+		date:   now,
+	}
+
+	return true
+}
+
+// dropReport forgets the report which wasn't delivered, so the message can be reported again.
+func (m *Manager) dropReport(key reportKey) {
+	m.reportsMu.Lock()
+	defer m.reportsMu.Unlock()
+
+	delete(m.pendingReports, key)
+}
+
+// resolveReport marks the report resolved and returns its copy, false if there's no pending report.
+func (m *Manager) resolveReport(key reportKey) (pendingReport, bool) {
+	m.reportsMu.Lock()
+	defer m.reportsMu.Unlock()
+
+	pending, ok := m.pendingReports[key]
+	if !ok || pending.resolved || len(pending.notifications) == 0 {
+		return pendingReport{}, false
+	}
+
+	pending.resolved = true
+
+	return *pending, true
+}
+
+// processingReportCallback applies the action of the report button pressed by the chat admin
+// and updates all the notifications of the report with the decision.
+func (m *Manager) processingReportCallback(query *tgbotapi.CallbackQuery) {
+	action, key, ok := parseReportData(query.Data)
+	if !ok || query.From == nil {
+		return
+	}
+
+	admins, err := m.getAdmins(tgbotapi.ChatConfig{ChatID: key.chatID})
+	if err != nil {
+		m.log("Get admins of the reported chat",
+			zap.Int64("chatID", key.chatID),
+			zap.Error(err),
+		)
+		m.answerCallback(query.ID, "Не удалось проверить права администратора.")
+
+		return
+	}
+
+	if !authorIsAdmin(admins, query.From.ID) {
+		m.answerCallback(query.ID, "Жалобу может рассмотреть только администратор чата.")
+
+		return
+	}
+
+	pending, ok := m.resolveReport(key)
+	if !ok {
+		m.answerCallback(query.ID, "Жалоба уже рассмотрена.")
+
+		return
+	}
+
+	decision := m.applyReportAction(action, key, pending, query.From)
+	text := pending.text + "\n\n" + decision + "\nАдминистратор: " + mention(query.From)
+
+	for _, n := range pending.notifications {
+		if err := m.bot.EditMessageText(n.chatID, n.messageID, text); err != nil {
+			m.log("Edit report",
+				zap.Int64("chatID", n.chatID),
+				zap.Error(err),
+			)
+		}
+	}
+
+	m.answerCallback(query.ID, "Готово.")
+}
+
+// applyReportAction applies the action of the admin to the reported message and its author
+// and returns the decision text. The message is deleted for all the actions except the dismissal.
+func (m *Manager) applyReportAction(action reportAction, key reportKey, pending pendingReport, admin *tgbotapi.User) string {
+	if action == reportDismiss {
+		return "Жалоба отклонена."
+	}
+
+	target := pending.target
+
+	err := m.bot.DeleteMessage(key.chatID, key.messageID)
+	if err != nil {
+		m.log("Delete reported message",
+			zap.Int("messageID", key.messageID),
+			zap.Error(err),
+		)
+	} else {
+		m.auditReport(pending, audit.ActionDelete, admin, 0)
+	}
+
+	switch action {
+	case reportMute:
+		text, ok := m.muteMember(key.chatID, target, reportMuteDuration)
+		if ok {
+			m.auditReport(pending, audit.ActionMute, admin, reportMuteDuration)
+		}
+
+		return text
+	case reportBan:
+		text, ok := m.banMember(key.chatID, target, 0, false)
+		if ok {
+			m.auditReport(pending, audit.ActionBan, admin, 0)
+		}
+
+		return text
+	case reportDelete, reportDismiss:
+	}
+
+	if err != nil {
+		return "Не удалось удалить сообщение: " + html.EscapeString(err.Error())
+	}
+
+	return "Сообщение удалено."
+}
+
+// auditReport logs the action of the admin applied on the report.
+func (m *Manager) auditReport(pending pendingReport, action audit.Action, admin *tgbotapi.User, d time.Duration) {
+	if pending.chat == nil || pending.message == nil {
+		return
+	}
+
+	e := m.auditEvent(action, pending.chat, pending.message, admin)
+	e.Duration = auditDuration(d)
+	e.Reason = "жалоба"
+
+	if action == audit.ActionDelete {
+		e.Content = content(pending.message)
+	}
+
+	m.audit(pending.chat, e)
+}
+
+// reportKeyboard returns the inline buttons of the report.
+func reportKeyboard(key reportKey) tgbotapi.InlineKeyboardMarkup {
+	data := func(action reportAction) string {
+		return reportPrefix + string(action) + ":" + strconv.FormatInt(key.chatID, 10) + ":" + strconv.Itoa(key.messageID)
+	}
+
+	return tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("Удалить", data(reportDelete)),
+			tgbotapi.NewInlineKeyboardButtonData("Мут на день", data(reportMute)),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("Бан", data(reportBan)),
+			tgbotapi.NewInlineKeyboardButtonData("Отклонить", data(reportDismiss)),
+		),
+	)
+}
+
+// parseReportData parses the callback data of the report button.
+func parseReportData(data string) (reportAction, reportKey, bool) {
+	rest, ok := strings.CutPrefix(data, reportPrefix)
+	if !ok {
+		return "", reportKey{}, false
+	}
+
+	parts := strings.Split(rest, ":")
+	if len(parts) != 3 {
+		return "", reportKey{}, false
+	}
+
+	action := reportAction(parts[0])
+	switch action {
+	case reportDelete, reportMute, reportBan, reportDismiss:
+	default:
+		return "", reportKey{}, false
+	}
+
+	chatID, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return "", reportKey{}, false
+	}
+
+	messageID, err := strconv.Atoi(parts[2])
+	if err != nil {
+		return "", reportKey{}, false
+	}
+
+	return action, reportKey{chatID: chatID, messageID: messageID}, true
+}
+
+// reportsCmd subscribes the admin to the reports of the chat: /reports on, /reports off, /reports shows the state.
+func (m *Manager) reportsCmd(req *request) ([]string, error) {
+	if m.reports == nil {
+		return []string{"Подписка на жалобы отключена."}, nil
+	}
+
+	chatID, userID := req.message.Chat.ID, req.message.From.ID
+
+	switch strings.ToLower(strings.TrimSpace(req.args)) {
+	case "":
+		for _, id := range m.reports.Subscribers(chatID) {
+			if id == userID {
+				return []string{"Вы подписаны на жалобы этого чата. Отписаться: <code>/reports off</code>."}, nil
+			}
+		}
+
+		return []string{"Вы не подписаны на жалобы этого чата. Подписаться: <code>/reports on</code>."}, nil
+	case "on":
+		if err := m.reports.Subscribe(chatID, userID); err != nil {
+			m.log("Subscribe to reports",
+				zap.Int64("userID", userID),
+				zap.Error(err),
+			)
+
+			return []string{"Не удалось подписаться: " + html.EscapeString(err.Error())}, nil
+		}
+
+		return []string{"Жалобы из этого чата будут приходить вам в личные сообщения. Если бот не может вам написать, начните с ним диалог."}, nil
+	case "off":
+		if err := m.reports.Unsubscribe(chatID, userID); err != nil {
+			m.log("Unsubscribe from reports",
+				zap.Int64("userID", userID),
+				zap.Error(err),
+			)
+
+			return []string{"Не удалось отписаться: " + html.EscapeString(err.Error())}, nil
+		}
+
+		return []string{"Вы отписались от жалоб этого чата."}, nil
+	default:
+		return []string{"Использование: <code>/reports on</code> или <code>/reports off</code>."}, nil
+	}
+}
@@ -0,0 +1,434 @@
+package observer
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/stretchr/testify/assert"
+
+	"geeksonator/internal/catalog"
+	"geeksonator/internal/observer/mocks"
+)
+
+// reportRequest returns the /report request of the member replied to the message of the user.
+func reportRequest(args string, target *tgbotapi.User) *request {
+	req := moderationRequest(catalog.HandlerReport, args, target)
+	req.role = catalog.RoleMember
+	req.message.Chat.Title = "PHP"
+	req.message.From.UserName = "reporter"
+
+	if target != nil {
+		req.message.ReplyToMessage.Text = "Лучшее казино"
+	}
+
+	return req
+}
+
+func TestManager_report(t *testing.T) {
+	t.Parallel()
+
+	spammer := &tgbotapi.User{ID: 100501, UserName: "spammer"}
+	text := "Жалоба от @reporter.\nПричина: спам\nЧат: PHP\nАвтор: @spammer\n<blockquote>Лучшее казино</blockquote>"
+	keyboard := reportKeyboard(reportKey{chatID: 300600, messageID: 41})
+
+	sendReport := func(botProvider *mocks.BotProviderMock, chatID int64, sent int, err error) {
+		botProvider.EXPECT().
+			NewMessage(chatID, text).
+			Return(tgbotapi.MessageConfig{BaseChat: tgbotapi.BaseChat{ChatID: chatID}, Text: text})
+
+		botProvider.EXPECT().
+			Send(tgbotapi.MessageConfig{
+				BaseChat:              tgbotapi.BaseChat{ChatID: chatID, ReplyMarkup: keyboard},
+				Text:                  text,
+				ParseMode:             "html",
+				DisableWebPagePreview: true,
+			}).
+			Return(tgbotapi.Message{MessageID: sent}, err)
+	}
+
+	tests := []struct {
+		name        string
+		logChat     int64
+		subscribers []int64
+		reported    bool
+		target      *tgbotapi.User
+		expect      func(botProvider *mocks.BotProviderMock)
+		want        []string
+		wantSent    []notification
+	}{
+		{
+			name: "Not a reply",
+			want: []string{notReplyTxt},
+		},
+		{
+			name:     "Already reported",
+			target:   spammer,
+			reported: true,
+			want:     []string{"Жалоба на это сообщение уже отправлена администраторам."},
+		},
+		{
+			name:   "No recipients",
+			target: spammer,
+			want:   []string{"Жалобы в этом чате не настроены."},
+		},
+		{
+			name:        "Log chat and subscribed admins",
+			target:      spammer,
+			logChat:     -100100,
+			subscribers: []int64{100500, 100502},
+			expect: func(botProvider *mocks.BotProviderMock) {
+				sendReport(botProvider, -100100, 7, nil)
+				sendReport(botProvider, 100500, 8, nil)
+			},
+			want:     []string{"Жалоба отправлена администраторам."},
+			wantSent: []notification{{chatID: -100100, messageID: 7}, {chatID: 100500, messageID: 8}},
+		},
+		{
+			name:        "Delivery failed",
+			target:      spammer,
+			subscribers: []int64{100500},
+			expect: func(botProvider *mocks.BotProviderMock) {
+				sendReport(botProvider, 100500, 0, errors.New("bot can't initiate conversation"))
+			},
+			want: []string{"Не удалось отправить жалобу администраторам."},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			botProvider := mocks.NewBotProviderMock(t)
+			commands := mocks.NewCommandsMock(t)
+			store := mocks.NewReportsMock(t)
+
+			var cache Cache = mocks.NewCacheMock(t)
+
+			if tt.target != nil {
+				cache = adminsCache(t, 100500)
+			}
+
+			if tt.target != nil && !tt.reported {
+				commands.EXPECT().
+					Reports(catalog.ChatRef{ID: 300600}).
+					Return(&catalog.Reports{LogChat: tt.logChat})
+
+				store.EXPECT().
+					Subscribers(int64(300600)).
+					Return(tt.subscribers)
+			}
+
+			if tt.expect != nil {
+				tt.expect(botProvider)
+			}
+
+			m := &Manager{
+				bot:      botProvider,
+				cache:    cache,
+				commands: commands,
+				reports:  store,
+				now:      func() time.Time { return moderationNow },
+			}
+
+			if tt.reported {
+				m.reserveReport(reportKey{chatID: 300600, messageID: 41}, spammer)
+			}
+
+			got, err := m.report(reportRequest("спам", tt.target))
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+
+			pending, ok := m.pendingReports[reportKey{chatID: 300600, messageID: 41}]
+			assert.Equal(t, tt.reported || tt.wantSent != nil, ok)
+
+			if tt.wantSent != nil {
+				assert.Equal(t, text, pending.text)
+				assert.Equal(t, tt.wantSent, pending.notifications)
+			}
+		})
+	}
+}
+
+func TestManager_reserveReport_Expired(t *testing.T) {
+	t.Parallel()
+
+	now := moderationNow
+	m := &Manager{
+		now: func() time.Time { return now },
+	}
+
+	key := reportKey{chatID: 300600, messageID: 41}
+
+	assert.True(t, m.reserveReport(key, &tgbotapi.User{ID: 100501}))
+	assert.False(t, m.reserveReport(key, &tgbotapi.User{ID: 100501}))
+
+	now = now.Add(reportTTL + time.Second)
+	assert.True(t, m.reserveReport(key, &tgbotapi.User{ID: 100501}), "expired report is forgotten")
+}
+
+func TestManager_processingReportCallback(t *testing.T) {
+	t.Parallel()
+
+	spammer := &tgbotapi.User{ID: 100501, UserName: "spammer"}
+	admin := &tgbotapi.User{ID: 100500, UserName: "admin"}
+
+	editReports := func(botProvider *mocks.BotProviderMock, decision string) {
+		text := "Жалоба\n\n" + decision + "\nАдминистратор: @admin"
+
+		botProvider.EXPECT().
+			EditMessageText(int64(-100100), 7, text).
+			Return(nil)
+
+		botProvider.EXPECT().
+			EditMessageText(int64(100500), 8, text).
+			Return(errors.New("message is not modified"))
+	}
+
+	tests := []struct {
+		name     string
+		from     *tgbotapi.User
+		data     string
+		resolved bool
+		expect   func(botProvider *mocks.BotProviderMock)
+		answer   string
+	}{
+		{
+			name:   "Not an admin",
+			from:   spammer,
+			data:   "report:ban:300600:41",
+			answer: "Жалобу может рассмотреть только администратор чата.",
+		},
+		{
+			name:     "Already resolved",
+			from:     admin,
+			data:     "report:ban:300600:41",
+			resolved: true,
+			answer:   "Жалоба уже рассмотрена.",
+		},
+		{
+			name: "Delete",
+			from: admin,
+			data: "report:del:300600:41",
+			expect: func(botProvider *mocks.BotProviderMock) {
+				botProvider.EXPECT().
+					DeleteMessage(int64(300600), 41).
+					Return(nil)
+
+				editReports(botProvider, "Сообщение удалено.")
+			},
+			answer: "Готово.",
+		},
+		{
+			name: "Delete failed",
+			from: admin,
+			data: "report:del:300600:41",
+			expect: func(botProvider *mocks.BotProviderMock) {
+				botProvider.EXPECT().
+					DeleteMessage(int64(300600), 41).
+					Return(errors.New("message to delete not found"))
+
+				editReports(botProvider, "Не удалось удалить сообщение: message to delete not found")
+			},
+			answer: "Готово.",
+		},
+		{
+			name: "Mute",
+			from: admin,
+			data: "report:mute:300600:41",
+			expect: func(botProvider *mocks.BotProviderMock) {
+				botProvider.EXPECT().
+					DeleteMessage(int64(300600), 41).
+					Return(nil)
+
+				botProvider.EXPECT().
+					RestrictChatMember(int64(300600), int64(100501), tgbotapi.ChatPermissions{}, moderationNow.Add(24*time.Hour)).
+					Return(nil)
+
+				editReports(botProvider, "Пользователь @spammer не может писать в чат на 1 день, до 09.03.2024 12:00.")
+			},
+			answer: "Готово.",
+		},
+		{
+			name: "Ban",
+			from: admin,
+			data: "report:ban:300600:41",
+			expect: func(botProvider *mocks.BotProviderMock) {
+				botProvider.EXPECT().
+					DeleteMessage(int64(300600), 41).
+					Return(nil)
+
+				botProvider.EXPECT().
+					BanChatMember(int64(300600), int64(100501), time.Time{}, false).
+					Return(nil)
+
+				editReports(botProvider, "Пользователь @spammer забанен навсегда.")
+			},
+			answer: "Готово.",
+		},
+		{
+			name: "Dismiss",
+			from: admin,
+			data: "report:dismiss:300600:41",
+			expect: func(botProvider *mocks.BotProviderMock) {
+				editReports(botProvider, "Жалоба отклонена.")
+			},
+			answer: "Готово.",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			botProvider := mocks.NewBotProviderMock(t)
+
+			if tt.expect != nil {
+				tt.expect(botProvider)
+			}
+
+			botProvider.EXPECT().
+				AnswerCallbackQuery("100", tt.answer).
+				Return(nil)
+
+			m := &Manager{
+				bot:   botProvider,
+				cache: adminsCache(t, 100500),
+				now:   func() time.Time { return moderationNow },
+				pendingReports: map[reportKey]*pendingReport{
+					{chatID: 300600, messageID: 41}: {
+						target:        spammer,
+						text:          "Жалоба",
+						notifications: []notification{{chatID: -100100, messageID: 7}, {chatID: 100500, messageID: 8}},
+						date:          moderationNow,
+						resolved:      tt.resolved,
+					},
+				},
+			}
+
+			m.processingCallback(&tgbotapi.CallbackQuery{
+				ID:      "100",
+				From:    tt.from,
+				Message: &tgbotapi.Message{MessageID: 7, Chat: &tgbotapi.Chat{ID: -100100}},
+				Data:    tt.data,
+			})
+
+			assert.Equal(t, tt.from == admin, m.pendingReports[reportKey{chatID: 300600, messageID: 41}].resolved)
+		})
+	}
+}
+
+func TestParseReportData(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		data       string
+		wantAction reportAction
+		wantKey    reportKey
+		wantOk     bool
+	}{
+		{
+			name:       "Valid",
+			data:       "report:mute:-1001234567890:42",
+			wantAction: reportMute,
+			wantKey:    reportKey{chatID: -1001234567890, messageID: 42},
+			wantOk:     true,
+		},
+		{
+			name: "Unknown action",
+			data: "report:kick:-100500:42",
+		},
+		{
+			name: "Captcha data",
+			data: "captcha:100501:0",
+		},
+		{
+			name: "Invalid message ID",
+			data: "report:del:-100500:x",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			action, key, ok := parseReportData(tt.data)
+			assert.Equal(t, tt.wantAction, action)
+			assert.Equal(t, tt.wantKey, key)
+			assert.Equal(t, tt.wantOk, ok)
+		})
+	}
+}
+
+func TestManager_reportsCmd(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		args   string
+		expect func(store *mocks.ReportsMock)
+		want   string
+	}{
+		{
+			name: "Subscribed",
+			expect: func(store *mocks.ReportsMock) {
+				store.EXPECT().
+					Subscribers(int64(300600)).
+					Return([]int64{100502, 100500})
+			},
+			want: "Вы подписаны на жалобы этого чата. Отписаться: <code>/reports off</code>.",
+		},
+		{
+			name: "Not subscribed",
+			expect: func(store *mocks.ReportsMock) {
+				store.EXPECT().
+					Subscribers(int64(300600)).
+					Return(nil)
+			},
+			want: "Вы не подписаны на жалобы этого чата. Подписаться: <code>/reports on</code>.",
+		},
+		{
+			name: "On",
+			args: " ON ",
+			expect: func(store *mocks.ReportsMock) {
+				store.EXPECT().
+					Subscribe(int64(300600), int64(100500)).
+					Return(nil)
+			},
+			want: "Жалобы из этого чата будут приходить вам в личные сообщения. Если бот не может вам написать, начните с ним диалог.",
+		},
+		{
+			name: "Off failed",
+			args: "off",
+			expect: func(store *mocks.ReportsMock) {
+				store.EXPECT().
+					Unsubscribe(int64(300600), int64(100500)).
+					Return(errors.New("disk is full"))
+			},
+			want: "Не удалось отписаться: disk is full",
+		},
+		{
+			name: "Usage",
+			args: "yes",
+			want: "Использование: <code>/reports on</code> или <code>/reports off</code>.",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			store := mocks.NewReportsMock(t)
+
+			if tt.expect != nil {
+				tt.expect(store)
+			}
+
+			m := &Manager{
+				reports: store,
+			}
+
+			got, err := m.reportsCmd(moderationRequest(catalog.HandlerReports, tt.args, nil))
+			assert.NoError(t, err)
+			assert.Equal(t, []string{tt.want}, got)
+		})
+	}
+}
@@ -0,0 +1,232 @@
+package observer
+
+import (
+	"fmt"
+	"html"
+	"strconv"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"go.uber.org/zap"
+
+	"geeksonator/internal/audit"
+	"geeksonator/internal/catalog"
+	"geeksonator/internal/warnings"
+	"geeksonator/pkg/duration"
+)
+
+// warningsDisabledTxt is the answer to the warnings commands if the warnings storage isn't set.
+const warningsDisabledTxt = "Предупреждения отключены."
+
+// warn warns the author of the replied message and applies the warnings ladder step.
+func (m *Manager) warn(req *request) ([]string, error) {
+	if m.warnings == nil {
+		return []string{warningsDisabledTxt}, nil
+	}
+
+	target, refusal, err := m.moderationTarget(req)
+	if err != nil {
+		return nil, fmt.Errorf("m.moderationTarget: %v", err)
+	}
+
+	if target == nil {
+		return []string{refusal}, nil
+	}
+
+	reason := strings.Join(strings.Fields(req.args), " ")
+
+	return []string{m.warnMember(req.message.Chat, req.message.ReplyToMessage, req.message.From, reason)}, nil
+}
+
+// warnMember warns the author of the message on behalf of the admin and applies the warnings ladder step,
+// it returns the result text. The admin is nil for the warnings given by the bot itself.
+func (m *Manager) warnMember(chat *tgbotapi.Chat, source *tgbotapi.Message, actor *tgbotapi.User, reason string) string {
+	cfg := m.commands.Warnings(chatRef(chat))
+	target := source.From
+
+	var by int64
+	if actor != nil {
+		by = actor.ID
+	}
+
+	list, err := m.warnings.Add(chat.ID, target.ID, warnings.Warning{
+		Reason: reason,
+		By:     by,
+		Date:   m.timeNow(),
+	}, m.warningsSince(cfg))
+	if err != nil {
+		m.log("Add warning",
+			zap.Int64("userID", target.ID),
+			zap.Error(err),
+		)
+
+		return "Не удалось сохранить предупреждение: " + html.EscapeString(err.Error())
+	}
+
+	e := m.auditEvent(audit.ActionWarn, chat, source, actor)
+	e.Reason = reason
+	m.audit(chat, e)
+
+	count := len(list)
+	text := "Пользователь " + mention(target) + " получил предупреждение, всего: " + strconv.Itoa(count) + "." + reasonLine(reason)
+
+	step, ok := cfg.Step(count)
+	if !ok {
+		if next, ok := cfg.Next(count); ok {
+			text += "\nПосле " + strconv.Itoa(next.Count) + " " + warningsGenitive(next.Count) + ": " + stepName(next) + "."
+		}
+
+		return text
+	}
+
+	return text + "\n" + m.applyStep(chat, source, step, count)
+}
+
+// applyStep applies the warnings ladder step to the author of the message and returns the result text.
+// The warnings of the banned user are reset.
+func (m *Manager) applyStep(chat *tgbotapi.Chat, source *tgbotapi.Message, step catalog.Step, count int) string {
+	d := time.Duration(step.Duration)
+	target := source.From
+
+	e := m.auditEvent(audit.ActionBan, chat, source, nil)
+	e.Duration = auditDuration(d)
+	e.Reason = "предупреждений: " + strconv.Itoa(count)
+
+	if step.Action == catalog.ActionMute {
+		text, ok := m.muteMember(chat.ID, target, d)
+		if ok {
+			e.Action = audit.ActionMute
+			m.audit(chat, e)
+		}
+
+		return text
+	}
+
+	text, ok := m.banMember(chat.ID, target, d, false)
+	if !ok {
+		return text
+	}
+
+	m.audit(chat, e)
+
+	if err := m.warnings.Reset(chat.ID, target.ID); err != nil {
+		m.log("Reset warnings",
+			zap.Int64("userID", target.ID),
+			zap.Error(err),
+		)
+	}
+
+	return text
+}
+
+// unwarn removes the last warning of the author of the replied message.
+func (m *Manager) unwarn(req *request) ([]string, error) {
+	if m.warnings == nil {
+		return []string{warningsDisabledTxt}, nil
+	}
+
+	target, refusal, err := m.moderationTarget(req)
+	if err != nil {
+		return nil, fmt.Errorf("m.moderationTarget: %v", err)
+	}
+
+	if target == nil {
+		return []string{refusal}, nil
+	}
+
+	cfg := m.commands.Warnings(chatRef(req.message.Chat))
+
+	list, ok, err := m.warnings.RemoveLast(req.message.Chat.ID, target.ID, m.warningsSince(cfg))
+	if err != nil {
+		m.log("Remove warning",
+			zap.Int64("userID", target.ID),
+			zap.Error(err),
+		)
+
+		return []string{"Не удалось снять предупреждение: " + html.EscapeString(err.Error())}, nil
+	}
+
+	if !ok {
+		return []string{"У пользователя " + mention(target) + " нет предупреждений."}, nil
+	}
+
+	m.audit(req.message.Chat, m.auditEvent(audit.ActionUnwarn, req.message.Chat, req.message.ReplyToMessage, req.message.From))
+
+	return []string{"С пользователя " + mention(target) + " снято последнее предупреждение, осталось: " + strconv.Itoa(len(list)) + "."}, nil
+}
+
+// warns lists the warnings of the author of the replied message.
+func (m *Manager) warns(req *request) ([]string, error) {
+	if m.warnings == nil {
+		return []string{warningsDisabledTxt}, nil
+	}
+
+	target, refusal, err := m.moderationTarget(req)
+	if err != nil {
+		return nil, fmt.Errorf("m.moderationTarget: %v", err)
+	}
+
+	if target == nil {
+		return []string{refusal}, nil
+	}
+
+	cfg := m.commands.Warnings(chatRef(req.message.Chat))
+
+	list := m.warnings.List(req.message.Chat.ID, target.ID, m.warningsSince(cfg))
+	if len(list) == 0 {
+		return []string{"У пользователя " + mention(target) + " нет предупреждений."}, nil
+	}
+
+	var b strings.Builder
+
+	b.WriteString("Предупреждения " + mention(target) + ":")
+
+	for i, w := range list {
+		reason := "без причины"
+		if w.Reason != "" {
+			reason = html.EscapeString(w.Reason)
+		}
+
+		b.WriteString("\n" + strconv.Itoa(i+1) + ". " + w.Date.In(m.timeNow().Location()).Format("02.01.2006 15:04") + " - " + reason)
+	}
+
+	if next, ok := cfg.Next(len(list)); ok {
+		b.WriteString("\nПосле " + strconv.Itoa(next.Count) + " " + warningsGenitive(next.Count) + ": " + stepName(next) + ".")
+	}
+
+	return []string{b.String()}, nil
+}
+
+// warningsSince returns the date after which the warnings are active, zero if the warnings never expire.
+func (m *Manager) warningsSince(cfg *catalog.Warnings) time.Time {
+	if cfg.Expire <= 0 {
+		return time.Time{}
+	}
+
+	return m.timeNow().Add(-time.Duration(cfg.Expire))
+}
+
+// stepName returns the name of the ladder step, e.g. "мут на 1 день" or "бан навсегда".
+func stepName(step catalog.Step) string {
+	name := "бан"
+	if step.Action == catalog.ActionMute {
+		name = "мут"
+	}
+
+	if step.Duration <= 0 {
+		return name + " навсегда"
+	}
+
+	return name + " на " + duration.Format(time.Duration(step.Duration))
+}
+
+// warningsGenitive returns the genitive form of the word "предупреждение" for the number,
+// e.g. "после 1 предупреждения", "после 5 предупреждений".
+func warningsGenitive(n int) string {
+	if n%10 == 1 && n%100 != 11 {
+		return "предупреждения"
+	}
+
+	return "предупреждений"
+}
@@ -0,0 +1,329 @@
+package observer
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/stretchr/testify/assert"
+
+	"geeksonator/internal/catalog"
+	"geeksonator/internal/observer/mocks"
+	"geeksonator/internal/warnings"
+)
+
+// warningsConfig is the warnings configuration of the tests: warnings expire in 30 days,
+// 3 warnings - 1 day mute, 5 warnings - ban.
+func warningsConfig(t *testing.T) *mocks.CommandsMock {
+	t.Helper()
+
+	commands := mocks.NewCommandsMock(t)
+
+	commands.EXPECT().
+		Warnings(catalog.ChatRef{ID: 300600}).
+		Return(&catalog.Warnings{
+			Expire: catalog.Duration(30 * 24 * time.Hour),
+			Ladder: []catalog.Step{
+				{Count: 3, Action: catalog.ActionMute, Duration: catalog.Duration(24 * time.Hour)},
+				{Count: 5, Action: catalog.ActionBan},
+			},
+		})
+
+	return commands
+}
+
+// warningsList returns n warnings of the tests.
+func warningsList(n int) []warnings.Warning {
+	list := make([]warnings.Warning, n)
+	for i := range list {
+		list[i] = warnings.Warning{By: 100500, Date: moderationNow}
+	}
+
+	return list
+}
+
+func TestManager_warn(t *testing.T) {
+	t.Parallel()
+
+	spammer := &tgbotapi.User{ID: 100501, UserName: "spammer"}
+	since := moderationNow.Add(-30 * 24 * time.Hour)
+	warning := warnings.Warning{Reason: "spam", By: 100500, Date: moderationNow}
+
+	tests := []struct {
+		name string
+		man  func() *Manager
+		req  *request
+		want []string
+	}{
+		{
+			name: "Below the ladder",
+			man: func() *Manager {
+				store := mocks.NewWarningsMock(t)
+
+				store.EXPECT().
+					Add(int64(300600), int64(100501), warning, since).
+					Return(warningsList(1), nil)
+
+				return &Manager{
+					cache:    adminsCache(t, 100500),
+					commands: warningsConfig(t),
+					warnings: store,
+					now:      func() time.Time { return moderationNow },
+				}
+			},
+			req: moderationRequest(catalog.HandlerWarn, " spam ", spammer),
+			want: []string{"Пользователь @spammer получил предупреждение, всего: 1.\nПричина: spam" +
+				"\nПосле 3 предупреждений: мут на 1 день."},
+		},
+		{
+			name: "Mute step",
+			man: func() *Manager {
+				botProvider := mocks.NewBotProviderMock(t)
+
+				botProvider.EXPECT().
+					RestrictChatMember(int64(300600), int64(100501), tgbotapi.ChatPermissions{}, moderationNow.Add(24*time.Hour)).
+					Return(nil)
+
+				store := mocks.NewWarningsMock(t)
+
+				store.EXPECT().
+					Add(int64(300600), int64(100501), warning, since).
+					Return(warningsList(3), nil)
+
+				return &Manager{
+					bot:      botProvider,
+					cache:    adminsCache(t, 100500),
+					commands: warningsConfig(t),
+					warnings: store,
+					now:      func() time.Time { return moderationNow },
+				}
+			},
+			req: moderationRequest(catalog.HandlerWarn, "spam", spammer),
+			want: []string{"Пользователь @spammer получил предупреждение, всего: 3.\nПричина: spam" +
+				"\nПользователь @spammer не может писать в чат на 1 день, до 09.03.2024 12:00."},
+		},
+		{
+			name: "Ban step resets warnings",
+			man: func() *Manager {
+				botProvider := mocks.NewBotProviderMock(t)
+
+				botProvider.EXPECT().
+					BanChatMember(int64(300600), int64(100501), time.Time{}, false).
+					Return(nil)
+
+				store := mocks.NewWarningsMock(t)
+
+				store.EXPECT().
+					Add(int64(300600), int64(100501), warning, since).
+					Return(warningsList(5), nil)
+
+				store.EXPECT().
+					Reset(int64(300600), int64(100501)).
+					Return(nil)
+
+				return &Manager{
+					bot:      botProvider,
+					cache:    adminsCache(t, 100500),
+					commands: warningsConfig(t),
+					warnings: store,
+					now:      func() time.Time { return moderationNow },
+				}
+			},
+			req: moderationRequest(catalog.HandlerWarn, "spam", spammer),
+			want: []string{"Пользователь @spammer получил предупреждение, всего: 5.\nПричина: spam" +
+				"\nПользователь @spammer забанен навсегда."},
+		},
+		{
+			name: "Ban error keeps warnings",
+			man: func() *Manager {
+				botProvider := mocks.NewBotProviderMock(t)
+
+				botProvider.EXPECT().
+					BanChatMember(int64(300600), int64(100501), time.Time{}, false).
+					Return(errors.New("not enough rights"))
+
+				store := mocks.NewWarningsMock(t)
+
+				store.EXPECT().
+					Add(int64(300600), int64(100501), warning, since).
+					Return(warningsList(6), nil)
+
+				return &Manager{
+					bot:      botProvider,
+					cache:    adminsCache(t, 100500),
+					commands: warningsConfig(t),
+					warnings: store,
+					now:      func() time.Time { return moderationNow },
+				}
+			},
+			req: moderationRequest(catalog.HandlerWarn, "spam", spammer),
+			want: []string{"Пользователь @spammer получил предупреждение, всего: 6.\nПричина: spam" +
+				"\nНе удалось забанить @spammer: not enough rights"},
+		},
+		{
+			name: "Storage error",
+			man: func() *Manager {
+				store := mocks.NewWarningsMock(t)
+
+				store.EXPECT().
+					Add(int64(300600), int64(100501), warning, since).
+					Return(nil, errors.New("disk full"))
+
+				return &Manager{
+					cache:    adminsCache(t, 100500),
+					commands: warningsConfig(t),
+					warnings: store,
+					now:      func() time.Time { return moderationNow },
+				}
+			},
+			req:  moderationRequest(catalog.HandlerWarn, "spam", spammer),
+			want: []string{"Не удалось сохранить предупреждение: disk full"},
+		},
+		{
+			name: "Not a reply",
+			man: func() *Manager {
+				return &Manager{
+					warnings: mocks.NewWarningsMock(t),
+				}
+			},
+			req:  moderationRequest(catalog.HandlerWarn, "spam", nil),
+			want: []string{"Команда работает только ответом на сообщение."},
+		},
+		{
+			name: "Disabled",
+			man: func() *Manager {
+				return &Manager{}
+			},
+			req:  moderationRequest(catalog.HandlerWarn, "spam", spammer),
+			want: []string{warningsDisabledTxt},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := tt.man().getMessageText(tt.req)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestManager_unwarn(t *testing.T) {
+	t.Parallel()
+
+	spammer := &tgbotapi.User{ID: 100501, UserName: "spammer"}
+	since := moderationNow.Add(-30 * 24 * time.Hour)
+
+	tests := []struct {
+		name string
+		man  func() *Manager
+		want []string
+	}{
+		{
+			name: "Removed",
+			man: func() *Manager {
+				store := mocks.NewWarningsMock(t)
+
+				store.EXPECT().
+					RemoveLast(int64(300600), int64(100501), since).
+					Return(warningsList(1), true, nil)
+
+				return &Manager{
+					cache:    adminsCache(t, 100500),
+					commands: warningsConfig(t),
+					warnings: store,
+					now:      func() time.Time { return moderationNow },
+				}
+			},
+			want: []string{"С пользователя @spammer снято последнее предупреждение, осталось: 1."},
+		},
+		{
+			name: "No warnings",
+			man: func() *Manager {
+				store := mocks.NewWarningsMock(t)
+
+				store.EXPECT().
+					RemoveLast(int64(300600), int64(100501), since).
+					Return(nil, false, nil)
+
+				return &Manager{
+					cache:    adminsCache(t, 100500),
+					commands: warningsConfig(t),
+					warnings: store,
+					now:      func() time.Time { return moderationNow },
+				}
+			},
+			want: []string{"У пользователя @spammer нет предупреждений."},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := tt.man().getMessageText(moderationRequest(catalog.HandlerUnwarn, "", spammer))
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestManager_warns(t *testing.T) {
+	t.Parallel()
+
+	store := mocks.NewWarningsMock(t)
+
+	store.EXPECT().
+		List(int64(300600), int64(100501), time.Time{}).
+		Return([]warnings.Warning{
+			{Reason: "<spam>", By: 100500, Date: moderationNow.Add(-time.Hour)},
+			{By: 100500, Date: moderationNow},
+		})
+
+	commands := mocks.NewCommandsMock(t)
+
+	commands.EXPECT().
+		Warnings(catalog.ChatRef{ID: 300600}).
+		Return(&catalog.Warnings{
+			Ladder: []catalog.Step{
+				{Count: 3, Action: catalog.ActionMute, Duration: catalog.Duration(24 * time.Hour)},
+			},
+		})
+
+	m := &Manager{
+		cache:    adminsCache(t, 100500),
+		commands: commands,
+		warnings: store,
+		now:      func() time.Time { return moderationNow },
+	}
+
+	got, err := m.getMessageText(moderationRequest(catalog.HandlerWarns, "", &tgbotapi.User{ID: 100501, UserName: "spammer"}))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Предупреждения @spammer:" +
+		"\n1. 08.03.2024 11:00 - &lt;spam&gt;" +
+		"\n2. 08.03.2024 12:00 - без причины" +
+		"\nПосле 3 предупреждений: мут на 1 день."}, got)
+}
+
+func Test_warningsGenitive(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		n    int
+		want string
+	}{
+		{n: 1, want: "предупреждения"},
+		{n: 3, want: "предупреждений"},
+		{n: 11, want: "предупреждений"},
+		{n: 21, want: "предупреждения"},
+	}
+	for _, tt := range tests {
+		t.Run(strconv.Itoa(tt.n), func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, warningsGenitive(tt.n))
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package posts
+
+import (
+	"hash/fnv"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// shingleSize is the number of the words in the phrases the texts are compared by.
+const shingleSize = 3
+
+// Fingerprint returns the sorted hashes of the phrases of the text, the case, punctuation and
+// formatting are ignored. The text shorter than a phrase is a single phrase.
+func Fingerprint(text string) []uint32 {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	if len(words) == 0 {
+		return nil
+	}
+
+	n := max(len(words)-shingleSize+1, 1)
+
+	hashes := make([]uint32, 0, n)
+	for i := range n {
+		h := fnv.New32a()
+		_, _ = h.Write([]byte(strings.Join(words[i:min(i+shingleSize, len(words))], " ")))
+
+		hashes = append(hashes, h.Sum32())
+	}
+
+	slices.Sort(hashes)
+
+	return slices.Compact(hashes)
+}
+
+// Similarity returns the share of the common phrases of the fingerprints from 0 to 1.
+func Similarity(a, b []uint32) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+
+	var common int
+
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		switch {
+		case a[i] == b[j]:
+			common++
+			i++
+			j++
+		case a[i] < b[j]:
+			i++
+		default:
+			j++
+		}
+	}
+
+	return float64(common) / float64(len(a)+len(b)-common)
+}
@@ -0,0 +1,64 @@
+package posts
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSimilarity(t *testing.T) {
+	t.Parallel()
+
+	post := "Ищем Senior PHP разработчика в команду платежей, зарплата от 300k, удалённо. Пишите @hr"
+
+	tests := []struct {
+		name string
+		text string
+		want func(t assert.TestingT, similarity float64)
+	}{
+		{
+			name: "Same text in another format",
+			text: "ищем senior php разработчика — в команду платежей!\nЗарплата от 300k; удалённо.\n\nПишите @hr",
+			want: func(t assert.TestingT, similarity float64) {
+				assert.InDelta(t, 1, similarity, 0.001)
+			},
+		},
+		{
+			name: "Small edit",
+			text: "Ищем Senior PHP разработчика в команду платежей, зарплата от 350k, удалённо. Пишите @hr",
+			want: func(t assert.TestingT, similarity float64) {
+				assert.Greater(t, similarity, 0.5)
+				assert.Less(t, similarity, 1.0)
+			},
+		},
+		{
+			name: "Other vacancy",
+			text: "Нужен Go разработчик в офис в Москве, зарплата по договорённости, резюме на jobs@geeks.dev",
+			want: func(t assert.TestingT, similarity float64) {
+				assert.Less(t, similarity, 0.1)
+			},
+		},
+		{
+			name: "Empty text",
+			text: "!!!",
+			want: func(t assert.TestingT, similarity float64) {
+				assert.Zero(t, similarity)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tt.want(t, Similarity(Fingerprint(post), Fingerprint(tt.text)))
+		})
+	}
+}
+
+func TestFingerprint(t *testing.T) {
+	t.Parallel()
+
+	assert.Nil(t, Fingerprint(" ... "))
+	assert.Len(t, Fingerprint("PHP"), 1, "short text is a single phrase")
+	assert.Len(t, Fingerprint("php go php go php go"), 2, "repeated phrases are counted once")
+}
@@ -0,0 +1,101 @@
+package posts
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"geeksonator/pkg/jsonfile"
+)
+
+// Post is the published post of the chat.
+type Post struct {
+	// MessageID is the ID of the message of the post.
+	MessageID int `json:"messageId"`
+	// UserID is the ID of the author.
+	UserID int64 `json:"userId"`
+	// Fingerprint is the fingerprint of the text of the post.
+	Fingerprint []uint32 `json:"fingerprint"`
+	// Date is the date of the post.
+	Date time.Time `json:"date"`
+}
+
+// Store keeps the recent posts of the chats in the JSON file.
+type Store struct {
+	path string
+
+	mu    sync.Mutex
+	posts map[int64][]Post
+}
+
+// NewStore loads the posts from the file, the posts are kept in memory only if the path is empty.
+func NewStore(path string) (*Store, error) {
+	s := &Store{
+		path:  path,
+		posts: map[int64][]Post{},
+	}
+
+	if path == "" {
+		return s, nil
+	}
+
+	if err := jsonfile.Load(path, &s.posts); err != nil {
+		return nil, fmt.Errorf("jsonfile.Load: %v", err)
+	}
+
+	return s, nil
+}
+
+// Add adds the post to the chat, the posts of the chat published before the since date are dropped.
+func (s *Store) Add(chatID int64, post Post, since time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	prev, ok := s.posts[chatID]
+
+	s.posts[chatID] = append(slices.DeleteFunc(slices.Clone(prev), func(p Post) bool {
+		return p.Date.Before(since)
+	}), post)
+
+	if err := s.save(); err != nil {
+		if ok {
+			s.posts[chatID] = prev
+		} else {
+			delete(s.posts, chatID)
+		}
+
+		return fmt.Errorf("s.save: %v", err)
+	}
+
+	return nil
+}
+
+// Recent returns the posts of the chat published after the since date in the order of publication.
+func (s *Store) Recent(chatID int64, since time.Time) []Post {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var recent []Post
+
+	for _, p := range s.posts[chatID] {
+		if p.Date.After(since) {
+			recent = append(recent, p)
+		}
+	}
+
+	return recent
+}
+
+// save writes all the posts into the file.
+func (s *Store) save() error {
+	if s.path == "" {
+		return nil
+	}
+
+	if err := jsonfile.Save(s.path, s.posts); err != nil {
+		return fmt.Errorf("jsonfile.Save: %v", err)
+	}
+
+	return nil
+}
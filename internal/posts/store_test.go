@@ -0,0 +1,34 @@
+package posts
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStore(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "posts.json")
+	now := time.Date(2024, 3, 8, 12, 0, 0, 0, time.UTC)
+	week := 7 * 24 * time.Hour
+
+	old := Post{MessageID: 41, UserID: 100500, Fingerprint: []uint32{1, 2}, Date: now.Add(-10 * 24 * time.Hour)}
+	post := Post{MessageID: 42, UserID: 100501, Fingerprint: []uint32{3, 4}, Date: now.Add(-time.Hour)}
+
+	s, err := NewStore(path)
+	assert.NoError(t, err)
+
+	assert.NoError(t, s.Add(-100300600, old, time.Time{}))
+	assert.NoError(t, s.Add(-100300600, post, now.Add(-week)))
+
+	assert.Equal(t, []Post{post}, s.Recent(-100300600, now.Add(-week)))
+	assert.Empty(t, s.Recent(-100300601, now.Add(-week)))
+
+	loaded, err := NewStore(path)
+	assert.NoError(t, err)
+
+	assert.Equal(t, []Post{post}, loaded.Recent(-100300600, time.Time{}), "posts persist, the old ones are dropped")
+}
@@ -11,4 +11,7 @@ type BotAPI interface {
 
 	// NewMessage creates new message.
 	Send(c tgbotapi.Chattable) (tgbotapi.Message, error)
+
+	// Request sends request for the methods which don't return a message.
+	Request(c tgbotapi.Chattable) (*tgbotapi.APIResponse, error)
 }
@@ -2,6 +2,7 @@ package telegram
 
 import (
 	"fmt"
+	"time"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 )
@@ -46,3 +47,130 @@ func (s *Service) Send(c tgbotapi.Chattable) (tgbotapi.Message, error) {
 
 	return msg, nil
 }
+
+// SetMyCommands sets the list of the bot commands for the scope and the language.
+func (s *Service) SetMyCommands(scope tgbotapi.BotCommandScope, languageCode string, commands []tgbotapi.BotCommand) error {
+	_, err := s.bot.Request(tgbotapi.NewSetMyCommandsWithScopeAndLanguage(scope, languageCode, commands...))
+	if err != nil {
+		return fmt.Errorf("s.bot.Request: %v", err)
+	}
+
+	return nil
+}
+
+// DeleteMyCommands deletes the list of the bot commands for the scope and the language.
+func (s *Service) DeleteMyCommands(scope tgbotapi.BotCommandScope, languageCode string) error {
+	_, err := s.bot.Request(tgbotapi.NewDeleteMyCommandsWithScopeAndLanguage(scope, languageCode))
+	if err != nil {
+		return fmt.Errorf("s.bot.Request: %v", err)
+	}
+
+	return nil
+}
+
+// DeleteMessage deletes message.
+func (s *Service) DeleteMessage(chatID int64, messageID int) error {
+	_, err := s.bot.Request(tgbotapi.NewDeleteMessage(chatID, messageID))
+	if err != nil {
+		return fmt.Errorf("s.bot.Request: %v", err)
+	}
+
+	return nil
+}
+
+// EditMessageText replaces the text of the message with the HTML text and removes its inline keyboard.
+func (s *Service) EditMessageText(chatID int64, messageID int, text string) error {
+	edit := tgbotapi.NewEditMessageText(chatID, messageID, text)
+	edit.ParseMode = "html"
+	edit.DisableWebPagePreview = true
+
+	_, err := s.bot.Request(edit)
+	if err != nil {
+		return fmt.Errorf("s.bot.Request: %v", err)
+	}
+
+	return nil
+}
+
+// AnswerCallbackQuery answers the callback query of the inline button, the text is shown as a notification.
+func (s *Service) AnswerCallbackQuery(callbackID, text string) error {
+	_, err := s.bot.Request(tgbotapi.NewCallback(callbackID, text))
+	if err != nil {
+		return fmt.Errorf("s.bot.Request: %v", err)
+	}
+
+	return nil
+}
+
+// DeclineChatJoinRequest declines the request of the user to join the chat.
+func (s *Service) DeclineChatJoinRequest(chatID, userID int64) error {
+	_, err := s.bot.Request(tgbotapi.DeclineChatJoinRequest{
+		ChatConfig: tgbotapi.ChatConfig{ChatID: chatID},
+		UserID:     userID,
+	})
+	if err != nil {
+		return fmt.Errorf("s.bot.Request: %v", err)
+	}
+
+	return nil
+}
+
+// BanChatMember bans the user in the chat until the date, the zero date bans forever.
+// The revokeMessages flag deletes all messages of the user in the chat.
+func (s *Service) BanChatMember(chatID, userID int64, untilDate time.Time, revokeMessages bool) error {
+	_, err := s.bot.Request(tgbotapi.BanChatMemberConfig{
+		ChatMemberConfig: tgbotapi.ChatMemberConfig{
+			ChatID: chatID,
+			UserID: userID,
+		},
+		UntilDate:      unixTime(untilDate),
+		RevokeMessages: revokeMessages,
+	})
+	if err != nil {
+		return fmt.Errorf("s.bot.Request: %v", err)
+	}
+
+	return nil
+}
+
+// UnbanChatMember unbans the user in the chat, members who aren't banned are left in the chat.
+func (s *Service) UnbanChatMember(chatID, userID int64) error {
+	_, err := s.bot.Request(tgbotapi.UnbanChatMemberConfig{
+		ChatMemberConfig: tgbotapi.ChatMemberConfig{
+			ChatID: chatID,
+			UserID: userID,
+		},
+		OnlyIfBanned: true,
+	})
+	if err != nil {
+		return fmt.Errorf("s.bot.Request: %v", err)
+	}
+
+	return nil
+}
+
+// RestrictChatMember sets the permissions of the user in the chat until the date, the zero date restricts forever.
+func (s *Service) RestrictChatMember(chatID, userID int64, permissions tgbotapi.ChatPermissions, untilDate time.Time) error {
+	_, err := s.bot.Request(tgbotapi.RestrictChatMemberConfig{
+		ChatMemberConfig: tgbotapi.ChatMemberConfig{
+			ChatID: chatID,
+			UserID: userID,
+		},
+		UntilDate:   unixTime(untilDate),
+		Permissions: &permissions,
+	})
+	if err != nil {
+		return fmt.Errorf("s.bot.Request: %v", err)
+	}
+
+	return nil
+}
+
+// unixTime returns the unix time of the date, zero for the zero date.
+func unixTime(date time.Time) int64 {
+	if date.IsZero() {
+		return 0
+	}
+
+	return date.Unix()
+}
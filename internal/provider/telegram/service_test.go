@@ -1,10 +1,13 @@
 package telegram
 
 import (
+	"errors"
 	"testing"
+	"time"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 
 	"geeksonator/internal/provider/telegram/mocks"
 )
@@ -178,3 +181,284 @@ func TestService_Send(t *testing.T) {
 		})
 	}
 }
+
+func TestService_SetMyCommands(t *testing.T) {
+	t.Parallel()
+
+	type args struct {
+		scope        tgbotapi.BotCommandScope
+		languageCode string
+		commands     []tgbotapi.BotCommand
+	}
+	tests := []struct {
+		name    string
+		srv     func() *Service
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Success",
+			srv: func() *Service {
+				bot := mocks.NewBotAPIMock(t)
+
+				scope := tgbotapi.NewBotCommandScopeAllChatAdministrators()
+
+				bot.EXPECT().
+					Request(
+						tgbotapi.SetMyCommandsConfig{
+							Commands: []tgbotapi.BotCommand{
+								{Command: "go", Description: "Go"},
+							},
+							Scope:        &scope,
+							LanguageCode: "en",
+						},
+					).
+					Return(&tgbotapi.APIResponse{Ok: true}, nil)
+
+				return &Service{
+					bot: bot,
+				}
+			},
+			args: args{
+				scope:        tgbotapi.NewBotCommandScopeAllChatAdministrators(),
+				languageCode: "en",
+				commands: []tgbotapi.BotCommand{
+					{Command: "go", Description: "Go"},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Error",
+			srv: func() *Service {
+				bot := mocks.NewBotAPIMock(t)
+
+				bot.EXPECT().
+					Request(mock.Anything).
+					Return(nil, errors.New("Bad Request: invalid command description"))
+
+				return &Service{
+					bot: bot,
+				}
+			},
+			args: args{
+				scope: tgbotapi.NewBotCommandScopeDefault(),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tt.srv().SetMyCommands(tt.args.scope, tt.args.languageCode, tt.args.commands)
+			assert.Equal(t, tt.wantErr, err != nil)
+		})
+	}
+}
+
+func TestService_DeleteMyCommands(t *testing.T) {
+	t.Parallel()
+
+	bot := mocks.NewBotAPIMock(t)
+
+	scope := tgbotapi.NewBotCommandScopeChat(-100500)
+
+	bot.EXPECT().
+		Request(
+			tgbotapi.DeleteMyCommandsConfig{
+				Scope: &scope,
+			},
+		).
+		Return(&tgbotapi.APIResponse{Ok: true}, nil)
+
+	srv := &Service{
+		bot: bot,
+	}
+
+	assert.NoError(t, srv.DeleteMyCommands(tgbotapi.NewBotCommandScopeChat(-100500), ""))
+}
+
+func TestService_DeleteMessage(t *testing.T) {
+	t.Parallel()
+
+	bot := mocks.NewBotAPIMock(t)
+
+	bot.EXPECT().
+		Request(
+			tgbotapi.DeleteMessageConfig{
+				ChatID:    100500,
+				MessageID: 42,
+			},
+		).
+		Return(&tgbotapi.APIResponse{Ok: true}, nil)
+
+	srv := &Service{
+		bot: bot,
+	}
+
+	assert.NoError(t, srv.DeleteMessage(100500, 42))
+}
+
+func TestService_EditMessageText(t *testing.T) {
+	t.Parallel()
+
+	bot := mocks.NewBotAPIMock(t)
+
+	bot.EXPECT().
+		Request(
+			tgbotapi.EditMessageTextConfig{
+				BaseEdit: tgbotapi.BaseEdit{
+					ChatID:    300600,
+					MessageID: 42,
+				},
+				Text:                  "Жалоба отклонена.",
+				ParseMode:             "html",
+				DisableWebPagePreview: true,
+			},
+		).
+		Return(&tgbotapi.APIResponse{Ok: true}, nil)
+
+	srv := &Service{
+		bot: bot,
+	}
+
+	assert.NoError(t, srv.EditMessageText(300600, 42, "Жалоба отклонена."))
+}
+
+func TestService_AnswerCallbackQuery(t *testing.T) {
+	t.Parallel()
+
+	bot := mocks.NewBotAPIMock(t)
+
+	bot.EXPECT().
+		Request(
+			tgbotapi.CallbackConfig{
+				CallbackQueryID: "100500",
+				Text:            "Добро пожаловать!",
+			},
+		).
+		Return(nil, errors.New("query is too old"))
+
+	srv := &Service{
+		bot: bot,
+	}
+
+	assert.Error(t, srv.AnswerCallbackQuery("100500", "Добро пожаловать!"))
+}
+
+func TestService_DeclineChatJoinRequest(t *testing.T) {
+	t.Parallel()
+
+	bot := mocks.NewBotAPIMock(t)
+
+	bot.EXPECT().
+		Request(
+			tgbotapi.DeclineChatJoinRequest{
+				ChatConfig: tgbotapi.ChatConfig{ChatID: 300600},
+				UserID:     100500,
+			},
+		).
+		Return(&tgbotapi.APIResponse{Ok: true}, nil)
+
+	srv := &Service{
+		bot: bot,
+	}
+
+	assert.NoError(t, srv.DeclineChatJoinRequest(300600, 100500))
+}
+
+func TestService_BanChatMember(t *testing.T) {
+	t.Parallel()
+
+	untilDate := time.Date(2024, 3, 9, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		untilDate      time.Time
+		revokeMessages bool
+		want           tgbotapi.BanChatMemberConfig
+	}{
+		{
+			name:           "Temporary",
+			untilDate:      untilDate,
+			revokeMessages: false,
+			want: tgbotapi.BanChatMemberConfig{
+				ChatMemberConfig: tgbotapi.ChatMemberConfig{ChatID: 300600, UserID: 100500},
+				UntilDate:        untilDate.Unix(),
+			},
+		},
+		{
+			name:           "Forever with revoke",
+			untilDate:      time.Time{},
+			revokeMessages: true,
+			want: tgbotapi.BanChatMemberConfig{
+				ChatMemberConfig: tgbotapi.ChatMemberConfig{ChatID: 300600, UserID: 100500},
+				UntilDate:        0,
+				RevokeMessages:   true,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			bot := mocks.NewBotAPIMock(t)
+
+			bot.EXPECT().
+				Request(tt.want).
+				Return(&tgbotapi.APIResponse{Ok: true}, nil)
+
+			srv := &Service{
+				bot: bot,
+			}
+
+			assert.NoError(t, srv.BanChatMember(300600, 100500, tt.untilDate, tt.revokeMessages))
+		})
+	}
+}
+
+func TestService_UnbanChatMember(t *testing.T) {
+	t.Parallel()
+
+	bot := mocks.NewBotAPIMock(t)
+
+	bot.EXPECT().
+		Request(
+			tgbotapi.UnbanChatMemberConfig{
+				ChatMemberConfig: tgbotapi.ChatMemberConfig{ChatID: 300600, UserID: 100500},
+				OnlyIfBanned:     true,
+			},
+		).
+		Return(&tgbotapi.APIResponse{Ok: true}, nil)
+
+	srv := &Service{
+		bot: bot,
+	}
+
+	assert.NoError(t, srv.UnbanChatMember(300600, 100500))
+}
+
+func TestService_RestrictChatMember(t *testing.T) {
+	t.Parallel()
+
+	untilDate := time.Date(2024, 3, 9, 12, 0, 0, 0, time.UTC)
+
+	bot := mocks.NewBotAPIMock(t)
+
+	bot.EXPECT().
+		Request(
+			tgbotapi.RestrictChatMemberConfig{
+				ChatMemberConfig: tgbotapi.ChatMemberConfig{ChatID: 300600, UserID: 100500},
+				UntilDate:        untilDate.Unix(),
+				Permissions:      &tgbotapi.ChatPermissions{},
+			},
+		).
+		Return(&tgbotapi.APIResponse{Ok: true}, nil)
+
+	srv := &Service{
+		bot: bot,
+	}
+
+	assert.NoError(t, srv.RestrictChatMember(300600, 100500, tgbotapi.ChatPermissions{}, untilDate))
+}
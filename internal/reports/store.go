@@ -0,0 +1,109 @@
+package reports
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+
+	"geeksonator/pkg/jsonfile"
+)
+
+// Store keeps the admins subscribed to the reports of the chats in the JSON file.
+type Store struct {
+	path string
+
+	mu          sync.Mutex
+	subscribers map[int64][]int64
+}
+
+// NewStore loads the subscribers from the file, the subscribers are kept in memory only if the path is empty.
+func NewStore(path string) (*Store, error) {
+	s := &Store{
+		path:        path,
+		subscribers: map[int64][]int64{},
+	}
+
+	if path == "" {
+		return s, nil
+	}
+
+	if err := jsonfile.Load(path, &s.subscribers); err != nil {
+		return nil, fmt.Errorf("jsonfile.Load: %v", err)
+	}
+
+	return s, nil
+}
+
+// Subscribe subscribes the admin to the reports of the chat.
+func (s *Store) Subscribe(chatID, userID int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	users := s.subscribers[chatID]
+	if slices.Contains(users, userID) {
+		return nil
+	}
+
+	if err := s.save(chatID, append(slices.Clone(users), userID)); err != nil {
+		return fmt.Errorf("s.save: %v", err)
+	}
+
+	return nil
+}
+
+// Unsubscribe unsubscribes the admin from the reports of the chat.
+func (s *Store) Unsubscribe(chatID, userID int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	users := s.subscribers[chatID]
+	if !slices.Contains(users, userID) {
+		return nil
+	}
+
+	users = slices.DeleteFunc(slices.Clone(users), func(id int64) bool {
+		return id == userID
+	})
+
+	if err := s.save(chatID, users); err != nil {
+		return fmt.Errorf("s.save: %v", err)
+	}
+
+	return nil
+}
+
+// Subscribers returns the admins subscribed to the reports of the chat.
+func (s *Store) Subscribers(chatID int64) []int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return slices.Clone(s.subscribers[chatID])
+}
+
+// save replaces the chat subscribers and writes all the subscribers into the file.
+// The previous subscribers are restored if the file can't be written.
+func (s *Store) save(chatID int64, users []int64) error {
+	prev, ok := s.subscribers[chatID]
+
+	if len(users) == 0 {
+		delete(s.subscribers, chatID)
+	} else {
+		s.subscribers[chatID] = users
+	}
+
+	if s.path == "" {
+		return nil
+	}
+
+	if err := jsonfile.Save(s.path, s.subscribers); err != nil {
+		if ok {
+			s.subscribers[chatID] = prev
+		} else {
+			delete(s.subscribers, chatID)
+		}
+
+		return fmt.Errorf("jsonfile.Save: %v", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,36 @@
+package reports
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStore(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "reports.json")
+
+	s, err := NewStore(path)
+	assert.NoError(t, err)
+
+	assert.NoError(t, s.Subscribe(-100500, 42))
+	assert.NoError(t, s.Subscribe(-100500, 42), "repeated subscription")
+	assert.NoError(t, s.Subscribe(-100500, 43))
+	assert.NoError(t, s.Unsubscribe(-100500, 44), "not subscribed")
+
+	assert.Equal(t, []int64{42, 43}, s.Subscribers(-100500))
+	assert.Empty(t, s.Subscribers(-100501), "subscriptions are per chat")
+
+	loaded, err := NewStore(path)
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{42, 43}, loaded.Subscribers(-100500), "subscriptions persist")
+
+	assert.NoError(t, loaded.Unsubscribe(-100500, 42))
+	assert.NoError(t, loaded.Unsubscribe(-100500, 43))
+
+	loaded, err = NewStore(path)
+	assert.NoError(t, err)
+	assert.Empty(t, loaded.Subscribers(-100500), "unsubscription persists")
+}
@@ -0,0 +1,7 @@
+package scheduler
+
+// Deleter interface for telegram messages deletion.
+type Deleter interface {
+	// DeleteMessage deletes message.
+	DeleteMessage(chatID int64, messageID int) error
+}
@@ -0,0 +1,75 @@
+// Code generated by mockery v2.36.0. DO NOT EDIT.
+
+package mocks
+
+import mock "github.com/stretchr/testify/mock"
+
+// DeleterMock is an autogenerated mock type for the Deleter type
+type DeleterMock struct {
+	mock.Mock
+}
+
+type DeleterMock_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *DeleterMock) EXPECT() *DeleterMock_Expecter {
+	return &DeleterMock_Expecter{mock: &_m.Mock}
+}
+
+// DeleteMessage provides a mock function with given fields: chatID, messageID
+func (_m *DeleterMock) DeleteMessage(chatID int64, messageID int) error {
+	ret := _m.Called(chatID, messageID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int64, int) error); ok {
+		r0 = rf(chatID, messageID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleterMock_DeleteMessage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteMessage'
+type DeleterMock_DeleteMessage_Call struct {
+	*mock.Call
+}
+
+// DeleteMessage is a helper method to define mock.On call
+//   - chatID int64
+//   - messageID int
+func (_e *DeleterMock_Expecter) DeleteMessage(chatID interface{}, messageID interface{}) *DeleterMock_DeleteMessage_Call {
+	return &DeleterMock_DeleteMessage_Call{Call: _e.mock.On("DeleteMessage", chatID, messageID)}
+}
+
+func (_c *DeleterMock_DeleteMessage_Call) Run(run func(chatID int64, messageID int)) *DeleterMock_DeleteMessage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int64), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *DeleterMock_DeleteMessage_Call) Return(_a0 error) *DeleterMock_DeleteMessage_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *DeleterMock_DeleteMessage_Call) RunAndReturn(run func(int64, int) error) *DeleterMock_DeleteMessage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewDeleterMock creates a new instance of DeleterMock. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewDeleterMock(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *DeleterMock {
+	mock := &DeleterMock{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
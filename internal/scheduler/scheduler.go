@@ -0,0 +1,192 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"geeksonator/pkg/jsonfile"
+)
+
+// Deletion is the scheduled message deletion.
+type Deletion struct {
+	ChatID    int64     `json:"chatId"`
+	MessageID int       `json:"messageId"`
+	At        time.Time `json:"at"`
+}
+
+// Scheduler deletes the messages at the scheduled time. The pending deletions are kept
+// in the JSON file, so they survive restarts.
+type Scheduler struct {
+	path    string
+	deleter Deleter
+	logger  *zap.Logger
+	now     func() time.Time
+
+	mu      sync.Mutex
+	pending []Deletion
+	wake    chan struct{}
+}
+
+// NewScheduler loads the pending deletions from the file, they are kept in memory only if the path is empty.
+func NewScheduler(path string, deleter Deleter, logger *zap.Logger) (*Scheduler, error) {
+	s := &Scheduler{
+		path:    path,
+		deleter: deleter,
+		logger:  logger.Named("scheduler"),
+		now:     time.Now,
+		wake:    make(chan struct{}, 1),
+	}
+
+	if path == "" {
+		return s, nil
+	}
+
+	if err := jsonfile.Load(path, &s.pending); err != nil {
+		return nil, fmt.Errorf("jsonfile.Load: %v", err)
+	}
+
+	return s, nil
+}
+
+// Schedule schedules the deletion of the message.
+func (s *Scheduler) Schedule(chatID int64, messageID int, at time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.pending = append(s.pending, Deletion{
+		ChatID:    chatID,
+		MessageID: messageID,
+		At:        at,
+	})
+
+	if err := s.save(); err != nil {
+		s.pending = s.pending[:len(s.pending)-1]
+
+		return fmt.Errorf("s.save: %v", err)
+	}
+
+	select {
+	case s.wake <- struct{}{}:
+	default: // the scheduler is already woken up
+	}
+
+	return nil
+}
+
+// Cancel cancels the scheduled deletions of the message, e.g. the message is already deleted.
+func (s *Scheduler) Cancel(chatID int64, messageID int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	prev := s.pending
+
+	s.pending = slices.DeleteFunc(slices.Clone(s.pending), func(d Deletion) bool {
+		return d.ChatID == chatID && d.MessageID == messageID
+	})
+
+	if len(s.pending) == len(prev) {
+		return nil
+	}
+
+	if err := s.save(); err != nil {
+		s.pending = prev
+
+		return fmt.Errorf("s.save: %v", err)
+	}
+
+	return nil
+}
+
+// Run deletes the messages at the scheduled time until the context is canceled.
+// The deletions overdue during the downtime are done at once.
+func (s *Scheduler) Run(ctx context.Context) {
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		var timerC <-chan time.Time
+		if next, ok := s.deleteDue(); ok {
+			timer.Reset(next.Sub(s.now()))
+			timerC = timer.C
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.wake:
+		case <-timerC:
+		}
+	}
+}
+
+// deleteDue deletes the due messages and returns the time of the next deletion, false if there's none.
+// The failed deletions aren't retried, e.g. the message may be already deleted by an admin.
+func (s *Scheduler) deleteDue() (time.Time, bool) {
+	now := s.now()
+
+	s.mu.Lock()
+	var due []Deletion
+	for _, d := range s.pending {
+		if !d.At.After(now) {
+			due = append(due, d)
+		}
+	}
+	s.mu.Unlock()
+
+	for _, d := range due {
+		if err := s.deleter.DeleteMessage(d.ChatID, d.MessageID); err != nil {
+			s.logger.Warn("Scheduled deletion failed",
+				zap.Int64("chatID", d.ChatID),
+				zap.Int("messageID", d.MessageID),
+				zap.Error(err),
+			)
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var next time.Time
+
+	pending := s.pending[:0]
+	for _, d := range s.pending {
+		if !d.At.After(now) {
+			continue
+		}
+
+		pending = append(pending, d)
+
+		if next.IsZero() || d.At.Before(next) {
+			next = d.At
+		}
+	}
+	s.pending = pending
+
+	if len(due) > 0 {
+		if err := s.save(); err != nil {
+			s.logger.Error("Save pending deletions",
+				zap.Error(err),
+			)
+		}
+	}
+
+	return next, !next.IsZero()
+}
+
+// save writes the pending deletions into the file.
+func (s *Scheduler) save() error {
+	if s.path == "" {
+		return nil
+	}
+
+	if err := jsonfile.Save(s.path, s.pending); err != nil {
+		return fmt.Errorf("jsonfile.Save: %v", err)
+	}
+
+	return nil
+}
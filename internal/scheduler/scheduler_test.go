@@ -0,0 +1,115 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"geeksonator/internal/scheduler/mocks"
+)
+
+func TestScheduler_deleteDue(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "cleanup.json")
+	now := time.Date(2024, 3, 8, 12, 0, 0, 0, time.UTC)
+
+	deleter := mocks.NewDeleterMock(t)
+
+	deleter.EXPECT().
+		DeleteMessage(int64(-100500), 41).
+		Return(nil)
+
+	deleter.EXPECT().
+		DeleteMessage(int64(-100500), 42).
+		Return(errors.New("message to delete not found"))
+
+	s, err := NewScheduler(path, deleter, zap.NewNop())
+	assert.NoError(t, err)
+	s.now = func() time.Time { return now }
+
+	assert.NoError(t, s.Schedule(-100500, 41, now.Add(-time.Minute)))
+	assert.NoError(t, s.Schedule(-100500, 42, now))
+	assert.NoError(t, s.Schedule(-100500, 44, now.Add(time.Hour)))
+	assert.NoError(t, s.Schedule(-100500, 43, now.Add(time.Minute)))
+
+	next, ok := s.deleteDue()
+	assert.True(t, ok)
+	assert.Equal(t, now.Add(time.Minute), next)
+
+	loaded, err := NewScheduler(path, deleter, zap.NewNop())
+	assert.NoError(t, err)
+	assert.Equal(t, []Deletion{
+		{ChatID: -100500, MessageID: 44, At: now.Add(time.Hour)},
+		{ChatID: -100500, MessageID: 43, At: now.Add(time.Minute)},
+	}, loaded.pending, "failed deletions aren't retried")
+}
+
+func TestScheduler_Cancel(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "cleanup.json")
+	now := time.Date(2024, 3, 8, 12, 0, 0, 0, time.UTC)
+
+	s, err := NewScheduler(path, mocks.NewDeleterMock(t), zap.NewNop())
+	assert.NoError(t, err)
+
+	assert.NoError(t, s.Schedule(-100500, 41, now))
+	assert.NoError(t, s.Schedule(-100500, 42, now))
+	assert.NoError(t, s.Schedule(-100501, 41, now))
+
+	assert.NoError(t, s.Cancel(-100500, 41))
+	assert.NoError(t, s.Cancel(-100500, 41), "nothing to cancel")
+
+	loaded, err := NewScheduler(path, mocks.NewDeleterMock(t), zap.NewNop())
+	assert.NoError(t, err)
+	assert.Equal(t, []Deletion{
+		{ChatID: -100500, MessageID: 42, At: now},
+		{ChatID: -100501, MessageID: 41, At: now},
+	}, loaded.pending)
+}
+
+func TestScheduler_Run(t *testing.T) {
+	t.Parallel()
+
+	deleted := make(chan int, 1)
+
+	deleter := mocks.NewDeleterMock(t)
+
+	deleter.EXPECT().
+		DeleteMessage(int64(-100500), 42).
+		RunAndReturn(func(_ int64, messageID int) error {
+			deleted <- messageID
+
+			return nil
+		})
+
+	s, err := NewScheduler("", deleter, zap.NewNop())
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+
+		s.Run(ctx)
+	}()
+
+	assert.NoError(t, s.Schedule(-100500, 42, time.Now().Add(10*time.Millisecond)))
+
+	select {
+	case messageID := <-deleted:
+		assert.Equal(t, 42, messageID)
+	case <-time.After(time.Second):
+		t.Error("message isn't deleted")
+	}
+
+	cancel()
+	<-done
+}
@@ -0,0 +1,157 @@
+package warnings
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"geeksonator/pkg/jsonfile"
+)
+
+// Warning is the warning of the chat member.
+type Warning struct {
+	// Reason is the free text reason, may be empty.
+	Reason string `json:"reason"`
+	// By is the ID of the admin who warned the member.
+	By int64 `json:"by"`
+	// Date is the date of the warning.
+	Date time.Time `json:"date"`
+}
+
+// Store keeps the warnings of the chat members in the JSON file.
+type Store struct {
+	path string
+
+	mu       sync.Mutex
+	warnings map[string][]Warning
+}
+
+// NewStore loads the warnings from the file, the warnings are kept in memory only if the path is empty.
+func NewStore(path string) (*Store, error) {
+	s := &Store{
+		path:     path,
+		warnings: map[string][]Warning{},
+	}
+
+	if path == "" {
+		return s, nil
+	}
+
+	if err := jsonfile.Load(path, &s.warnings); err != nil {
+		return nil, fmt.Errorf("jsonfile.Load: %v", err)
+	}
+
+	return s, nil
+}
+
+// Add adds the warning to the member and returns the member warnings given after the since date.
+func (s *Store) Add(chatID, userID int64, w Warning, since time.Time) ([]Warning, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	k := key(chatID, userID)
+	list := append(active(s.warnings[k], since), w)
+
+	if err := s.save(k, list); err != nil {
+		return nil, fmt.Errorf("s.save: %v", err)
+	}
+
+	return clone(list), nil
+}
+
+// RemoveLast removes the last member warning given after the since date and returns the rest of them.
+// False is returned if the member has no warnings.
+func (s *Store) RemoveLast(chatID, userID int64, since time.Time) ([]Warning, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	k := key(chatID, userID)
+
+	list := active(s.warnings[k], since)
+	if len(list) == 0 {
+		return nil, false, nil
+	}
+
+	list = list[:len(list)-1]
+
+	if err := s.save(k, list); err != nil {
+		return nil, false, fmt.Errorf("s.save: %v", err)
+	}
+
+	return clone(list), true, nil
+}
+
+// List returns the member warnings given after the since date.
+func (s *Store) List(chatID, userID int64, since time.Time) []Warning {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return clone(active(s.warnings[key(chatID, userID)], since))
+}
+
+// Reset removes all the member warnings.
+func (s *Store) Reset(chatID, userID int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.save(key(chatID, userID), nil); err != nil {
+		return fmt.Errorf("s.save: %v", err)
+	}
+
+	return nil
+}
+
+// save replaces the member warnings and writes all the warnings into the file.
+// The previous warnings are restored if the file can't be written.
+func (s *Store) save(k string, list []Warning) error {
+	prev, ok := s.warnings[k]
+
+	if len(list) == 0 {
+		delete(s.warnings, k)
+	} else {
+		s.warnings[k] = list
+	}
+
+	if s.path == "" {
+		return nil
+	}
+
+	if err := jsonfile.Save(s.path, s.warnings); err != nil {
+		if ok {
+			s.warnings[k] = prev
+		} else {
+			delete(s.warnings, k)
+		}
+
+		return fmt.Errorf("jsonfile.Save: %v", err)
+	}
+
+	return nil
+}
+
+// key returns the key of the member warnings, e.g. -100500:42.
+func key(chatID, userID int64) string {
+	return strconv.FormatInt(chatID, 10) + ":" + strconv.FormatInt(userID, 10)
+}
+
+// active returns the warnings given after the since date, all of them if the date is zero.
+func active(list []Warning, since time.Time) []Warning {
+	result := make([]Warning, 0, len(list)+1)
+	for _, w := range list {
+		if since.IsZero() || w.Date.After(since) {
+			result = append(result, w)
+		}
+	}
+
+	return result
+}
+
+// clone returns the copy of the warnings, so the caller can't change the stored ones.
+func clone(list []Warning) []Warning {
+	if len(list) == 0 {
+		return nil
+	}
+
+	return append([]Warning(nil), list...)
+}
@@ -0,0 +1,86 @@
+package warnings
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStore(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "warnings.json")
+	now := time.Date(2024, 3, 8, 12, 0, 0, 0, time.UTC)
+
+	s, err := NewStore(path)
+	assert.NoError(t, err)
+
+	old := Warning{Reason: "flood", By: 1, Date: now.Add(-48 * time.Hour)}
+	first := Warning{Reason: "spam", By: 1, Date: now.Add(-time.Hour)}
+	second := Warning{By: 2, Date: now}
+
+	list, err := s.Add(-100500, 42, old, time.Time{})
+	assert.NoError(t, err)
+	assert.Equal(t, []Warning{old}, list)
+
+	since := now.Add(-24 * time.Hour)
+
+	list, err = s.Add(-100500, 42, first, since)
+	assert.NoError(t, err)
+	assert.Equal(t, []Warning{first}, list, "expired warning is dropped")
+
+	list, err = s.Add(-100500, 42, second, since)
+	assert.NoError(t, err)
+	assert.Equal(t, []Warning{first, second}, list)
+
+	assert.Empty(t, s.List(-100501, 42, since), "warnings are per chat")
+
+	loaded, err := NewStore(path)
+	assert.NoError(t, err)
+	assert.Equal(t, []Warning{first, second}, loaded.List(-100500, 42, since), "warnings persist")
+
+	list, ok, err := loaded.RemoveLast(-100500, 42, since)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, []Warning{first}, list)
+
+	assert.NoError(t, loaded.Reset(-100500, 42))
+	assert.Empty(t, loaded.List(-100500, 42, time.Time{}))
+
+	_, ok, err = loaded.RemoveLast(-100500, 42, since)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	loaded, err = NewStore(path)
+	assert.NoError(t, err)
+	assert.Empty(t, loaded.List(-100500, 42, time.Time{}), "reset persists")
+}
+
+func TestStore_InMemory(t *testing.T) {
+	t.Parallel()
+
+	s, err := NewStore("")
+	assert.NoError(t, err)
+
+	w := Warning{Reason: "spam", Date: time.Date(2024, 3, 8, 12, 0, 0, 0, time.UTC)}
+
+	list, err := s.Add(-100500, 42, w, time.Time{})
+	assert.NoError(t, err)
+	assert.Equal(t, []Warning{w}, list)
+
+	list[0].Reason = "changed"
+	assert.Equal(t, []Warning{w}, s.List(-100500, 42, time.Time{}))
+}
+
+func TestNewStore_InvalidFile(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "warnings.json")
+	assert.NoError(t, os.WriteFile(path, []byte(`{"-100500:42": [`), 0o600))
+
+	_, err := NewStore(path)
+	assert.Error(t, err)
+}
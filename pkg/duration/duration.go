@@ -0,0 +1,118 @@
+package duration
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+const (
+	day  = 24 * time.Hour
+	week = 7 * day
+)
+
+var ErrInvalid = errors.New("invalid duration")
+
+// units are the duration units by their suffixes.
+var units = map[string]time.Duration{ //nolint:gochecknoglobals // it's a constant map
+	"s": time.Second,
+	"m": time.Minute,
+	"h": time.Hour,
+	"d": day,
+	"w": week,
+	"с": time.Second,
+	"м": time.Minute,
+	"ч": time.Hour,
+	"д": day,
+	"н": week,
+}
+
+// Parse parses the duration of one or more numbers with units, e.g. 10s, 30m, 2h, 1d12h or 1w.
+// The units are s (seconds), m (minutes), h (hours), d (days) and w (weeks), the Russian units are
+// с (секунды), м (минуты), ч (часы), д (дни) and н (недели), e.g. 30м, 2ч or 1д.
+func Parse(s string) (time.Duration, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if s == "" {
+		return 0, ErrInvalid
+	}
+
+	var total time.Duration
+	for s != "" {
+		digits := strings.IndexFunc(s, func(r rune) bool { return !unicode.IsDigit(r) })
+		if digits <= 0 {
+			return 0, fmt.Errorf("%w: %q", ErrInvalid, s)
+		}
+
+		n, err := strconv.Atoi(s[:digits])
+		if err != nil {
+			return 0, fmt.Errorf("%w: %v", ErrInvalid, err)
+		}
+
+		s = s[digits:]
+
+		end := strings.IndexFunc(s, unicode.IsDigit)
+		if end == -1 {
+			end = len(s)
+		}
+
+		unit, ok := units[s[:end]]
+		if !ok {
+			return 0, fmt.Errorf("%w: unknown unit %q", ErrInvalid, s[:end])
+		}
+
+		if time.Duration(n) > (math.MaxInt64-total)/unit {
+			return 0, fmt.Errorf("%w: too long", ErrInvalid)
+		}
+
+		total += time.Duration(n) * unit
+		s = s[end:]
+	}
+
+	return total, nil
+}
+
+// Format formats the duration in Russian with days, hours, minutes and seconds, e.g. "1 день 2 часа".
+func Format(d time.Duration) string {
+	d = d.Round(time.Second)
+
+	parts := make([]string, 0, 4)
+	for _, u := range []struct {
+		unit  time.Duration
+		forms [3]string
+	}{
+		{unit: day, forms: [3]string{"день", "дня", "дней"}},
+		{unit: time.Hour, forms: [3]string{"час", "часа", "часов"}},
+		{unit: time.Minute, forms: [3]string{"минута", "минуты", "минут"}},
+		{unit: time.Second, forms: [3]string{"секунда", "секунды", "секунд"}},
+	} {
+		n := int(d / u.unit)
+		if n == 0 {
+			continue
+		}
+
+		d -= time.Duration(n) * u.unit
+		parts = append(parts, strconv.Itoa(n)+" "+plural(n, u.forms))
+	}
+
+	if len(parts) == 0 {
+		return "0 минут"
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// plural returns the Russian plural form of the noun for the number: one, few or many.
+func plural(n int, forms [3]string) string {
+	switch {
+	case n%10 == 1 && n%100 != 11:
+		return forms[0]
+	case n%10 >= 2 && n%10 <= 4 && (n%100 < 12 || n%100 > 14):
+		return forms[1]
+	default:
+		return forms[2]
+	}
+}
@@ -0,0 +1,142 @@
+package duration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		s       string
+		want    time.Duration
+		wantErr error
+	}{
+		{
+			name:    "Seconds",
+			s:       "10s",
+			want:    10 * time.Second,
+			wantErr: nil,
+		},
+		{
+			name:    "Minutes",
+			s:       "30m",
+			want:    30 * time.Minute,
+			wantErr: nil,
+		},
+		{
+			name:    "Hours",
+			s:       "2h",
+			want:    2 * time.Hour,
+			wantErr: nil,
+		},
+		{
+			name:    "Days",
+			s:       "1D",
+			want:    24 * time.Hour,
+			wantErr: nil,
+		},
+		{
+			name:    "Weeks",
+			s:       "1w",
+			want:    7 * 24 * time.Hour,
+			wantErr: nil,
+		},
+		{
+			name:    "Combined",
+			s:       "1d12h",
+			want:    36 * time.Hour,
+			wantErr: nil,
+		},
+		{
+			name:    "Russian units",
+			s:       "1Д2ч30м",
+			want:    26*time.Hour + 30*time.Minute,
+			wantErr: nil,
+		},
+		{
+			name:    "Russian weeks",
+			s:       "2н",
+			want:    14 * 24 * time.Hour,
+			wantErr: nil,
+		},
+		{
+			name:    "Empty",
+			s:       "",
+			want:    0,
+			wantErr: ErrInvalid,
+		},
+		{
+			name:    "No unit",
+			s:       "30",
+			want:    0,
+			wantErr: ErrInvalid,
+		},
+		{
+			name:    "Unknown unit",
+			s:       "30y",
+			want:    0,
+			wantErr: ErrInvalid,
+		},
+		{
+			name:    "Overflow",
+			s:       "99999999999w",
+			want:    0,
+			wantErr: ErrInvalid,
+		},
+		{
+			name:    "Overflow of the sum",
+			s:       "15250w15250w",
+			want:    0,
+			wantErr: ErrInvalid,
+		},
+		{
+			name:    "Not a duration",
+			s:       "spam",
+			want:    0,
+			wantErr: ErrInvalid,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := Parse(tt.s)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestFormat(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		d    time.Duration
+		want string
+	}{
+		{d: 0, want: "0 минут"},
+		{d: 10 * time.Second, want: "10 секунд"},
+		{d: time.Minute, want: "1 минута"},
+		{d: 90 * time.Second, want: "1 минута 30 секунд"},
+		{d: 30 * time.Minute, want: "30 минут"},
+		{d: 2 * time.Hour, want: "2 часа"},
+		{d: 11 * time.Hour, want: "11 часов"},
+		{d: 21 * time.Hour, want: "21 час"},
+		{d: 24 * time.Hour, want: "1 день"},
+		{d: 36*time.Hour + 5*time.Minute, want: "1 день 12 часов 5 минут"},
+		{d: 22 * 24 * time.Hour, want: "22 дня"},
+		{d: 366 * 24 * time.Hour, want: "366 дней"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, Format(tt.d))
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package jsonfile
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// filePerm is the permission of the saved files.
+const filePerm = 0o600
+
+// Load reads the JSON file into v, v is left unchanged if the file doesn't exist.
+func Load(path string, v any) error {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("os.ReadFile: %v", err)
+	}
+
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("json.Unmarshal: %v", err)
+	}
+
+	return nil
+}
+
+// Save writes v into the JSON file. The data is written into a temporary file which replaces
+// the file, so the file is never left half-written.
+func Save(path string, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("json.Marshal: %v", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("os.CreateTemp: %v", err)
+	}
+	defer os.Remove(tmp.Name()) //nolint:errcheck // the file is already renamed on success
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close() //nolint:errcheck,gosec // the write error is returned
+
+		return fmt.Errorf("tmp.Write: %v", err)
+	}
+
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("tmp.Close: %v", err)
+	}
+
+	if err := os.Chmod(tmp.Name(), filePerm); err != nil {
+		return fmt.Errorf("os.Chmod: %v", err)
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("os.Rename: %v", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,51 @@
+package jsonfile
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSaveLoad(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "data.json")
+
+	type data struct {
+		Name  string `json:"name"`
+		Count int    `json:"count"`
+	}
+
+	got := data{Name: "default"}
+	assert.NoError(t, Load(path, &got))
+	assert.Equal(t, data{Name: "default"}, got)
+
+	assert.NoError(t, Save(path, data{Name: "warnings", Count: 2}))
+	assert.NoError(t, Save(path, data{Name: "warnings", Count: 3}))
+
+	assert.NoError(t, Load(path, &got))
+	assert.Equal(t, data{Name: "warnings", Count: 3}, got)
+
+	entries, err := os.ReadDir(filepath.Dir(path))
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
+
+func TestLoad_Invalid(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "data.json")
+	assert.NoError(t, os.WriteFile(path, []byte("{"), 0o600))
+
+	var got map[string]int
+	assert.Error(t, Load(path, &got))
+}
+
+func TestSave_NoDir(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "missing", "data.json")
+	assert.Error(t, Save(path, 1))
+}
@@ -0,0 +1,52 @@
+package layout
+
+import (
+	"strings"
+	"unicode"
+)
+
+const (
+	// qwerty and jcuken are the keys of the US and the Russian keyboard layouts in the same order.
+	qwerty = "`qwertyuiop[]asdfghjkl;'zxcvbnm,./" + `~QWERTYUIOP{}ASDFGHJKL:"ZXCVBNM<>?`
+	jcuken = "ёйцукенгшщзхъфывапролджэячсмитьбю." + "ЁЙЦУКЕНГШЩЗХЪФЫВАПРОЛДЖЭЯЧСМИТЬБЮ,"
+)
+
+var (
+	toJCUKEN = keymap(qwerty, jcuken) //nolint:gochecknoglobals // it's a constant map
+	toQWERTY = keymap(jcuken, qwerty) //nolint:gochecknoglobals // it's a constant map
+)
+
+// Switch converts the text typed in the wrong keyboard layout: the text with cyrillic letters
+// is converted from ЙЦУКЕН to QWERTY, any other text is converted from QWERTY to ЙЦУКЕН.
+// Characters without a key in the layout are kept as is.
+func Switch(text string) string {
+	keys := toJCUKEN
+	if strings.IndexFunc(text, isCyrillic) != -1 {
+		keys = toQWERTY
+	}
+
+	return strings.Map(func(r rune) rune {
+		if switched, ok := keys[r]; ok {
+			return switched
+		}
+
+		return r
+	}, text)
+}
+
+// isCyrillic returns true if the rune is a cyrillic letter.
+func isCyrillic(r rune) bool {
+	return unicode.Is(unicode.Cyrillic, r)
+}
+
+// keymap maps the characters of the keys from one layout to another.
+func keymap(from, to string) map[rune]rune {
+	fromKeys, toKeys := []rune(from), []rune(to)
+
+	m := make(map[rune]rune, len(fromKeys))
+	for i, r := range fromKeys {
+		m[r] = toKeys[i]
+	}
+
+	return m
+}
@@ -0,0 +1,65 @@
+package layout
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSwitch(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{
+			name: "ЙЦУКЕН to QWERTY",
+			text: "зрз",
+			want: "php",
+		},
+		{
+			name: "QWERTY to ЙЦУКЕН",
+			text: "kfhf",
+			want: "лара",
+		},
+		{
+			name: "Punctuation keys",
+			text: ",l",
+			want: "бд",
+		},
+		{
+			name: "Upper case",
+			text: "ПЩ",
+			want: "GO",
+		},
+		{
+			name: "Digits and underscore are kept",
+			text: "ощи_2",
+			want: "job_2",
+		},
+		{
+			name: "Empty",
+			text: "",
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, Switch(tt.text))
+		})
+	}
+}
+
+func TestKeymap(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, len([]rune(qwerty)), len([]rune(jcuken)))
+
+	for r, switched := range toJCUKEN {
+		assert.Equal(t, r, toQWERTY[switched], string(r))
+	}
+}